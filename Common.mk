@@ -93,6 +93,7 @@ REPO_NO_CLONE?=false
 PATCHES_DIR=$(or $(wildcard $(PROJECT_ROOT)/patches),$(wildcard $(MAKE_ROOT)/patches))
 HELM_PATCHES_DIR=$(or $(wildcard $(PROJECT_ROOT)/helm/patches),$(wildcard $(MAKE_ROOT)/helm/patches))
 REPO_SPARSE_CHECKOUT?=
+REPO_CLONE_FILTER?=
 ####################################################
 
 #################### RELEASE BRANCHES ##############
@@ -454,6 +455,10 @@ IMAGE_FORMAT?=
 IMAGE_OS?=
 UPLOAD_DO_NOT_DELETE?=false
 UPLOAD_CREATE_PUBLIC_ACL?=true
+# Upload artifacts in CI with tools/artifacts-uploader, which verifies the digest of every uploaded artifact,
+# instead of the AWS CLI.
+USE_ARTIFACTS_UPLOADER?=$(if $(filter true,$(CODEBUILD_CI) $(CI)),true,false)
+ARTIFACTS_UPLOADER_BIN?=$(BASE_DIRECTORY)/tools/artifacts-uploader/bin/artifacts-uploader
 ####################################################
 
 #################### OTHER #########################
@@ -583,7 +588,7 @@ ifneq ($(REPO_SPARSE_CHECKOUT),)
 	source $(BUILD_LIB)/common.sh && retry git clone --quiet --depth 1 --filter=blob:none --sparse -b $(GIT_TAG) $(CLONE_URL) $(REPO)
 	git -C $(REPO) sparse-checkout set $(REPO_SPARSE_CHECKOUT) --cone --skip-checks
 else
-	source $(BUILD_LIB)/common.sh && retry git clone --quiet $(CLONE_URL) $(REPO)
+	source $(BUILD_LIB)/common.sh && retry git clone --quiet $(if $(REPO_CLONE_FILTER),--filter=$(REPO_CLONE_FILTER),) $(CLONE_URL) $(REPO)
 endif
 	@echo -e $(call TARGET_END_LOG)
 endif
@@ -598,9 +603,7 @@ $(GIT_CHECKOUT_TARGET): | $(REPO)
 
 $(GIT_PATCH_TARGET): $(GIT_CHECKOUT_TARGET)
 	@echo -e $(call TARGET_START_LOG)
-	git -C $(REPO) config user.email prow@amazonaws.com
-	git -C $(REPO) config user.name "Prow Bot"
-	if [ -n "$(PATCHES_DIR)" ]; then git -C $(REPO) am --committer-date-is-author-date $(PATCHES_DIR)/*; fi
+	if [ -n "$(PATCHES_DIR)" ]; then git -C $(REPO) -c user.email=prow@amazonaws.com -c user.name="Prow Bot" am --committer-date-is-author-date $(PATCHES_DIR)/*; fi
 	@touch $@
 	@echo -e $(call TARGET_END_LOG)
 
@@ -634,9 +637,7 @@ endif
 
 $(HELM_GIT_PATCH_TARGET): $(HELM_GIT_CHECKOUT_TARGET)
 	@echo -e $(call TARGET_START_LOG)
-	git -C $(HELM_SOURCE_REPOSITORY) config user.email prow@amazonaws.com
-	git -C $(HELM_SOURCE_REPOSITORY) config user.name "Prow Bot"
-	if [ -n "$(HELM_PATCHES_DIR)" ]; then git -C $(HELM_SOURCE_REPOSITORY) am --committer-date-is-author-date $(HELM_PATCHES_DIR)/*; fi
+	if [ -n "$(HELM_PATCHES_DIR)" ]; then git -C $(HELM_SOURCE_REPOSITORY) -c user.email=prow@amazonaws.com -c user.name="Prow Bot" am --committer-date-is-author-date $(HELM_PATCHES_DIR)/*; fi
 	@touch $@
 	@echo -e $(call TARGET_END_LOG)
 
@@ -735,8 +736,11 @@ ifeq ($(SIMPLE_CREATE_TARBALLS),true)
 endif
 
 .PHONY: upload-artifacts
-upload-artifacts: s3-artifacts upload-output-to-prow-artifacts-s3-artifacts | $$(ENABLE_LOGGING)
-	@$(BUILD_LIB)/upload_artifacts.sh $(ARTIFACTS_PATH) $(ARTIFACTS_BUCKET) $(ARTIFACTS_UPLOAD_PATH) $(BUILD_IDENTIFIER) $(GIT_HASH) $(LATEST) $(UPLOAD_DRY_RUN) $(UPLOAD_DO_NOT_DELETE) $(UPLOAD_CREATE_PUBLIC_ACL)
+upload-artifacts: s3-artifacts upload-output-to-prow-artifacts-s3-artifacts $(if $(filter true,$(USE_ARTIFACTS_UPLOADER)),$(ARTIFACTS_UPLOADER_BIN),) | $$(ENABLE_LOGGING)
+	@$(if $(filter true,$(USE_ARTIFACTS_UPLOADER)),ARTIFACTS_UPLOADER=$(ARTIFACTS_UPLOADER_BIN) ,)$(BUILD_LIB)/upload_artifacts.sh $(ARTIFACTS_PATH) $(ARTIFACTS_BUCKET) $(ARTIFACTS_UPLOAD_PATH) $(BUILD_IDENTIFIER) $(GIT_HASH) $(LATEST) $(UPLOAD_DRY_RUN) $(UPLOAD_DO_NOT_DELETE) $(UPLOAD_CREATE_PUBLIC_ACL)
+
+$(ARTIFACTS_UPLOADER_BIN):
+	$(MAKE) -C $(BASE_DIRECTORY)/tools/artifacts-uploader build BINARY_PATH=$@ GOLANG_VERSION=$(shell cat $(BASE_DIRECTORY)/tools/artifacts-uploader/GOLANG_VERSION)
 
 .PHONY: s3-artifacts
 s3-artifacts: tarballs
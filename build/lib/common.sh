@@ -127,7 +127,24 @@ function build::common::upload_artifacts() {
       public_acl="--acl public-read"
     fi
     
-    if [ "$dry_run" = "true" ]; then
+    if [ -n "${ARTIFACTS_UPLOADER:-}" ]; then
+      # Use the artifacts-uploader tool, which retries failed uploads, tags each object with its
+      # sha256 digest and skips artifacts which have already been uploaded with the same digest
+      local uploader_args=()
+      if [ "$create_public_acl" = "true" ]; then
+        uploader_args+=(--public-acl)
+      fi
+      if [ "$dry_run" = "true" ]; then
+        uploader_args+=(--dry-run)
+      fi
+
+      build::common::echo_and_run $ARTIFACTS_UPLOADER upload --source-dir "$artifactspath" --destination "$artifactsbucket"/"$projectpath"/"$buildidentifier"-"$githash"/artifacts ${uploader_args[@]+"${uploader_args[@]}"}
+
+      if [ "$do_not_delete" != "true" ]; then
+        uploader_args+=(--delete)
+      fi
+      build::common::echo_and_run $ARTIFACTS_UPLOADER upload --source-dir "$artifactspath" --destination "$artifactsbucket"/"$projectpath"/"$latesttag" ${uploader_args[@]+"${uploader_args[@]}"}
+    elif [ "$dry_run" = "true" ]; then
       build::common::echo_and_run aws s3 cp "$artifactspath" "$artifactsbucket"/"$projectpath"/"$buildidentifier"-"$githash"/artifacts --recursive --dryrun
       build::common::echo_and_run aws s3 cp "$artifactspath" "$artifactsbucket"/"$projectpath"/"$latesttag" --recursive --dryrun
     else
@@ -23,6 +23,8 @@ _output/1-26/dependencies/linux-amd64/eksa/containerd/containerd: ## Fetch `_out
 _output/1-26/dependencies/linux-arm64/eksa/containerd/containerd: ## Fetch `_output/1-26/dependencies/linux-arm64/eksa/containerd/containerd`
 _output/1-26/dependencies/linux-amd64/eksa/kubernetes-sigs/cri-tools: ## Fetch `_output/1-26/dependencies/linux-amd64/eksa/kubernetes-sigs/cri-tools`
 _output/1-26/dependencies/linux-arm64/eksa/kubernetes-sigs/cri-tools: ## Fetch `_output/1-26/dependencies/linux-arm64/eksa/kubernetes-sigs/cri-tools`
+_output/1-26/dependencies/linux-amd64/eksa/kubernetes/cloud-provider-aws: ## Fetch `_output/1-26/dependencies/linux-amd64/eksa/kubernetes/cloud-provider-aws`
+_output/1-26/dependencies/linux-arm64/eksa/kubernetes/cloud-provider-aws: ## Fetch `_output/1-26/dependencies/linux-arm64/eksa/kubernetes/cloud-provider-aws`
 
 ##@ Run in Docker Targets
 run-in-docker/all-attributions: ## Run `all-attributions` in docker builder container
@@ -0,0 +1,87 @@
+#!/usr/bin/env bash
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+BINARY_DEPS_DIR="$1"
+OUTPUT_DIR="$2"
+IMAGE_PLATFORMS="$3"
+SIGNING_KEY="$4"
+REQUIRE_SIGNATURE="$5"
+SHIPPED_SYSTEMD_UNITS_FILE="$6"
+
+# Prints the checksums of the systemd units and drop-ins under etc/systemd/system in the artifacts of
+# the components in the directory, with their paths relative to the systemd unit directory.
+function build::upgrader::systemd_units_checksums() {
+  local -r bin_dir=$1
+
+  local unit_file
+  while IFS= read -r -d '' unit_file; do
+    echo "$(sha256sum "$unit_file" | cut -d' ' -f1)  ${unit_file#*/etc/systemd/system/}"
+  done < <(find "$bin_dir" -path '*/etc/systemd/system/*' -type f -print0)
+}
+
+# Lays out the replacement binaries the way upgrade.sh expects them in the upgrader image, records
+# the version of the artifact layout, which upgrade.sh resolves artifact paths with, the checksums of
+# the systemd units shipped by this and previous builds, which upgrade.sh treats units on the node
+# matching as its own, and the checksums of the binaries, which upgrade.sh verifies before installing
+# them. When a signing key is given, the checksums file is signed with cosign and the public key is
+# shipped next to the signature, so that nodes can verify the binaries come from this pipeline and not
+# only that they match checksums shipped alongside them. Nodes refuse checksums with a signature that
+# does not verify, and can be provisioned with the public key to not trust the one in the image.
+function build::upgrader::stage_binaries() {
+  local -r platform=$1
+  local -r deps_dir="${BINARY_DEPS_DIR}/${platform}"
+  local -r bin_dir="${OUTPUT_DIR}/${platform}"
+
+  rm -rf "$bin_dir"
+  mkdir -p "${bin_dir}/containerd/usr/local/bin" "${bin_dir}/cni-plugins/opt/cni/bin" "${bin_dir}/kubernetes/usr/bin" "${bin_dir}/credential-provider"
+
+  cp -r "${deps_dir}/eksa/containerd/containerd/." "${bin_dir}/containerd"
+  cp -r "${deps_dir}/eksa/kubernetes-sigs/cri-tools/." "${bin_dir}/containerd/usr/local/bin"
+  cp -r "${deps_dir}/eksd/cni-plugins/." "${bin_dir}/cni-plugins/opt/cni/bin"
+  cp -r "${deps_dir}/eksd/kubernetes/client/bin/." "${bin_dir}/kubernetes/usr/bin"
+  cp "${deps_dir}/eksd/kubernetes/server/bin/kubelet" "${deps_dir}/eksd/kubernetes/server/bin/kubeadm" "${bin_dir}/kubernetes/usr/bin"
+  cp "${deps_dir}/eksa/kubernetes/cloud-provider-aws/ecr-credential-provider" "${bin_dir}/credential-provider"
+
+  echo 1 > "${bin_dir}/layout-version"
+  {
+    cat "$SHIPPED_SYSTEMD_UNITS_FILE"
+    build::upgrader::systemd_units_checksums "$bin_dir"
+  } | sort -u -k2,2 -k1,1 > "${bin_dir}/shipped-systemd-units.sha256"
+  local -r checksums=$(cd "$bin_dir" && find . -type f -printf '%P\0' | sort -z | xargs -0 sha256sum)
+  echo "$checksums" > "${bin_dir}/SHA256SUMS"
+
+  if [ -z "$SIGNING_KEY" ]; then
+    if [ "$REQUIRE_SIGNATURE" = "true" ]; then
+      echo "UPGRADE_ARTIFACTS_SIGNING_KEY must be set to sign the checksums of the upgrader binaries"
+      exit 1
+    fi
+    echo "UPGRADE_ARTIFACTS_SIGNING_KEY is not set, the checksums of the ${platform} upgrader binaries are not signed and nodes with REQUIRE_SIGNED_ARTIFACTS=true will refuse them"
+    return
+  fi
+  if ! command -v cosign &> /dev/null; then
+    echo "cosign is required to sign the checksums of the upgrader binaries"
+    exit 1
+  fi
+  cosign sign-blob --yes --key "$SIGNING_KEY" --output-signature "${bin_dir}/SHA256SUMS.sig" "${bin_dir}/SHA256SUMS"
+  cosign public-key --key "$SIGNING_KEY" --outfile "${bin_dir}/SHA256SUMS.pub"
+}
+
+for platform in ${IMAGE_PLATFORMS//,/ }; do
+  build::upgrader::stage_binaries "${platform/\//-}"
+done
@@ -0,0 +1,132 @@
+#!/usr/bin/env bash
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Tests that upgrade.sh validates image pulls with the credentials returned by the image credential provider
+# without passing them on the crictl command line, where they would show up in the process list. The
+# credential provider and crictl are replaced with stubs, and the crictl stub records how it was called. The
+# validation runs in a subshell, since it turns tracing back on when it is done with the credentials.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd -P)"
+UPGRADE_SCRIPT="${SCRIPT_ROOT}/../upgrade.sh"
+
+TEST_DIR=$(mktemp -d)
+trap 'rm -rf "$TEST_DIR"' EXIT
+
+# The functions of upgrade.sh are sourced without the dispatch at the end of the script.
+sed '/^while \[\[ "\${1:-}" == --\*=\* \]\]; do/,$d' "$UPGRADE_SCRIPT" > "${TEST_DIR}/functions.sh"
+source "${TEST_DIR}/functions.sh"
+{ set +x; } 2> /dev/null
+
+export CREDENTIAL_PROVIDER_VALIDATION_IMAGE="public.ecr.aws/eks-anywhere/pause:3.9"
+export TMPDIR="${TEST_DIR}/tmp"
+export PATH="${TEST_DIR}/bin:${PATH}"
+
+FAILURES=0
+
+function test::setup() {
+  local -r crictl_exit_code=$1
+
+  rm -rf "${TEST_DIR}/bin" "${TEST_DIR}/providers" "${TEST_DIR}/tmp" "${TEST_DIR}/calls"
+  mkdir -p "${TEST_DIR}/bin" "${TEST_DIR}/providers" "${TEST_DIR}/tmp"
+
+  cat > "${TEST_DIR}/config.yaml" <<EOF
+apiVersion: kubelet.config.k8s.io/v1
+kind: CredentialProviderConfig
+providers:
+  - name: ecr-credential-provider
+    matchImages:
+      - "*.ecr.aws"
+    apiVersion: credentialprovider.kubelet.k8s.io/v1
+EOF
+
+  cat > "${TEST_DIR}/providers/ecr-credential-provider" <<'EOF'
+#!/usr/bin/env bash
+cat > /dev/null
+echo '{"kind":"CredentialProviderResponse","auth":{"public.ecr.aws":{"username":"AWS","password":"s3cr3t-token"}}}'
+EOF
+  chmod +x "${TEST_DIR}/providers/ecr-credential-provider"
+
+  cat > "${TEST_DIR}/bin/crictl" <<EOF
+#!/usr/bin/env bash
+{
+  printf 'arg=%s\n' "\$@"
+  echo "CRICTL_AUTH=\${CRICTL_AUTH:-}"
+} > "${TEST_DIR}/calls"
+exit ${crictl_exit_code}
+EOF
+  chmod +x "${TEST_DIR}/bin/crictl"
+}
+
+function test::assert_credentials_not_leaked() {
+  local -r test_name=$1
+
+  if grep -qF "s3cr3t-token" "${TEST_DIR}/calls"; then
+    echo "FAIL ${test_name}: credentials passed on the crictl command line"
+    cat "${TEST_DIR}/calls"
+    FAILURES=$((FAILURES + 1))
+    return 1
+  fi
+  if ! grep -qxF "CRICTL_AUTH=$(printf 'AWS:s3cr3t-token' | base64 -w 0)" "${TEST_DIR}/calls"; then
+    echo "FAIL ${test_name}: credentials not passed to crictl in CRICTL_AUTH"
+    cat "${TEST_DIR}/calls"
+    FAILURES=$((FAILURES + 1))
+    return 1
+  fi
+  if [ -n "$(ls -A "${TEST_DIR}/tmp")" ]; then
+    echo "FAIL ${test_name}: auth file left behind in ${TEST_DIR}/tmp"
+    FAILURES=$((FAILURES + 1))
+    return 1
+  fi
+}
+
+function test::pull_with_credentials_in_environment() {
+  test::setup 0
+  if ! (validate_credential_provider_pull "${TEST_DIR}/config.yaml" "${TEST_DIR}/providers") > "${TEST_DIR}/output" 2>&1; then
+    echo "FAIL ${FUNCNAME[0]}: image pull validation failed"
+    cat "${TEST_DIR}/output"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  if ! grep -qxF "arg=${CREDENTIAL_PROVIDER_VALIDATION_IMAGE}" "${TEST_DIR}/calls"; then
+    echo "FAIL ${FUNCNAME[0]}: crictl did not pull ${CREDENTIAL_PROVIDER_VALIDATION_IMAGE}"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  test::assert_credentials_not_leaked "${FUNCNAME[0]}" || return 0
+  echo "PASS ${FUNCNAME[0]}"
+}
+
+function test::failed_pull_removes_auth_file() {
+  test::setup 1
+  if (validate_credential_provider_pull "${TEST_DIR}/config.yaml" "${TEST_DIR}/providers") > "${TEST_DIR}/output" 2>&1; then
+    echo "FAIL ${FUNCNAME[0]}: image pull validation succeeded although crictl failed"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  test::assert_credentials_not_leaked "${FUNCNAME[0]}" || return 0
+  echo "PASS ${FUNCNAME[0]}"
+}
+
+test::pull_with_credentials_in_environment
+test::failed_pull_removes_auth_file
+
+if [ "$FAILURES" -gt 0 ]; then
+  echo "${FAILURES} test(s) failed"
+  exit 1
+fi
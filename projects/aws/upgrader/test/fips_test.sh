@@ -0,0 +1,131 @@
+#!/usr/bin/env bash
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Tests that upgrade.sh selects the FIPS builds of the artifacts on nodes that require FIPS, and that
+# nodes whose kernel runs in FIPS mode refuse upgrade bundles without FIPS builds with FIPS_MODE=auto
+# instead of silently installing the regular builds.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd -P)"
+UPGRADE_SCRIPT="${SCRIPT_ROOT}/../upgrade.sh"
+
+TEST_DIR=$(mktemp -d)
+trap 'rm -rf "$TEST_DIR"' EXIT
+
+# The functions of upgrade.sh are sourced without the dispatch at the end of the script.
+sed '/^while \[\[ "\${1:-}" == --\*=\* \]\]; do/,$d' "$UPGRADE_SCRIPT" > "${TEST_DIR}/functions.sh"
+source "${TEST_DIR}/functions.sh"
+{ set +x; } 2> /dev/null
+
+function upgrade_components_bin_dir() {
+  echo "${TEST_DIR}/binaries"
+}
+
+export UPGRADER_STATE_DIR="${TEST_DIR}/state"
+export FIPS_ENABLED_FILE="${TEST_DIR}/fips_enabled"
+
+FAILURES=0
+
+# Creates an upgrade bundle, with FIPS builds if the argument is true, on a node whose kernel runs in
+# FIPS mode if the second argument is 1.
+function test::setup() {
+  local -r fips_builds=$1
+  local -r fips_enabled=$2
+
+  rm -rf "${TEST_DIR}/binaries" "${TEST_DIR}/state"
+  mkdir -p "${TEST_DIR}/binaries/kubernetes/usr/bin"
+  echo 1 > "${TEST_DIR}/binaries/layout-version"
+  echo "kubeadm" > "${TEST_DIR}/binaries/kubernetes/usr/bin/kubeadm"
+  if [ "$fips_builds" = "true" ]; then
+    mkdir -p "${TEST_DIR}/binaries/fips/kubernetes/usr/bin"
+    echo "kubeadm" > "${TEST_DIR}/binaries/fips/kubernetes/usr/bin/kubeadm"
+  fi
+  echo "$fips_enabled" > "$FIPS_ENABLED_FILE"
+}
+
+function test::assert_artifact_path() {
+  local -r test_name=$1
+  local -r fips_mode=$2
+  local -r expected_path=$3
+
+  local path
+  if ! path=$(FIPS_MODE=$fips_mode bundle_artifact_path kubeadm 2> /dev/null); then
+    echo "FAIL ${test_name}: no artifact path"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  if [ "$path" != "$expected_path" ]; then
+    echo "FAIL ${test_name}: artifact path is ${path}, expected ${expected_path}"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  echo "PASS ${test_name}"
+}
+
+function test::assert_refused() {
+  local -r test_name=$1
+  local -r fips_mode=$2
+
+  if FIPS_MODE=$fips_mode bundle_artifact_path kubeadm > /dev/null 2>&1; then
+    echo "FAIL ${test_name}: regular build selected on a node that requires FIPS"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  if ! grep -qF '"event":"fips-verification","artifact":"kubeadm","result":"failed"' "$(upgrade_status_file)" 2> /dev/null; then
+    echo "FAIL ${test_name}: expected a failed FIPS verification record"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  echo "PASS ${test_name}"
+}
+
+function test::auto_mode_refuses_bundle_without_fips_builds_on_fips_host() {
+  test::setup false 1
+  test::assert_refused "${FUNCNAME[0]}" auto
+}
+
+function test::forced_mode_refuses_bundle_without_fips_builds() {
+  test::setup false 0
+  test::assert_refused "${FUNCNAME[0]}" true
+}
+
+function test::auto_mode_selects_fips_builds_on_fips_host() {
+  test::setup true 1
+  test::assert_artifact_path "${FUNCNAME[0]}" auto "${TEST_DIR}/binaries/fips/kubernetes/usr/bin/kubeadm"
+}
+
+function test::auto_mode_selects_regular_builds_on_other_hosts() {
+  test::setup true 0
+  test::assert_artifact_path "${FUNCNAME[0]}" auto "${TEST_DIR}/binaries/kubernetes/usr/bin/kubeadm"
+}
+
+function test::disabled_mode_selects_regular_builds_on_fips_host() {
+  test::setup false 1
+  test::assert_artifact_path "${FUNCNAME[0]}" false "${TEST_DIR}/binaries/kubernetes/usr/bin/kubeadm"
+}
+
+test::auto_mode_refuses_bundle_without_fips_builds_on_fips_host
+test::forced_mode_refuses_bundle_without_fips_builds
+test::auto_mode_selects_fips_builds_on_fips_host
+test::auto_mode_selects_regular_builds_on_other_hosts
+test::disabled_mode_selects_regular_builds_on_fips_host
+
+if [ "$FAILURES" -gt 0 ]; then
+  echo "${FAILURES} test(s) failed"
+  exit 1
+fi
@@ -0,0 +1,86 @@
+#!/usr/bin/env bash
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Tests that the failure bundle of a failed upgrade step holds the output the step printed right before
+# it failed. tee is replaced with a stub that starts copying late, and the failure bundle collection with
+# a stub that keeps the phase log, so the test fails if the log is read before the tee processes are done.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd -P)"
+UPGRADE_SCRIPT="${SCRIPT_ROOT}/../upgrade.sh"
+
+TEST_DIR=$(mktemp -d)
+trap 'rm -rf "$TEST_DIR"' EXIT
+
+# The functions of upgrade.sh are sourced without the dispatch at the end of the script.
+sed '/^while \[\[ "\${1:-}" == --\*=\* \]\]; do/,$d' "$UPGRADE_SCRIPT" > "${TEST_DIR}/functions.sh"
+
+mkdir -p "${TEST_DIR}/bin"
+cat > "${TEST_DIR}/bin/tee" <<'EOF'
+#!/usr/bin/env bash
+sleep 1
+exec /usr/bin/tee "$@"
+EOF
+chmod +x "${TEST_DIR}/bin/tee"
+
+FAILURES=0
+
+# Runs a step that prints to stdout and stderr and then fails, in its own shell since run_phase
+# redirects the output of the shell and collects the failure bundle when it exits.
+function test::run_failing_step() {
+  PATH="${TEST_DIR}/bin:${PATH}" UPGRADER_STATE_DIR="${TEST_DIR}/state" UPGRADE_CLOUDWATCH_METRICS=false TEST_DIR="$TEST_DIR" \
+    bash -c '
+      source "${TEST_DIR}/functions.sh"
+      { set +x; } 2> /dev/null
+      collect_failure_bundle() {
+        cp "$PHASE_LOG_FILE" "${TEST_DIR}/upgrader.log"
+      }
+      failing_step() {
+        echo "last line on stdout"
+        echo "last line on stderr" >&2
+        return 1
+      }
+      run_phase failing_step
+    ' > "${TEST_DIR}/output" 2>&1
+}
+
+function test::failure_bundle_includes_last_output() {
+  if test::run_failing_step; then
+    echo "FAIL ${FUNCNAME[0]}: failing step succeeded"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+
+  local line
+  for line in "last line on stdout" "last line on stderr"; do
+    if ! grep -qxF "$line" "${TEST_DIR}/upgrader.log" 2> /dev/null; then
+      echo "FAIL ${FUNCNAME[0]}: expected '${line}' in the phase log of the failure bundle"
+      cat "${TEST_DIR}/output"
+      FAILURES=$((FAILURES + 1))
+      return
+    fi
+  done
+  echo "PASS ${FUNCNAME[0]}"
+}
+
+test::failure_bundle_includes_last_output
+
+if [ "$FAILURES" -gt 0 ]; then
+  echo "${FAILURES} test(s) failed"
+  exit 1
+fi
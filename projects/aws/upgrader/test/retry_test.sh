@@ -0,0 +1,83 @@
+#!/usr/bin/env bash
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Tests that retry keeps its messages out of the output of the retried command, which upgrade.sh
+# captures with command substitutions, for example to back up the kubeadm and CoreDNS configuration.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd -P)"
+UPGRADE_SCRIPT="${SCRIPT_ROOT}/../upgrade.sh"
+
+TEST_DIR=$(mktemp -d)
+trap 'rm -rf "$TEST_DIR"' EXIT
+
+# The functions of upgrade.sh are sourced without the dispatch at the end of the script.
+sed '/^while \[\[ "\${1:-}" == --\*=\* \]\]; do/,$d' "$UPGRADE_SCRIPT" > "${TEST_DIR}/functions.sh"
+source "${TEST_DIR}/functions.sh"
+{ set +x; } 2> /dev/null
+
+FAILURES=0
+
+# Fails the first time it is called and prints the configuration afterwards.
+function test::flaky_command() {
+  if [ ! -f "${TEST_DIR}/attempted" ]; then
+    touch "${TEST_DIR}/attempted"
+    return 1
+  fi
+  echo "apiVersion: kubeadm.k8s.io/v1beta3"
+}
+
+function test::captured_output_excludes_retry_messages() {
+  local output
+  output=$(UPGRADE_RETRY_DELAY_SECONDS=0 retry test::flaky_command 2> "${TEST_DIR}/stderr")
+
+  if [ "$output" != "apiVersion: kubeadm.k8s.io/v1beta3" ]; then
+    echo "FAIL ${FUNCNAME[0]}: captured output is '${output}'"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  if ! grep -qF "'test::flaky_command' failed, retrying" "${TEST_DIR}/stderr"; then
+    echo "FAIL ${FUNCNAME[0]}: retry message not written to stderr"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  echo "PASS ${FUNCNAME[0]}"
+}
+
+function test::captured_output_excludes_failure_message() {
+  local output
+  if output=$(UPGRADE_MAX_RETRIES=1 UPGRADE_RETRY_DELAY_SECONDS=0 retry false 2> /dev/null); then
+    echo "FAIL ${FUNCNAME[0]}: retry of a failing command succeeded"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  if [ -n "$output" ]; then
+    echo "FAIL ${FUNCNAME[0]}: captured output is '${output}'"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  echo "PASS ${FUNCNAME[0]}"
+}
+
+test::captured_output_excludes_retry_messages
+test::captured_output_excludes_failure_message
+
+if [ "$FAILURES" -gt 0 ]; then
+  echo "${FAILURES} test(s) failed"
+  exit 1
+fi
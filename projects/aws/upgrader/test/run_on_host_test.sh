@@ -0,0 +1,124 @@
+#!/usr/bin/env bash
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Tests that upgrade.sh re-executes itself on the host with nsenter or chroot when it runs in a pod,
+# with the host access settings read from the upgrader config file. nsenter and chroot are replaced
+# with stubs that record how they were called instead of entering the host.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd -P)"
+UPGRADE_SCRIPT="${SCRIPT_ROOT}/../upgrade.sh"
+
+TEST_DIR=$(mktemp -d)
+trap 'rm -rf "$TEST_DIR"' EXIT
+
+FAILURES=0
+
+function test::setup() {
+  rm -rf "${TEST_DIR:?}"/*
+  mkdir -p "${TEST_DIR}/pod/eksa-upgrades/scripts" "${TEST_DIR}/pod/eksa-upgrades/binaries" "${TEST_DIR}/host" "${TEST_DIR}/bin"
+  cp "$UPGRADE_SCRIPT" "${TEST_DIR}/pod/eksa-upgrades/scripts/upgrade.sh"
+  echo 1 > "${TEST_DIR}/pod/eksa-upgrades/binaries/layout-version"
+
+  local command
+  for command in nsenter chroot; do
+    cat > "${TEST_DIR}/bin/${command}" <<EOF
+#!/usr/bin/env bash
+{
+  echo "command=${command}"
+  printf 'arg=%s\n' "\$@"
+  echo "UPGRADER_HOST_ACCESS=\${UPGRADER_HOST_ACCESS:-}"
+  echo "KUBELET_SERVICE=\${KUBELET_SERVICE:-}"
+} > "${TEST_DIR}/calls"
+EOF
+    chmod +x "${TEST_DIR}/bin/${command}"
+  done
+
+  cat > "${TEST_DIR}/config" <<EOF
+HOST_ROOT=${TEST_DIR}/host
+UPGRADER_HOST_DIR=/opt/eksa-upgrades
+KUBELET_SERVICE=custom-kubelet
+EOF
+}
+
+# Runs the copied upgrade script in the pod with the given environment variables.
+function test::run_upgrader() {
+  if ! env -i PATH="${TEST_DIR}/bin:${PATH}" UPGRADER_CONFIG_FILE="${TEST_DIR}/config" "$@" \
+    bash "${TEST_DIR}/pod/eksa-upgrades/scripts/upgrade.sh" status > "${TEST_DIR}/output" 2>&1; then
+    cat "${TEST_DIR}/output"
+    return 1
+  fi
+}
+
+function test::assert_called_with() {
+  local -r test_name=$1
+  shift
+
+  local line
+  for line in "$@"; do
+    if ! grep -qxF -- "$line" "${TEST_DIR}/calls" 2>/dev/null; then
+      echo "FAIL ${test_name}: expected '${line}' in the recorded call"
+      cat "${TEST_DIR}/calls" 2>/dev/null || echo "neither nsenter nor chroot was called"
+      FAILURES=$((FAILURES + 1))
+      return
+    fi
+  done
+  if [ ! -f "${TEST_DIR}/host/opt/eksa-upgrades/scripts/upgrade.sh" ]; then
+    echo "FAIL ${test_name}: upgrader files were not copied to the host directory"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  echo "PASS ${test_name}"
+}
+
+function test::nsenter_from_config() {
+  test::setup
+  echo "UPGRADER_HOST_ACCESS=nsenter" >> "${TEST_DIR}/config"
+  test::run_upgrader
+  test::assert_called_with "${FUNCNAME[0]}" \
+    "command=nsenter" "arg=--target" "arg=1" "arg=--mount" "arg=--pid" "arg=--" \
+    "arg=/opt/eksa-upgrades/scripts/upgrade.sh" "arg=status" \
+    "UPGRADER_HOST_ACCESS=host" "KUBELET_SERVICE=custom-kubelet"
+}
+
+function test::chroot_from_config() {
+  test::setup
+  echo "UPGRADER_HOST_ACCESS=chroot" >> "${TEST_DIR}/config"
+  test::run_upgrader
+  test::assert_called_with "${FUNCNAME[0]}" \
+    "command=chroot" "arg=${TEST_DIR}/host" \
+    "arg=/opt/eksa-upgrades/scripts/upgrade.sh" "arg=status" \
+    "UPGRADER_HOST_ACCESS=host" "KUBELET_SERVICE=custom-kubelet"
+}
+
+function test::environment_overrides_config() {
+  test::setup
+  echo "UPGRADER_HOST_ACCESS=nsenter" >> "${TEST_DIR}/config"
+  test::run_upgrader UPGRADER_HOST_ACCESS=chroot
+  test::assert_called_with "${FUNCNAME[0]}" \
+    "command=chroot" "arg=${TEST_DIR}/host" "arg=/opt/eksa-upgrades/scripts/upgrade.sh" "arg=status"
+}
+
+test::nsenter_from_config
+test::chroot_from_config
+test::environment_overrides_config
+
+if [ "$FAILURES" -gt 0 ]; then
+  echo "${FAILURES} test(s) failed"
+  exit 1
+fi
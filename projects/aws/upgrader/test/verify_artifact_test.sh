@@ -0,0 +1,167 @@
+#!/usr/bin/env bash
+# Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Tests that upgrade.sh verifies replacement artifacts against the checksums shipped in the upgrader
+# image, enforces the signature of the checksums when the image was built with a signing key, and
+# only refuses unsigned checksums on nodes that require signatures. Signatures are created with
+# openssl the way cosign sign-blob creates them, as a base64-encoded signature of the SHA256 digest.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+SCRIPT_ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd -P)"
+UPGRADE_SCRIPT="${SCRIPT_ROOT}/../upgrade.sh"
+
+TEST_DIR=$(mktemp -d)
+trap 'rm -rf "$TEST_DIR"' EXIT
+
+# The functions of upgrade.sh are sourced without the dispatch at the end of the script.
+sed '/^while \[\[ "\${1:-}" == --\*=\* \]\]; do/,$d' "$UPGRADE_SCRIPT" > "${TEST_DIR}/functions.sh"
+source "${TEST_DIR}/functions.sh"
+{ set +x; } 2> /dev/null
+
+function upgrade_components_bin_dir() {
+  echo "${TEST_DIR}/binaries"
+}
+
+export UPGRADER_STATE_DIR="${TEST_DIR}/state"
+export FIPS_MODE=false
+export UPGRADE_ARTIFACTS_PUBLIC_KEY="${TEST_DIR}/node/upgrade-artifacts.pub"
+
+FAILURES=0
+
+function test::setup() {
+  rm -rf "${TEST_DIR}/binaries" "${TEST_DIR}/state" "${TEST_DIR}/node" "${TEST_DIR}/keys"
+  mkdir -p "${TEST_DIR}/binaries/kubernetes/usr/bin" "${TEST_DIR}/node" "${TEST_DIR}/keys"
+  echo "kubelet" > "${TEST_DIR}/binaries/kubernetes/usr/bin/kubelet"
+  (cd "${TEST_DIR}/binaries" && sha256sum kubernetes/usr/bin/kubelet > SHA256SUMS)
+
+  local key
+  for key in image other; do
+    openssl ecparam -name prime256v1 -genkey -noout -out "${TEST_DIR}/keys/${key}.key" 2> /dev/null
+    openssl ec -in "${TEST_DIR}/keys/${key}.key" -pubout -out "${TEST_DIR}/keys/${key}.pub" 2> /dev/null
+  done
+}
+
+# Signs the checksums with the image key and ships its public key next to the signature.
+function test::sign_checksums() {
+  openssl dgst -sha256 -sign "${TEST_DIR}/keys/image.key" "${TEST_DIR}/binaries/SHA256SUMS" |
+    base64 > "${TEST_DIR}/binaries/SHA256SUMS.sig"
+  cp "${TEST_DIR}/keys/image.pub" "${TEST_DIR}/binaries/SHA256SUMS.pub"
+}
+
+# Verifies the kubelet artifact in a subshell with the given environment variables, so that neither
+# the settings nor the cached signature verification result leak into other tests.
+function test::verify_kubelet() {
+  (
+    export "$@"
+    verify_artifact "${TEST_DIR}/binaries/kubernetes/usr/bin/kubelet"
+  ) > "${TEST_DIR}/output" 2>&1
+}
+
+function test::assert_verified() {
+  local -r test_name=$1
+  local -r signed=$2
+  shift 2
+
+  if ! test::verify_kubelet "$@"; then
+    echo "FAIL ${test_name}: artifact was refused"
+    cat "${TEST_DIR}/output"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  if ! grep -qF "\"result\":\"verified\"" "$(upgrade_status_file)" || ! grep -qF "\"signed\":\"${signed}\"" "$(upgrade_status_file)"; then
+    echo "FAIL ${test_name}: expected a verified record with signed=${signed}"
+    cat "$(upgrade_status_file)"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  echo "PASS ${test_name}"
+}
+
+function test::assert_refused() {
+  local -r test_name=$1
+  shift
+
+  if test::verify_kubelet "$@"; then
+    echo "FAIL ${test_name}: artifact was installed"
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  if ! grep -qF "\"reason\":\"missing or invalid checksums signature\"" "$(upgrade_status_file)"; then
+    echo "FAIL ${test_name}: expected a failed verification record"
+    cat "$(upgrade_status_file)" 2> /dev/null
+    FAILURES=$((FAILURES + 1))
+    return
+  fi
+  echo "PASS ${test_name}"
+}
+
+function test::unsigned_image_verified_against_checksums() {
+  test::setup
+  test::assert_verified "${FUNCNAME[0]}" false
+}
+
+function test::unsigned_image_refused_when_signatures_required() {
+  test::setup
+  test::assert_refused "${FUNCNAME[0]}" REQUIRE_SIGNED_ARTIFACTS=true
+}
+
+function test::unsigned_image_allowed_by_override() {
+  test::setup
+  test::assert_verified "${FUNCNAME[0]}" false REQUIRE_SIGNED_ARTIFACTS=true ALLOW_UNSIGNED_ARTIFACTS=true
+}
+
+function test::signed_image_verified_with_shipped_key() {
+  test::setup
+  test::sign_checksums
+  test::assert_verified "${FUNCNAME[0]}" true
+}
+
+function test::signed_image_verified_with_node_key() {
+  test::setup
+  test::sign_checksums
+  cp "${TEST_DIR}/keys/image.pub" "$UPGRADE_ARTIFACTS_PUBLIC_KEY"
+  cp "${TEST_DIR}/keys/other.pub" "${TEST_DIR}/binaries/SHA256SUMS.pub"
+  test::assert_verified "${FUNCNAME[0]}" true
+}
+
+function test::signature_from_other_key_refused() {
+  test::setup
+  test::sign_checksums
+  cp "${TEST_DIR}/keys/other.pub" "$UPGRADE_ARTIFACTS_PUBLIC_KEY"
+  test::assert_refused "${FUNCNAME[0]}"
+}
+
+function test::tampered_checksums_refused() {
+  test::setup
+  test::sign_checksums
+  echo "0000000000000000000000000000000000000000000000000000000000000000  kubernetes/usr/bin/kubeadm" >> "${TEST_DIR}/binaries/SHA256SUMS"
+  test::assert_refused "${FUNCNAME[0]}"
+}
+
+test::unsigned_image_verified_against_checksums
+test::unsigned_image_refused_when_signatures_required
+test::unsigned_image_allowed_by_override
+test::signed_image_verified_with_shipped_key
+test::signed_image_verified_with_node_key
+test::signature_from_other_key_refused
+test::tampered_checksums_refused
+
+if [ "$FAILURES" -gt 0 ]; then
+  echo "${FAILURES} test(s) failed"
+  exit 1
+fi
@@ -15,6 +15,31 @@ backup_file() {
   fi
 
   cp "$file_path" "$backedup_file"
+  sync "$backedup_file"
+}
+
+# Replaces a file without ever leaving a partially written file in its place, even if the node loses
+# power or the upgrader is killed midway: the new file is written next to the file it replaces with the
+# same ownership and mode, flushed to disk and renamed over it, and the rename is flushed as well. The
+# file being replaced is left untouched if any of this fails.
+replace_file_atomically() {
+  local -r new_file=$1
+  local -r target_file=$2
+  local -r tmp_file="$(dirname "$target_file")/.$(basename "$target_file").new"
+
+  if ! {
+    cp "$new_file" "$tmp_file" &&
+      if [ -e "$target_file" ]; then
+        chown --reference="$target_file" "$tmp_file" && chmod --reference="$target_file" "$tmp_file"
+      fi &&
+      sync "$tmp_file" &&
+      mv -f "$tmp_file" "$target_file"
+  }; then
+    rm -f "$tmp_file"
+    echo "unable to replace ${target_file} with ${new_file}"
+    return 1
+  fi
+  sync "$(dirname "$target_file")"
 }
 
 backup_and_replace() {
@@ -22,7 +47,102 @@ backup_and_replace() {
   backup_folder=$2
   new_file=$3
 
-  backup_file "$old_file" "$backup_folder" && cp "$new_file" "$old_file"
+  verify_artifact "$new_file"
+  backup_file "$old_file" "$backup_folder" && replace_file_atomically "$new_file" "$old_file"
+}
+
+# Sets a setting from a --setting-name=value flag given before the upgrade step.
+set_flag() {
+  local name=${1%%=*}
+  name=${name#--}
+  name=${name//-/_}
+  export "${name^^}=${1#*=}"
+}
+
+# Loads the paths and service names of the node components, and the timeouts of the upgrade steps.
+# The paths default to the layout of the EKS Anywhere node images and can be overridden for images
+# with other layouts, such as custom AMIs or immutable OS variants, and the timeouts can be raised for
+# slow nodes, with environment variables or flags, or with KEY=VALUE lines in the file named by
+# UPGRADER_CONFIG_FILE. Environment variables and flags take precedence over the config file.
+load_config() {
+  local -r config_file=${UPGRADER_CONFIG_FILE:-/etc/eksa-upgrader/config}
+  if [ -f "$config_file" ]; then
+    local key value
+    while IFS='=' read -r key value; do
+      if [[ -z "$key" || "$key" == \#* ]]; then
+        continue
+      fi
+      if [[ ! "$key" =~ ^[A-Z_][A-Z0-9_]*$ ]]; then
+        echo "invalid setting ${key} in ${config_file}"
+        return 1
+      fi
+      if [ -z "${!key+set}" ]; then
+        export "${key}=${value}"
+      fi
+    done < "$config_file"
+  fi
+
+  : "${KUBEADM_BIN:=/usr/bin/kubeadm}"
+  : "${KUBELET_BIN:=/usr/bin/kubelet}"
+  : "${KUBECTL_BIN:=/usr/bin/kubectl}"
+  : "${CONTAINERD_BIN:=/usr/local/bin/containerd}"
+  : "${KUBERNETES_CONFIG_DIR:=/etc/kubernetes}"
+  : "${STATIC_POD_MANIFESTS_DIR:=${KUBERNETES_CONFIG_DIR}/manifests}"
+  : "${ADMIN_KUBECONFIG:=${KUBERNETES_CONFIG_DIR}/admin.conf}"
+  : "${KUBELET_CONFIG_FILE:=/var/lib/kubelet/config.yaml}"
+  : "${KUBELET_EXTRA_ARGS_FILE:=/etc/sysconfig/kubelet}"
+  : "${CONTAINERD_CONFIG_DIR:=/etc/containerd}"
+  : "${CONTAINERD_CONFIG:=${CONTAINERD_CONFIG_DIR}/config.toml}"
+  : "${CGROUP_MOUNT_DIR:=/sys/fs/cgroup}"
+  : "${PAUSE_IMAGE:=}"
+  : "${CREDENTIAL_PROVIDER_CONFIG:=}"
+  : "${CREDENTIAL_PROVIDER_BIN_DIR:=}"
+  : "${CREDENTIAL_PROVIDER_VALIDATION_IMAGE:=}"
+  : "${FIPS_MODE:=auto}"
+  : "${FIPS_ENABLED_FILE:=/proc/sys/crypto/fips_enabled}"
+  : "${UPGRADE_ARTIFACTS_PUBLIC_KEY:=/etc/eksa-upgrader/upgrade-artifacts.pub}"
+
+  : "${KUBEADM_UPGRADE_TIMEOUT_SECONDS:=900}"
+  : "${KUBELET_READY_TIMEOUT_SECONDS:=300}"
+  : "${CONTAINERD_READY_TIMEOUT_SECONDS:=120}"
+  : "${STATIC_POD_READY_TIMEOUT_SECONDS:=300}"
+  : "${STATIC_POD_STABLE_SECONDS:=30}"
+  : "${SYSTEMD_UNIT_DIR:=/etc/systemd/system}"
+  : "${KUBELET_SERVICE:=kubelet}"
+  : "${CONTAINERD_SERVICE:=containerd}"
+}
+
+# Runs the upgrade step in the host's mount namespace when the upgrader runs in a privileged pod rather
+# than on the host, so that every file and command the step touches is the host's. The upgrader files
+# are copied to UPGRADER_HOST_DIR on the host filesystem, which is mounted at HOST_ROOT in the pod, and
+# the script is executed from there, either with nsenter in the namespaces of the host's init process,
+# which requires the pod to share the host's PID namespace, or with chroot into HOST_ROOT. The host
+# access settings are read from the environment, flags or the upgrader config file.
+run_on_host() {
+  local -r host_access=${UPGRADER_HOST_ACCESS:-host}
+  if [ "$host_access" = "host" ]; then
+    return
+  fi
+
+  local -r host_root=${HOST_ROOT:-/usr/host}
+  local -r host_dir=${UPGRADER_HOST_DIR:-/var/lib/eksa-upgrader/eksa-upgrades}
+  mkdir -p "${host_root}${host_dir}"
+  cp -rf "$(upgrade_components_dir)"/. "${host_root}${host_dir}"
+
+  # The step runs on the host from here on.
+  export UPGRADER_HOST_ACCESS=host
+  case "$host_access" in
+    nsenter)
+      exec nsenter --target 1 --mount --uts --ipc --net --pid -- "${host_dir}/scripts/upgrade.sh" "$@"
+      ;;
+    chroot)
+      exec chroot "$host_root" "${host_dir}/scripts/upgrade.sh" "$@"
+      ;;
+    *)
+      echo "unsupported host access ${host_access}, expected host, nsenter or chroot"
+      return 1
+      ;;
+  esac
 }
 
 script_dir() {
@@ -37,22 +157,927 @@ upgrade_components_bin_dir() {
   echo "$(upgrade_components_dir)/binaries"
 }
 
+# Prints the schema version of the artifact layout in the upgrade bundle. Images record it in the
+# layout-version file of the binaries directory, and the layout of bundles that predate the file is
+# detected from the artifacts they contain.
+#   1: kubeadm, kubelet and kubectl under kubernetes/usr/bin
+#   2: the EKS-D release layout, with kubectl under kubernetes/client/bin and kubeadm and kubelet
+#      under kubernetes/server/bin
+# containerd and cni-plugins are trees of files installed relative to / in both layouts, and
+# credential-provider holds the kubelet image credential provider binaries. Bundles with FIPS builds
+# of the artifacts hold them under fips, laid out like the binaries directory, and bundles without
+# them have no fips directory.
+bundle_layout_version() {
+  local -r bin_dir=$(upgrade_components_bin_dir)
+  if [ -f "${bin_dir}/layout-version" ]; then
+    cat "${bin_dir}/layout-version"
+  elif [ -d "${bin_dir}/kubernetes/usr/bin" ]; then
+    echo 1
+  elif [ -d "${bin_dir}/kubernetes/server/bin" ]; then
+    echo 2
+  else
+    echo "unable to detect the layout of the upgrade bundle in ${bin_dir}" >&2
+    return 1
+  fi
+}
+
+# Prints the path of an artifact in the upgrade bundle. Artifacts the layout does not define, such
+# as the sources of components added to the upgrade plan, are directories of the binaries directory.
+# The FIPS builds are selected on nodes that require FIPS, which refuse bundles without them.
+bundle_artifact_path() {
+  local -r artifact=$1
+  local bin_dir
+  bin_dir=$(upgrade_components_bin_dir)
+  local layout_version
+  layout_version=$(bundle_layout_version) || return
+  local fips_builds=0
+  use_fips_builds || fips_builds=$?
+  case "$fips_builds" in
+    0)
+      bin_dir+="/fips"
+      ;;
+    2)
+      record_status event=fips-verification artifact="$artifact" result=failed reason="no FIPS builds in the upgrade bundle"
+      return 1
+      ;;
+  esac
+
+  case "${layout_version}:${artifact}" in
+    1:kubeadm | 1:kubelet | 1:kubectl)
+      echo "${bin_dir}/kubernetes/usr/bin/${artifact}"
+      ;;
+    2:kubectl)
+      echo "${bin_dir}/kubernetes/client/bin/${artifact}"
+      ;;
+    2:kubeadm | 2:kubelet)
+      echo "${bin_dir}/kubernetes/server/bin/${artifact}"
+      ;;
+    1:* | 2:*)
+      echo "${bin_dir}/${artifact}"
+      ;;
+    *)
+      echo "unsupported upgrade bundle layout version ${layout_version}" >&2
+      return 1
+      ;;
+  esac
+}
+
 upgrade_components_kubernetes_bin_dir() {
-  echo "$(upgrade_components_bin_dir)/kubernetes/usr/bin"
+  echo "$(dirname "$(bundle_artifact_path kubeadm)")"
+}
+
+upgrader_state_dir() {
+  echo "${UPGRADER_STATE_DIR:-/var/lib/eksa-upgrader}"
+}
+
+upgrade_status_file() {
+  echo "$(upgrader_state_dir)/status.jsonl"
+}
+
+json_escape() {
+  local value=$1
+  value=${value//\\/\\\\}
+  value=${value//\"/\\\"}
+  value=${value//$'\n'/\\n}
+  value=${value//$'\t'/\\t}
+  printf '%s' "$value"
+}
+
+# Appends a record built from the given key=value pairs to the status file, which holds
+# one JSON object per line so it can be read by controllers and support tooling.
+record_status() {
+  local record="{\"time\":\"$(date -u +%Y-%m-%dT%H:%M:%SZ)\""
+  local field
+  local key value
+  for field in "$@"; do
+    key=${field%%=*}
+    value=${field#*=}
+    if [[ "$value" =~ ^[0-9]+$ ]]; then
+      record+=",\"$(json_escape "$key")\":${value}"
+    else
+      record+=",\"$(json_escape "$key")\":\"$(json_escape "$value")\""
+    fi
+  done
+
+  mkdir -p "$(upgrader_state_dir)"
+  echo "${record}}" >> "$(upgrade_status_file)"
+}
+
+# Runs a command, retrying it up to UPGRADE_MAX_RETRIES times when it fails. Retries are
+# counted in a file rather than a variable so that retries in command substitutions are
+# included in the retry count of the phase. The retry messages go to stderr, so that the
+# output of commands captured with command substitutions only holds the command's output.
+retry() {
+  local attempt=0
+  until "$@"; do
+    attempt=$((attempt + 1))
+    if [ "$attempt" -gt "${UPGRADE_MAX_RETRIES:-3}" ]; then
+      echo "'$*' failed after ${attempt} attempts" >&2
+      return 1
+    fi
+    echo "'$*' failed, retrying" >&2
+    if [ -n "${PHASE_RETRIES_FILE:-}" ]; then
+      echo "$*" >> "$PHASE_RETRIES_FILE"
+    fi
+    sleep "${UPGRADE_RETRY_DELAY_SECONDS:-5}"
+  done
+}
+
+# Runs an upgrade step and records its duration, retry count and outcome in the status file
+# when the script exits, including when the step fails.
+run_phase() {
+  PHASE=$1
+  PHASE_START_TIME=$(date +%s)
+  PHASE_RETRIES_FILE=$(mktemp)
+  PHASE_LOG_FILE=$(mktemp)
+  trap 'record_phase_outcome $?' EXIT
+
+  # Keep a copy of the step's output for the failure bundle. The original stdout and stderr are saved,
+  # so that stop_phase_log can hand them back and let the tee processes drain into the log file.
+  exec {PHASE_STDOUT_FD}>&1 {PHASE_STDERR_FD}>&2
+  exec > >(tee -a "$PHASE_LOG_FILE")
+  PHASE_LOG_PIDS=($!)
+  exec 2> >(tee -a "$PHASE_LOG_FILE" >&2)
+  PHASE_LOG_PIDS+=($!)
+
+  "$@"
+}
+
+# Restores the original stdout and stderr of the step and waits for the tee processes to write the
+# rest of its output to the phase log, for at most 10 seconds. Older bash versions can only wait for
+# the last process substitution, so the tee processes are polled instead.
+stop_phase_log() {
+  exec 1>&"$PHASE_STDOUT_FD" 2>&"$PHASE_STDERR_FD" {PHASE_STDOUT_FD}>&- {PHASE_STDERR_FD}>&-
+
+  local pid attempt
+  for pid in "${PHASE_LOG_PIDS[@]}"; do
+    for attempt in {1..100}; do
+      kill -0 "$pid" 2> /dev/null || break
+      sleep 0.1
+    done
+  done
+}
+
+record_phase_outcome() {
+  local -r exit_code=$1
+  stop_phase_log
+  local -r duration_seconds=$(($(date +%s) - PHASE_START_TIME))
+  local -r retries=$(wc -l < "$PHASE_RETRIES_FILE")
+  rm -f "$PHASE_RETRIES_FILE"
+
+  local outcome=succeeded
+  if [ "$exit_code" -ne 0 ]; then
+    outcome=failed
+  fi
+
+  record_status event=phase phase="$PHASE" outcome="$outcome" exit_code="$exit_code" duration_seconds="$duration_seconds" retries="$retries"
+  put_phase_metrics "$outcome" "$duration_seconds" "$retries" || echo "Unable to push upgrade metrics to CloudWatch"
+  if [ "$exit_code" -ne 0 ]; then
+    collect_failure_bundle || echo "Unable to collect the failure bundle"
+  fi
+  rm -f "$PHASE_LOG_FILE"
+}
+
+upgrade_failure_bundles_dir() {
+  echo "$(upgrader_state_dir)/failures"
+}
+
+# Collects what support needs to troubleshoot a failed upgrade step into a tarball named after the step
+# in the failures directory of the upgrader state directory: the kubelet and containerd journals since
+# shortly before the step started, the last UPGRADE_FAILURE_LOG_LINES lines of the step's output, the
+# upgrade status file, the state of the services and containers, and the kubelet, containerd and static
+# pod configuration. Certificates and kubeconfigs are left out. Only the most recent
+# UPGRADE_FAILURE_BUNDLE_RETENTION tarballs are kept.
+collect_failure_bundle() {
+  local -r bundle_name="${PHASE}-$(date -u +%Y%m%dT%H%M%SZ)"
+  local -r work_dir=$(mktemp -d)
+  local -r bundle_dir="${work_dir}/${bundle_name}"
+  local -r since="@$((PHASE_START_TIME - 600))"
+  mkdir -p "${bundle_dir}/config"
+
+  journalctl -u "$KUBELET_SERVICE" --since "$since" --no-pager > "${bundle_dir}/kubelet.log" 2>&1
+  journalctl -u "$CONTAINERD_SERVICE" --since "$since" --no-pager > "${bundle_dir}/containerd.log" 2>&1
+  systemctl status "$KUBELET_SERVICE" "$CONTAINERD_SERVICE" --no-pager > "${bundle_dir}/services.txt" 2>&1
+  crictl ps -a > "${bundle_dir}/containers.txt" 2>&1
+  crictl pods > "${bundle_dir}/pods.txt" 2>&1
+  tail -n "${UPGRADE_FAILURE_LOG_LINES:-1000}" "$PHASE_LOG_FILE" > "${bundle_dir}/upgrader.log"
+  if [ -f "$(upgrade_status_file)" ]; then
+    cp "$(upgrade_status_file)" "${bundle_dir}/status.jsonl"
+  fi
+
+  local path
+  for path in "$KUBELET_CONFIG_FILE" \
+    "$(dirname "$KUBELET_CONFIG_FILE")/kubeadm-flags.env" \
+    "$KUBELET_EXTRA_ARGS_FILE" \
+    "${SYSTEMD_UNIT_DIR}/${KUBELET_SERVICE}.service.d" \
+    "$CONTAINERD_CONFIG" \
+    "${SYSTEMD_UNIT_DIR}/${CONTAINERD_SERVICE}.service.d" \
+    "$STATIC_POD_MANIFESTS_DIR"; do
+    if [ -e "$path" ]; then
+      cp -r --parents "$path" "${bundle_dir}/config"
+    fi
+  done
+
+  local -r bundle_file="$(upgrade_failure_bundles_dir)/${bundle_name}.tar.gz"
+  mkdir -p "$(upgrade_failure_bundles_dir)"
+  chmod 700 "$(upgrader_state_dir)"
+  tar -czf "$bundle_file" -C "$work_dir" "$bundle_name"
+  rm -rf "$work_dir"
+  echo "Collected the failure bundle ${bundle_file}"
+  record_status event=failure-bundle phase="$PHASE" path="$bundle_file"
+
+  local old_bundle_file
+  for old_bundle_file in $(find "$(upgrade_failure_bundles_dir)" -mindepth 1 -maxdepth 1 -name '*.tar.gz' -printf '%T@ %p\n' | sort -n | head -n "-${UPGRADE_FAILURE_BUNDLE_RETENTION:-5}" | cut -d' ' -f2); do
+    rm -f "$old_bundle_file"
+  done
+}
+
+# Pushes the duration, retry count and outcome of the phase to CloudWatch when the AWS CLI is
+# installed on the node and has credentials, unless disabled with UPGRADE_CLOUDWATCH_METRICS=false.
+put_phase_metrics() {
+  local -r outcome=$1
+  local -r duration_seconds=$2
+  local -r retries=$3
+
+  if [ "${UPGRADE_CLOUDWATCH_METRICS:-true}" != "true" ] || ! command -v aws &> /dev/null; then
+    return 0
+  fi
+  if ! aws sts get-caller-identity --cli-connect-timeout 5 &> /dev/null; then
+    return 0
+  fi
+
+  local -r dimensions="Dimensions=[{Name=Phase,Value=${PHASE}}]"
+  local failures=0
+  if [ "$outcome" = "failed" ]; then
+    failures=1
+  fi
+  aws cloudwatch put-metric-data --cli-connect-timeout 5 \
+    --namespace "${UPGRADE_CLOUDWATCH_NAMESPACE:-EKSAnywhere/InPlaceUpgrade}" \
+    --metric-data "MetricName=Duration,Unit=Seconds,Value=${duration_seconds},${dimensions}" \
+      "MetricName=Retries,Unit=Count,Value=${retries},${dimensions}" \
+      "MetricName=Failures,Unit=Count,Value=${failures},${dimensions}"
+}
+
+# Prints the cgroup version of the node, 2 when the unified hierarchy is mounted and 1 otherwise.
+cgroup_version() {
+  if [ "$(stat -fc %T "$CGROUP_MOUNT_DIR")" = "cgroup2fs" ]; then
+    echo 2
+  else
+    echo 1
+  fi
+}
+
+cgroup_version_file() {
+  echo "$(upgrader_state_dir)/cgroup-version"
+}
+
+# Prints the cgroup driver the kubelet is configured with, which is cgroupfs when cgroupDriver is not set.
+kubelet_cgroup_driver() {
+  local driver=""
+  if [ -f "$KUBELET_CONFIG_FILE" ]; then
+    driver=$(awk '$1 == "cgroupDriver:" { gsub(/"/, "", $2); print $2; exit }' "$KUBELET_CONFIG_FILE")
+  fi
+  echo "${driver:-cgroupfs}"
+}
+
+# Prints the cgroup driver containerd runs containers with, which is cgroupfs unless the runc runtime
+# options set SystemdCgroup.
+containerd_cgroup_driver() {
+  if [ -f "$CONTAINERD_CONFIG" ] && grep -Eq '^[[:space:]]*SystemdCgroup[[:space:]]*=[[:space:]]*true' "$CONTAINERD_CONFIG"; then
+    echo systemd
+  else
+    echo cgroupfs
+  fi
+}
+
+# Fails when the kubelet and containerd are configured with different cgroup drivers, which leaves the
+# node NotReady once they are restarted.
+check_cgroup_drivers() {
+  local -r version=$1
+  local -r kubelet_driver=$(kubelet_cgroup_driver)
+  local -r containerd_driver=$(containerd_cgroup_driver)
+
+  record_status event=cgroups cgroup_version="$version" kubelet_cgroup_driver="$kubelet_driver" containerd_cgroup_driver="$containerd_driver"
+  if [ "$kubelet_driver" != "$containerd_driver" ]; then
+    echo "the kubelet uses the ${kubelet_driver} cgroup driver but containerd uses the ${containerd_driver} cgroup driver."
+    echo "Set cgroupDriver in ${KUBELET_CONFIG_FILE} (and in the kubelet-config ConfigMap on control plane nodes) and SystemdCgroup in the runc options of ${CONTAINERD_CONFIG} to the same driver, systemd on nodes running systemd, then retry the upgrade."
+    return 1
+  fi
+  if [ "$version" = 2 ] && [ "$kubelet_driver" != "systemd" ]; then
+    echo "warning: the node uses cgroup v2 with the ${kubelet_driver} cgroup driver, the systemd cgroup driver is recommended on cgroup v2 nodes"
+  fi
+}
+
+# Checks the cgroup configuration before the kubelet or containerd is replaced, reporting a change of
+# cgroup version since the last upgrade, for example after an OS upgrade switched the node from cgroup
+# v1 to the unified cgroup v2 hierarchy.
+cgroups_preflight() {
+  local -r version=$(cgroup_version)
+  local previous_version=""
+  if [ -f "$(cgroup_version_file)" ]; then
+    previous_version=$(cat "$(cgroup_version_file)")
+  fi
+
+  if [ -n "$previous_version" ] && [ "$previous_version" != "$version" ]; then
+    echo "the node switched from cgroup v${previous_version} to cgroup v${version} since the last upgrade"
+    record_status event=cgroup-transition from="$previous_version" to="$version"
+  fi
+  check_cgroup_drivers "$version"
+}
+
+# Validates the cgroup configuration once the upgraded component is restarted and records the cgroup
+# version the node was upgraded with.
+validate_cgroups() {
+  local -r version=$(cgroup_version)
+  check_cgroup_drivers "$version"
+
+  mkdir -p "$(upgrader_state_dir)"
+  echo "$version" > "$(cgroup_version_file)"
+}
+
+# Prints the versions of the components installed on the node as component=version lines, with an empty
+# version for components that are not installed.
+installed_component_versions() {
+  echo "kubernetes=$("$KUBELET_BIN" --version 2>/dev/null | awk '{print $2}' || true)"
+  echo "kubeadm=$("$KUBEADM_BIN" version -oshort 2>/dev/null || true)"
+  echo "kubectl=$("$KUBECTL_BIN" version --client 2>/dev/null | awk '/^Client Version:/ {print $3}' || true)"
+  echo "containerd=$("$CONTAINERD_BIN" --version 2>/dev/null | awk '{print $3}' || true)"
+  echo "cni-plugins=$(/opt/cni/bin/loopback --version 2>/dev/null | awk 'NR == 1 {print $4}' || true)"
+}
+
+upgrade_backups_dir() {
+  echo "$(upgrader_state_dir)/backups"
+}
+
+# Node configuration saved in the backup bundle, in addition to the binaries the upgrade replaces.
+backup_config_paths() {
+  echo "$KUBERNETES_CONFIG_DIR"
+  if [[ "$STATIC_POD_MANIFESTS_DIR" != "$KUBERNETES_CONFIG_DIR"/* ]]; then
+    echo "$STATIC_POD_MANIFESTS_DIR"
+  fi
+  # The binaries are also saved from their configured locations, which can differ from their
+  # locations in the upgrade artifacts on images with non-default layouts.
+  echo "$KUBEADM_BIN" \
+    "$KUBELET_BIN" \
+    "$KUBECTL_BIN" \
+    "$CONTAINERD_BIN" \
+    "$KUBELET_CONFIG_FILE" \
+    "$(dirname "$KUBELET_CONFIG_FILE")/kubeadm-flags.env" \
+    "$KUBELET_EXTRA_ARGS_FILE" \
+    /etc/default/kubelet \
+    "$(credential_provider_config)" \
+    "$(credential_provider_bin_dir)" \
+    "${SYSTEMD_UNIT_DIR}/${KUBELET_SERVICE}.service" \
+    "${SYSTEMD_UNIT_DIR}/${KUBELET_SERVICE}.service.d" \
+    "$CONTAINERD_CONFIG_DIR" \
+    "${SYSTEMD_UNIT_DIR}/${CONTAINERD_SERVICE}.service" \
+    "${SYSTEMD_UNIT_DIR}/${CONTAINERD_SERVICE}.service.d"
+}
+
+# Saves the binaries replaced by the upgrade, the kubelet and containerd configuration and the static
+# pod manifests to a timestamped bundle before the node is modified, along with the versions of the
+# installed components. A single bundle is created per upgrade, by whichever step runs first, and only
+# the most recent UPGRADE_BACKUP_RETENTION bundles are kept.
+create_backup_bundle() {
+  local -r marker_file="$(upgrade_components_dir)/backup-bundle"
+  if [ -f "$marker_file" ]; then
+    echo "Backup bundle $(cat "$marker_file") already created for this upgrade"
+    return
+  fi
+
+  local -r bundle_dir="$(upgrade_backups_dir)/$(date -u +%Y%m%dT%H%M%SZ)"
+  mkdir -p "$bundle_dir"
+  # The bundle contains the cluster's certificates and kubeconfigs.
+  chmod 700 "$(upgrader_state_dir)"
+
+  local path
+  {
+    for path in $(backup_config_paths); do
+      if [ -e "$path" ]; then
+        printf '%s\0' "${path#/}"
+      fi
+    done
+    local component_dir relative_path
+    for component_dir in "$(upgrade_components_bin_dir)"/*/; do
+      while IFS= read -r -d '' relative_path; do
+        if [ -e "/${relative_path}" ]; then
+          printf '%s\0' "$relative_path"
+        fi
+      done < <(cd "$component_dir" && find . \( -type f -o -type l \) -printf '%P\0')
+    done
+  } | sort -zu > "${bundle_dir}/paths"
+  tar -czf "${bundle_dir}/files.tar.gz" -C / --null -T "${bundle_dir}/paths"
+
+  installed_component_versions > "${bundle_dir}/versions"
+
+  echo "$bundle_dir" > "$marker_file"
+  record_status event=backup bundle="$bundle_dir"
+  echo "Created backup bundle ${bundle_dir}"
+
+  prune_backup_bundles
+}
+
+prune_backup_bundles() {
+  local -r retention=${UPGRADE_BACKUP_RETENTION:-3}
+
+  local bundle_dir
+  for bundle_dir in $(find "$(upgrade_backups_dir)" -mindepth 1 -maxdepth 1 -type d | sort | head -n "-${retention}"); do
+    echo "Deleting old backup bundle ${bundle_dir}"
+    rm -rf "$bundle_dir"
+  done
+}
+
+# Restores the node from a backup bundle, the most recent one by default, as an escape hatch for an
+# upgrade to a bad release: the binaries and configuration saved in the bundle are extracted back in
+# place, containerd and the kubelet are restarted, and the node is validated to run the component
+# versions recorded in the bundle again. Changes the upgrade made to the cluster, such as the kubeadm
+# ConfigMaps updated when upgrading the first control plane node, are not reverted.
+revert() {
+  local bundle_dir=${1:-}
+  if [ -z "$bundle_dir" ] && [ -d "$(upgrade_backups_dir)" ]; then
+    bundle_dir=$(find "$(upgrade_backups_dir)" -mindepth 1 -maxdepth 1 -type d | sort | tail -n 1)
+  fi
+  if [ -z "$bundle_dir" ] || [ ! -f "${bundle_dir}/files.tar.gz" ]; then
+    echo "no backup bundle to revert to in $(upgrade_backups_dir)"
+    return 1
+  fi
+
+  echo "Reverting the node to backup bundle ${bundle_dir}"
+  systemctl stop "$KUBELET_SERVICE"
+  tar -xzf "${bundle_dir}/files.tar.gz" -C /
+  restart_containerd
+  systemctl restart "$KUBELET_SERVICE"
+
+  local -r installed_versions=$(installed_component_versions)
+  local component version installed_version
+  local mismatches=0
+  while IFS='=' read -r component version; do
+    installed_version=$(echo "$installed_versions" | sed -n "s/^${component}=//p")
+    if [ "$installed_version" != "$version" ]; then
+      echo "${component} is at version ${installed_version:-none} after the revert, expected ${version:-none}"
+      mismatches=$((mismatches + 1))
+    fi
+  done < "${bundle_dir}/versions"
+
+  if [ "$(systemctl is-active "$KUBELET_SERVICE")" != "active" ]; then
+    echo "${KUBELET_SERVICE} is not active after the revert"
+    mismatches=$((mismatches + 1))
+  fi
+
+  if [ "$mismatches" -gt 0 ]; then
+    record_status event=revert outcome=failed bundle="$bundle_dir"
+    return 1
+  fi
+
+  # The next upgrade creates a new backup bundle.
+  rm -f "$(upgrade_components_dir)/backup-bundle"
+  record_status event=revert outcome=succeeded bundle="$bundle_dir"
+  echo "Reverted the node to backup bundle ${bundle_dir}"
+}
+
+artifact_checksums_file() {
+  echo "$(upgrade_components_bin_dir)/SHA256SUMS"
+}
+
+# Verifies the signature of the checksums file, which is expected next to it as a base64-encoded ECDSA or
+# RSA signature of its SHA256 digest, as produced by cosign sign-blob when the image is built with a
+# signing key. The signature is verified with the public key in UPGRADE_ARTIFACTS_PUBLIC_KEY when it is
+# provisioned on the node, and otherwise with the public key the image ships next to the signature.
+# Images built without a signing key ship neither, and their artifacts are only verified against the
+# checksums unless REQUIRE_SIGNED_ARTIFACTS is true. Checksums with a signature that does not verify, and
+# unsigned checksums on nodes that require signatures, are refused unless ALLOW_UNSIGNED_ARTIFACTS is true.
+verify_checksums_signature() {
+  if [ -n "${CHECKSUMS_SIGNATURE_VERIFIED:-}" ]; then
+    return 0
+  fi
+
+  local -r checksums_file=$(artifact_checksums_file)
+  local -r signature_file="${checksums_file}.sig"
+  local public_key=$UPGRADE_ARTIFACTS_PUBLIC_KEY
+  if [ ! -f "$public_key" ]; then
+    public_key="${checksums_file}.pub"
+  fi
+
+  local reason=""
+  if [ ! -f "$checksums_file" ] || [ ! -f "$signature_file" ]; then
+    if [ "${REQUIRE_SIGNED_ARTIFACTS:-false}" != "true" ]; then
+      echo "checksums file ${checksums_file} is not signed, verifying artifacts against their checksums only"
+      CHECKSUMS_SIGNATURE_VERIFIED=false
+      return 0
+    fi
+    reason="signature ${signature_file} of checksums file ${checksums_file} not found"
+  elif [ ! -f "$public_key" ]; then
+    reason="neither public key ${UPGRADE_ARTIFACTS_PUBLIC_KEY} nor ${public_key} found to verify signature ${signature_file}"
+  else
+    local -r decoded_signature_file=$(mktemp)
+    if ! base64 -d "$signature_file" > "$decoded_signature_file" ||
+      ! openssl dgst -sha256 -verify "$public_key" -signature "$decoded_signature_file" "$checksums_file"; then
+      reason="signature ${signature_file} does not match public key ${public_key}"
+    fi
+    rm -f "$decoded_signature_file"
+  fi
+
+  if [ -z "$reason" ]; then
+    CHECKSUMS_SIGNATURE_VERIFIED=true
+    return 0
+  fi
+  if [ "${ALLOW_UNSIGNED_ARTIFACTS:-false}" = "true" ]; then
+    echo "${reason}, installing unsigned artifacts as allowed by ALLOW_UNSIGNED_ARTIFACTS"
+    CHECKSUMS_SIGNATURE_VERIFIED=false
+    return 0
+  fi
+  echo "${reason}, set ALLOW_UNSIGNED_ARTIFACTS=true to install unsigned artifacts anyway"
+  return 1
+}
+
+# Verifies a replacement artifact against the checksums recorded when the upgrader image was
+# built and the signature of the checksums, recording the result in the status file. Artifacts with a
+# mismatching checksum are always refused, artifacts whose checksums fail the signature verification are
+# refused unless ALLOW_UNSIGNED_ARTIFACTS is true, and artifacts without a recorded checksum are refused unless
+# ALLOW_UNVERIFIED_ARTIFACTS is true.
+verify_artifact() {
+  local -r artifact=$1
+  local -r checksums_file=$(artifact_checksums_file)
+  local -r relative_path=${artifact#"$(upgrade_components_bin_dir)/"}
+
+  if ! verify_checksums_signature; then
+    record_status event=artifact-verification artifact="$relative_path" result=failed reason="missing or invalid checksums signature"
+    return 1
+  fi
+
+  local expected_checksum=""
+  if [ -f "$checksums_file" ]; then
+    expected_checksum=$(awk -v path="$relative_path" '$2 == path || $2 == "*"path { print $1; exit }' "$checksums_file")
+  fi
+
+  if [ -z "$expected_checksum" ]; then
+    if [ "${ALLOW_UNVERIFIED_ARTIFACTS:-false}" != "true" ]; then
+      record_status event=artifact-verification artifact="$relative_path" result=failed reason="no recorded checksum"
+      echo "no checksum recorded for ${relative_path} in ${checksums_file}, set ALLOW_UNVERIFIED_ARTIFACTS=true to install it anyway"
+      return 1
+    fi
+    record_status event=artifact-verification artifact="$relative_path" result=unverified reason="no recorded checksum, allowed by ALLOW_UNVERIFIED_ARTIFACTS"
+    return 0
+  fi
+
+  local -r actual_checksum=$(sha256sum "$artifact" | cut -d' ' -f1)
+  if [ "$actual_checksum" != "$expected_checksum" ]; then
+    record_status event=artifact-verification artifact="$relative_path" result=failed reason="checksum mismatch" expected="$expected_checksum" actual="$actual_checksum"
+    echo "checksum ${actual_checksum} of ${relative_path} does not match recorded checksum ${expected_checksum}"
+    return 1
+  fi
+
+  record_status event=artifact-verification artifact="$relative_path" result=verified checksum="$actual_checksum" signed="${CHECKSUMS_SIGNATURE_VERIFIED:-false}"
+  local fips_builds=0
+  use_fips_builds 2>/dev/null || fips_builds=$?
+  case "$fips_builds" in
+    0)
+      verify_fips_artifact "$artifact"
+      ;;
+    2)
+      record_status event=fips-verification artifact="$relative_path" result=failed reason="no FIPS builds in the upgrade bundle"
+      return 1
+      ;;
+  esac
+}
+
+# Returns whether the node requires FIPS-compliant binaries. FIPS_MODE is true or false to force the
+# mode, or auto to require them when the kernel runs in FIPS mode.
+fips_required() {
+  case "$FIPS_MODE" in
+    true)
+      return 0
+      ;;
+    false)
+      return 1
+      ;;
+    *)
+      [ -f "$FIPS_ENABLED_FILE" ] && [ "$(cat "$FIPS_ENABLED_FILE")" = "1" ]
+      ;;
+  esac
+}
+
+# Returns whether the FIPS builds of the artifacts are installed, which is the case on nodes that require
+# FIPS. Nodes that require FIPS, whether forced with FIPS_MODE=true or detected with FIPS_MODE=auto,
+# refuse upgrade bundles without FIPS builds and return 2 rather than silently installing the regular
+# builds. FIPS_MODE=false installs the regular builds on nodes whose kernel runs in FIPS mode.
+use_fips_builds() {
+  if ! fips_required; then
+    return 1
+  fi
+  if [ -d "$(upgrade_components_bin_dir)/fips" ]; then
+    return 0
+  fi
+  echo "the node requires FIPS with FIPS_MODE=${FIPS_MODE} but the upgrade bundle has no FIPS builds in $(upgrade_components_bin_dir)/fips, set FIPS_MODE=false to install the regular builds anyway" >&2
+  return 2
+}
+
+# Returns whether the file is a Go binary, which embeds its build information after a magic string.
+is_go_binary() {
+  [ -f "$1" ] && grep -qaF 'Go buildinf:' "$1"
+}
+
+# Prints whether the Go binary was built with a FIPS-validated crypto module, either BoringCrypto, which
+# the Go toolchain enables with GOEXPERIMENT=boringcrypto and the Go+BoringCrypto toolchains always use,
+# or the Go Cryptographic Module selected with GOFIPS140.
+is_fips_build() {
+  grep -qaE 'GOEXPERIMENT=[^[:space:]]*boringcrypto|GOFIPS140=(latest|v[0-9])|crypto/internal/boring/sig\.BoringCrypto|_Cfunc__goboringcrypto_' "$1"
+}
+
+# Verifies that an artifact installed on a node that requires FIPS is a FIPS build, recording the result
+# in the status file. Artifacts that are not Go binaries, such as scripts and configuration files, are
+# not checked.
+verify_fips_artifact() {
+  local -r artifact=$1
+  local -r relative_path=${artifact#"$(upgrade_components_bin_dir)/"}
+
+  if ! is_go_binary "$artifact"; then
+    return
+  fi
+  if ! is_fips_build "$artifact"; then
+    record_status event=fips-verification artifact="$relative_path" result=failed reason="not a FIPS build"
+    echo "the node requires FIPS but ${relative_path} is not a FIPS build"
+    return 1
+  fi
+  record_status event=fips-verification artifact="$relative_path" result=verified
+}
+
+# Prints the FIPS compliance of the binaries installed on the node as a JSON object, with whether each Go
+# binary is a FIPS build and whether all of them are.
+fips_compliance() {
+  local binaries="" name binary compliant=true
+  for name in kubeadm kubelet kubectl containerd; do
+    case "$name" in
+      kubeadm) binary=$KUBEADM_BIN ;;
+      kubelet) binary=$KUBELET_BIN ;;
+      kubectl) binary=$KUBECTL_BIN ;;
+      containerd) binary=$CONTAINERD_BIN ;;
+    esac
+    if ! is_go_binary "$binary"; then
+      continue
+    fi
+    if is_fips_build "$binary"; then
+      binaries+="${binaries:+,}\"${name}\":true"
+    else
+      binaries+="${binaries:+,}\"${name}\":false"
+      compliant=false
+    fi
+  done
+  printf '{"binaries":{%s},"compliant":%s}' "$binaries" "$compliant"
+}
+
+verify_artifacts_in_dir() {
+  local -r artifacts_dir=$1
+
+  local artifact
+  while IFS= read -r -d '' artifact; do
+    verify_artifact "$artifact"
+  done < <(find "$artifacts_dir" -type f -print0)
+}
+
+# Prints the name of the static pod defined by the manifest.
+static_pod_name() {
+  awk '/^metadata:/ { in_metadata = 1; next } in_metadata && /^[^ ]/ { exit } in_metadata && $1 == "name:" { gsub(/["\047]/, "", $2); print $2; exit }' "$1"
+}
+
+# Prints the IDs of the ready pod sandboxes of the static pod with the given name. The kubelet
+# suffixes the names of static pods with the node name.
+static_pod_sandboxes() {
+  crictl pods --name "^${1}-" --state ready -q 2>/dev/null || true
+}
+
+validate_static_pod_manifest() {
+  local -r manifest=$1
+
+  if ! grep -qE '^kind:[[:space:]]*Pod[[:space:]]*$' "$manifest" || [ -z "$(static_pod_name "$manifest")" ]; then
+    echo "${manifest} is not a valid static pod manifest"
+    return 1
+  fi
+  if [ -f "$ADMIN_KUBECONFIG" ]; then
+    "$KUBECTL_BIN" create --dry-run=client --validate=false -o name -f "$manifest" --kubeconfig "$ADMIN_KUBECONFIG"
+  fi
+}
+
+# Writes the manifest to a hidden file first, which the kubelet ignores, so that it never
+# picks up a partially written manifest.
+install_static_pod_manifest() {
+  local -r source_manifest=$1
+  local -r manifest=$2
+  local -r temp_manifest="$(dirname "$manifest")/.$(basename "$manifest").tmp"
+
+  cp "$source_manifest" "$temp_manifest"
+  mv -f "$temp_manifest" "$manifest"
+}
+
+# Waits until a pod sandbox of the static pod other than the given previous sandboxes is ready
+# and its containers have been running without exiting for STATIC_POD_STABLE_SECONDS, which
+# catches pods that start and then crash loop.
+wait_for_static_pod() {
+  local -r pod_name=$1
+  local -r previous_sandboxes=$2
+  local -r deadline=$(($(date +%s) + STATIC_POD_READY_TIMEOUT_SECONDS))
+
+  local sandbox running_since=0
+  while [ "$(date +%s)" -lt "$deadline" ]; do
+    sandbox=$(static_pod_sandboxes "$pod_name" | grep -vxF -e "$previous_sandboxes" | head -n 1 || true)
+    if [ -n "$sandbox" ] && [ -n "$(crictl ps --pod "$sandbox" --state running -q)" ] && [ -z "$(crictl ps --pod "$sandbox" --state exited -q)" ]; then
+      if [ "$running_since" -eq 0 ]; then
+        running_since=$(date +%s)
+      fi
+      if [ $(($(date +%s) - running_since)) -ge "$STATIC_POD_STABLE_SECONDS" ]; then
+        return 0
+      fi
+    else
+      running_since=0
+    fi
+    sleep 5
+  done
+
+  echo "static pod ${pod_name} did not become ready"
+  return 1
+}
+
+# Replaces a static pod manifest after validating the new manifest, and restores the previous
+# manifest if the pod does not come back with the new one.
+update_static_pod_manifest() {
+  local -r new_manifest=$1
+  local -r manifest=$2
+  local -r backup_manifest=$3
+
+  validate_static_pod_manifest "$new_manifest"
+  if [ -f "$manifest" ] && cmp -s "$new_manifest" "$manifest"; then
+    echo "Static pod manifest ${manifest} is up to date"
+    return 0
+  fi
+
+  local -r pod_name=$(static_pod_name "$new_manifest")
+  local -r previous_sandboxes=$(static_pod_sandboxes "$pod_name")
+  cp "$manifest" "$backup_manifest"
+  install_static_pod_manifest "$new_manifest" "$manifest"
+  if wait_for_static_pod "$pod_name" "$previous_sandboxes"; then
+    record_status event=static-pod-manifest manifest="$manifest" result=updated
+    return 0
+  fi
+
+  echo "Restoring previous static pod manifest ${manifest}"
+  local -r failed_sandboxes=$(static_pod_sandboxes "$pod_name")
+  install_static_pod_manifest "$backup_manifest" "$manifest"
+  wait_for_static_pod "$pod_name" "$failed_sandboxes" || true
+  record_status event=static-pod-manifest manifest="$manifest" result=restored
+  return 1
+}
+
+# Checks that the static pods whose manifests were rewritten by kubeadm come back, restoring the
+# manifests saved in the backup bundle for those that do not. The previous sandboxes of the pods
+# are read from the file written by save_static_pod_sandboxes before kubeadm ran.
+verify_static_pods_or_restore() {
+  local -r sandboxes_file=$1
+  local -r bundle_dir=$(cat "$(upgrade_components_dir)/backup-bundle")
+  local -r previous_manifests_dir=$(mktemp -d)
+  tar -xzf "${bundle_dir}/files.tar.gz" -C "$previous_manifests_dir" "${STATIC_POD_MANIFESTS_DIR#/}"
+
+  local manifest previous_manifest pod_name failed_sandboxes failed=false
+  for manifest in "$STATIC_POD_MANIFESTS_DIR"/*.yaml; do
+    previous_manifest="${previous_manifests_dir}${STATIC_POD_MANIFESTS_DIR}/$(basename "$manifest")"
+    if [ ! -f "$previous_manifest" ] || cmp -s "$manifest" "$previous_manifest"; then
+      continue
+    fi
+
+    pod_name=$(static_pod_name "$manifest")
+    if wait_for_static_pod "$pod_name" "$(awk -v pod="$pod_name" '$1 == pod { print $2 }' "$sandboxes_file")"; then
+      record_status event=static-pod-manifest manifest="$manifest" result=updated
+      continue
+    fi
+
+    echo "Restoring static pod manifest ${manifest} from backup bundle ${bundle_dir}"
+    failed_sandboxes=$(static_pod_sandboxes "$pod_name")
+    install_static_pod_manifest "$previous_manifest" "$manifest"
+    wait_for_static_pod "$pod_name" "$failed_sandboxes" || true
+    record_status event=static-pod-manifest manifest="$manifest" result=restored
+    failed=true
+  done
+  rm -rf "$previous_manifests_dir"
+
+  if [ "$failed" = "true" ]; then
+    return 1
+  fi
+}
+
+# Saves the ready sandboxes of the static pods, one "<pod> <sandbox>" pair per line, so that
+# the pods recreated from rewritten manifests can be told apart from the previous ones.
+save_static_pod_sandboxes() {
+  local -r sandboxes_file=$1
+
+  local manifest pod_name sandbox
+  : > "$sandboxes_file"
+  for manifest in "$STATIC_POD_MANIFESTS_DIR"/*.yaml; do
+    pod_name=$(static_pod_name "$manifest")
+    for sandbox in $(static_pod_sandboxes "$pod_name"); do
+      echo "${pod_name} ${sandbox}" >> "$sandboxes_file"
+    done
+  done
+}
+
+# Prints the version of an image reference without the EKS-D build suffix of its tag, for example v1.10.1
+# for public.ecr.aws/eks-distro/coredns/coredns:v1.10.1-eks-1-28-6.
+image_tag_version() {
+  local -r tag=${1##*:}
+  echo "${tag%%-*}"
+}
+
+# Prints the version of the image a kube-system workload runs, or nothing when it does not exist.
+kube_system_workload_version() {
+  local -r kind=$1
+  local -r name=$2
+  local image
+  image=$(retry "$KUBECTL_BIN" get "$kind" -n kube-system "$name" -ojsonpath='{.spec.template.spec.containers[0].image}' --kubeconfig "$ADMIN_KUBECONFIG" --ignore-not-found=true)
+  if [ -n "$image" ]; then
+    image_tag_version "$image"
+  fi
+}
+
+# Emits a warning event for the node, so that it shows up next to the node's other events during the
+# upgrade. Failing to emit it does not fail the upgrade.
+emit_node_warning_event() {
+  local -r reason=$1
+  local -r message=$2
+  local -r node_name=${NODE_NAME:-$(hostname)}
+  local -r now=$(date -u +%Y-%m-%dT%H:%M:%SZ)
+
+  "$KUBECTL_BIN" create --kubeconfig "$ADMIN_KUBECONFIG" -f - << EOF || echo "unable to emit ${reason} event for node ${node_name}"
+apiVersion: v1
+kind: Event
+metadata:
+  generateName: eksa-upgrader-
+  namespace: default
+involvedObject:
+  apiVersion: v1
+  kind: Node
+  name: ${node_name}
+type: Warning
+reason: ${reason}
+message: "${message}"
+source:
+  component: eksa-upgrader
+  host: ${node_name}
+firstTimestamp: ${now}
+lastTimestamp: ${now}
+count: 1
+EOF
+}
+
+report_addon_version_skew() {
+  local -r addon=$1
+  local -r version=$2
+  local -r kube_version=$3
+  local -r message=$4
+
+  echo "warning: ${message}"
+  record_status event=addon-version-skew addon="$addon" version="$version" kubernetes_version="$kube_version" message="$message"
+  emit_node_warning_event AddonVersionSkew "$message"
+}
+
+# Checks the versions of CoreDNS and kube-proxy running in the cluster against the Kubernetes version the
+# control plane was upgraded to, warning when they need to be upgraded as well. CoreDNS is compared with
+# the version kubeadm deploys for the target version, since its upgrades are skipped by kubeadm here, and
+# kube-proxy may be at most three minor versions older than the control plane and never newer.
+check_addon_version_skew() {
+  local -r kube_version=$1
+  local -r target_minor=$(echo "$kube_version" | cut -d. -f2)
+
+  local -r expected_coredns=$(image_tag_version "$("$KUBEADM_BIN" config images list --kubernetes-version "$kube_version" | grep /coredns)")
+  local -r coredns=$(kube_system_workload_version deployment coredns)
+  if [ -n "$coredns" ] && [ "$(printf '%s\n' "$coredns" "$expected_coredns" | sort -V | head -n1)" != "$expected_coredns" ]; then
+    report_addon_version_skew coredns "$coredns" "$kube_version" "CoreDNS ${coredns} is older than ${expected_coredns}, the version Kubernetes ${kube_version} is released with. Upgrade CoreDNS along with the cluster."
+  fi
+
+  local -r kube_proxy=$(kube_system_workload_version daemonset kube-proxy)
+  if [ -n "$kube_proxy" ]; then
+    local -r kube_proxy_minor=$(echo "$kube_proxy" | cut -d. -f2)
+    if [ "$kube_proxy_minor" -gt "$target_minor" ]; then
+      report_addon_version_skew kube-proxy "$kube_proxy" "$kube_version" "kube-proxy ${kube_proxy} is newer than the control plane at Kubernetes ${kube_version}, which is not supported."
+    elif [ $((target_minor - kube_proxy_minor)) -gt 3 ]; then
+      report_addon_version_skew kube-proxy "$kube_proxy" "$kube_version" "kube-proxy ${kube_proxy} is more than three minor versions older than the control plane at Kubernetes ${kube_version}, which is not supported. Upgrade kube-proxy to ${kube_version}."
+    elif [ "$kube_proxy_minor" -lt "$target_minor" ]; then
+      report_addon_version_skew kube-proxy "$kube_proxy" "$kube_version" "kube-proxy ${kube_proxy} is older than the control plane at Kubernetes ${kube_version}. Upgrade kube-proxy to ${kube_version}."
+    fi
+  fi
 }
 
 kubeadm_in_first_cp(){
   kube_version=$1
   etcd_version="${2:-NO_UPDATE}"
 
+  create_backup_bundle
+
   components_dir=$(upgrade_components_kubernetes_bin_dir)
 
-  backup_and_replace /usr/bin/kubeadm "$components_dir" "$components_dir/kubeadm"
+  backup_and_replace "$KUBEADM_BIN" "$components_dir" "$(bundle_artifact_path kubeadm)"
 
 
   kubeadm_config_backup="${components_dir}/kubeadm-config.backup.yaml"
   new_kubeadm_config="${components_dir}/kubeadm-config.yaml"
-  kubectl get cm -n kube-system kubeadm-config -ojsonpath='{.data.ClusterConfiguration}' --kubeconfig /etc/kubernetes/admin.conf > "$kubeadm_config_backup"
+  retry "$KUBECTL_BIN" get cm -n kube-system kubeadm-config -ojsonpath='{.data.ClusterConfiguration}' --kubeconfig "$ADMIN_KUBECONFIG" > "$kubeadm_config_backup"
 
   if [ "$etcd_version" != "NO_UPDATE" ]; then
     sed -zE "s/(imageRepository: public.ecr.aws\/eks-distro\/etcd-io\n\s+imageTag: )[^\n]*/\1${etcd_version}/" "$kubeadm_config_backup" > "$new_kubeadm_config"
@@ -60,7 +1085,7 @@ kubeadm_in_first_cp(){
 
   # the kubelet config appears to lose values, in the case of a kind cluster the failSwapOn:false
   echo "---" >> "$new_kubeadm_config"
-  kubectl get cm -n kube-system kubelet-config -ojsonpath='{.data.kubelet}' --kubeconfig /etc/kubernetes/admin.conf >> "$new_kubeadm_config"
+  retry "$KUBECTL_BIN" get cm -n kube-system kubelet-config -ojsonpath='{.data.kubelet}' --kubeconfig "$ADMIN_KUBECONFIG" >> "$new_kubeadm_config"
 
   # Backup and delete coredns configmap. If the CM doesn't exist, kubeadm will skip its upgrade.
   # This is desirable for 2 reasons:
@@ -72,22 +1097,30 @@ kubeadm_in_first_cp(){
   # TODO: consider using --skip-phases to skip addons/coredns once the feature flag is supported in kubeadm upgrade command
   backup_and_delete_coredns_config "$components_dir"
 
-  kubeadm version
-  kubeadm upgrade plan --ignore-preflight-errors=CoreDNSUnsupportedPlugins,CoreDNSMigration --config "$new_kubeadm_config"
-  kubeadm upgrade apply "$kube_version" --config "$new_kubeadm_config" --ignore-preflight-errors=CoreDNSUnsupportedPlugins,CoreDNSMigration --allow-experimental-upgrades --yes
-  
-  new_kubevip_config_path="$(upgrade_components_dir)/kube-vip.yaml"
-  static_kubevip_path="/etc/kubernetes/manifests/kube-vip.yaml"
-  cp "${static_kubevip_path}" "$(upgrade_components_dir)/kube-vip.backup.yaml"
-  cp "${new_kubevip_config_path}" "${static_kubevip_path}"
+  static_pod_sandboxes_file="$(upgrade_components_dir)/static-pod-sandboxes"
+  save_static_pod_sandboxes "$static_pod_sandboxes_file"
+
+  "$KUBEADM_BIN" version
+  "$KUBEADM_BIN" upgrade plan --ignore-preflight-errors=CoreDNSUnsupportedPlugins,CoreDNSMigration --config "$new_kubeadm_config"
+  timeout "$KUBEADM_UPGRADE_TIMEOUT_SECONDS" "$KUBEADM_BIN" upgrade apply "$kube_version" --config "$new_kubeadm_config" --ignore-preflight-errors=CoreDNSUnsupportedPlugins,CoreDNSMigration --allow-experimental-upgrades --yes
 
   restore_coredns_config "$components_dir"
+
+  verify_static_pods_or_restore "$static_pod_sandboxes_file"
+
+  new_kubevip_config_path="$(upgrade_components_dir)/kube-vip.yaml"
+  static_kubevip_path="${STATIC_POD_MANIFESTS_DIR}/kube-vip.yaml"
+  update_static_pod_manifest "${new_kubevip_config_path}" "${static_kubevip_path}" "$(upgrade_components_dir)/kube-vip.backup.yaml"
+
+  check_addon_version_skew "$kube_version"
 }
 
 kubeadm_in_rest_cp(){
+  create_backup_bundle
+
   components_dir=$(upgrade_components_kubernetes_bin_dir)
 
-  backup_and_replace /usr/bin/kubeadm "$components_dir" "$components_dir/kubeadm"
+  backup_and_replace "$KUBEADM_BIN" "$components_dir" "$(bundle_artifact_path kubeadm)"
 
   # Backup and delete coredns configmap. If the CM doesn't exist, kubeadm will skip its upgrade.
   # This is desirable for 2 reasons:
@@ -99,15 +1132,19 @@ kubeadm_in_rest_cp(){
   # TODO: consider using --skip-phases to skip addons/coredns once the feature flag is supported in kubeadm upgrade command
   backup_and_delete_coredns_config "$components_dir"
 
-  kubeadm version
-  kubeadm upgrade node --ignore-preflight-errors=CoreDNSUnsupportedPlugins,CoreDNSMigration
+  static_pod_sandboxes_file="$(upgrade_components_dir)/static-pod-sandboxes"
+  save_static_pod_sandboxes "$static_pod_sandboxes_file"
+
+  "$KUBEADM_BIN" version
+  timeout "$KUBEADM_UPGRADE_TIMEOUT_SECONDS" "$KUBEADM_BIN" upgrade node --ignore-preflight-errors=CoreDNSUnsupportedPlugins,CoreDNSMigration
 
-  new_kubevip_config_path="$(upgrade_components_dir)/kube-vip.yaml"
-  static_kubevip_path="/etc/kubernetes/manifests/kube-vip.yaml"
-  cp "${static_kubevip_path}" "$(upgrade_components_dir)/kube-vip.backup.yaml"
-  cp "${new_kubevip_config_path}" "${static_kubevip_path}"
-  
   restore_coredns_config "$components_dir"
+
+  verify_static_pods_or_restore "$static_pod_sandboxes_file"
+
+  new_kubevip_config_path="$(upgrade_components_dir)/kube-vip.yaml"
+  static_kubevip_path="${STATIC_POD_MANIFESTS_DIR}/kube-vip.yaml"
+  update_static_pod_manifest "${new_kubevip_config_path}" "${static_kubevip_path}" "$(upgrade_components_dir)/kube-vip.backup.yaml"
 }
 
 backup_and_delete_coredns_config(){
@@ -121,38 +1158,49 @@ backup_and_delete_coredns_config(){
   # it doesn't seem like there is an option.
   # TODO: consider using --skip-phases to skip addons/coredns once the feature flag is supported in kubeadm upgrade command
   coredns_backup="${components_dir}/coredns.yaml"
-  coredns=$(kubectl get cm -n kube-system coredns -oyaml --kubeconfig /etc/kubernetes/admin.conf --ignore-not-found=true)
+  coredns=$(retry "$KUBECTL_BIN" get cm -n kube-system coredns -oyaml --kubeconfig "$ADMIN_KUBECONFIG" --ignore-not-found=true)
   if [ -n "$coredns" ]; then
     echo "$coredns" >"$coredns_backup"
   fi
-  kubectl delete cm -n kube-system coredns --kubeconfig /etc/kubernetes/admin.conf --ignore-not-found=true
+  retry "$KUBECTL_BIN" delete cm -n kube-system coredns --kubeconfig "$ADMIN_KUBECONFIG" --ignore-not-found=true
 }
 
 restore_coredns_config(){
   components_dir=$1
   coredns_backup="${components_dir}/coredns.yaml"
   # Restore coredns config from backup
-  kubectl create -f "$coredns_backup" --kubeconfig /etc/kubernetes/admin.conf
+  "$KUBECTL_BIN" create -f "$coredns_backup" --kubeconfig "$ADMIN_KUBECONFIG"
 }
 
 kubeadm_in_worker() {
+  create_backup_bundle
+
   components_dir=$(upgrade_components_kubernetes_bin_dir)
 
-  backup_and_replace /usr/bin/kubeadm "$components_dir" "$components_dir/kubeadm"
+  backup_and_replace "$KUBEADM_BIN" "$components_dir" "$(bundle_artifact_path kubeadm)"
 
-  kubeadm version
-  kubeadm upgrade node 
+  "$KUBEADM_BIN" version
+  timeout "$KUBEADM_UPGRADE_TIMEOUT_SECONDS" "$KUBEADM_BIN" upgrade node
 }
 
 kubelet_and_kubectl() {
-  kube_version=$(kubeadm version -oshort)
+  create_backup_bundle
+  cgroups_preflight
+
+  kube_version=$("$KUBEADM_BIN" version -oshort)
 
   components_dir=$(upgrade_components_kubernetes_bin_dir)
 
-  backup_and_replace /usr/bin/kubectl "$components_dir" "$components_dir/kubectl"
+  migrate_kubelet_flags "$kube_version"
+  refresh_credential_provider "$kube_version"
+  if [ -n "$PAUSE_IMAGE" ]; then
+    update_pause_image "$PAUSE_IMAGE"
+  fi
+
+  backup_and_replace "$KUBECTL_BIN" "$components_dir" "$(bundle_artifact_path kubectl)"
 
-  systemctl stop kubelet
-  backup_and_replace /usr/bin/kubelet "$components_dir" "$components_dir/kubelet"
+  systemctl stop "$KUBELET_SERVICE"
+  backup_and_replace "$KUBELET_BIN" "$components_dir" "$(bundle_artifact_path kubelet)"
 
   # KubeletCredentialProviders support became GA in k8s v1.26, and the feature gate was removed in k8s v1.28.
   # For in-place upgrades, we should remove this feature gate if it exists on nodes running k8s v1.26 and above.
@@ -161,11 +1209,147 @@ kubelet_and_kubectl() {
   fi
 
   systemctl daemon-reload
-  systemctl restart kubelet
+  systemctl restart "$KUBELET_SERVICE"
+  wait_for_service "$KUBELET_SERVICE" "$KUBELET_READY_TIMEOUT_SECONDS"
+  wait_for_node_ready
+  validate_cgroups
+}
+
+# Kubelet command-line flags that are deprecated or removed, with the minor version from which they are
+# migrated. Flags with a KubeletConfiguration field are moved to the kubelet configuration file, flags
+# with - are dropped because they have no effect on containerd nodes, and the others cannot be migrated.
+# <flag>|<KubeletConfiguration field>|<minor version>
+kubelet_deprecated_flags() {
+  cat << EOF
+network-plugin|-|1.24
+network-plugin-mtu|-|1.24
+cni-bin-dir|-|1.24
+cni-conf-dir|-|1.24
+cni-cache-dir|-|1.24
+docker-endpoint|-|1.24
+image-pull-progress-deadline|-|1.24
+experimental-dockershim-root-directory|-|1.24
+non-masquerade-cidr|-|1.24
+dynamic-config-dir||1.24
+cgroup-driver|cgroupDriver|1.24
+container-runtime|-|1.27
+container-runtime-endpoint|containerRuntimeEndpoint|1.27
+image-service-endpoint|imageServiceEndpoint|1.27
+azure-container-registry-config||1.30
+EOF
+}
+
+# Prints the files the kubelet's command-line flags are set in: its extra args file, the flags file written
+# by kubeadm and the drop-ins of its systemd service.
+kubelet_flag_files() {
+  echo "$KUBELET_EXTRA_ARGS_FILE"
+  echo "$(dirname "$KUBELET_CONFIG_FILE")/kubeadm-flags.env"
+  local drop_in
+  for drop_in in "${SYSTEMD_UNIT_DIR}/${KUBELET_SERVICE}.service.d"/*.conf; do
+    if [ -f "$drop_in" ]; then
+      echo "$drop_in"
+    fi
+  done
+}
+
+# Prints the value of a kubelet command-line flag, or nothing if the flag is not set.
+kubelet_flag_value() {
+  local -r flag=$1
+  local file value
+  while IFS= read -r file; do
+    if [ ! -f "$file" ]; then
+      continue
+    fi
+    value=$(grep -oE -- "--${flag}(=|[[:space:]]+)[^-[:space:]\"'][^[:space:]\"']*" "$file" | head -n 1 | sed -E "s/^--${flag}(=|[[:space:]]+)//" || true)
+    if [ -n "$value" ]; then
+      echo "$value"
+      return
+    fi
+  done < <(kubelet_flag_files)
+}
+
+# Sets a top-level field of the kubelet configuration file, quoting values that are not numbers or booleans.
+set_kubelet_config_field() {
+  local -r field=$1
+  local value=$2
+  if [[ ! "$value" =~ ^([0-9]+|true|false)$ ]]; then
+    value="\"${value}\""
+  fi
+
+  if grep -q "^${field}:" "$KUBELET_CONFIG_FILE"; then
+    sed -i "s|^${field}:.*|${field}: ${value}|" "$KUBELET_CONFIG_FILE"
+  else
+    echo "${field}: ${value}" >> "$KUBELET_CONFIG_FILE"
+  fi
+}
+
+# Removes every occurrence of a string from a file, along with the space separating it from the
+# surrounding text.
+remove_from_file() {
+  local -r file=$1
+  local -r text=$2
+  local -r contents=$(cat "$file")
+  local updated=${contents//" ${text}"/}
+  updated=${updated//"${text} "/}
+  updated=${updated//"${text}"/}
+  echo "$updated" > "$file"
+}
+
+# Migrates the deprecated kubelet flags set in the kubelet's environment files and systemd drop-ins to
+# the kubelet configuration file before the kubelet of the target Kubernetes version is started, and
+# fails listing the flags that cannot be migrated, which the kubelet would refuse to start with. Fields
+# set in the configuration file are replaced by the next kubeadm upgrade, which drops the migrated flags
+# from the kubelet's defaults.
+migrate_kubelet_flags() {
+  local -r kube_version=$1
+  local -r target_minor=$(echo "$kube_version" | cut -d. -f2)
+
+  local files
+  mapfile -t files < <(kubelet_flag_files)
+
+  local unmigratable_flags=()
+  local flag field version file occurrence value
+  while IFS='|' read -r flag field version; do
+    if [ "$target_minor" -lt "${version#*.}" ]; then
+      continue
+    fi
+    for file in "${files[@]}"; do
+      if [ ! -f "$file" ]; then
+        continue
+      fi
+      while IFS= read -r occurrence; do
+        case "$field" in
+          "")
+            unmigratable_flags+=("--${flag} in ${file}")
+            continue
+            ;;
+          -)
+            echo "Removing --${flag}, which has no effect since Kubernetes v${version}, from ${file}"
+            ;;
+          *)
+            value=${occurrence#--"${flag}"}
+            value=${value#=}
+            value=${value#"${value%%[![:space:]]*}"}
+            echo "Migrating --${flag} in ${file} to ${field} in ${KUBELET_CONFIG_FILE}"
+            set_kubelet_config_field "$field" "$value"
+            ;;
+        esac
+        record_status event=kubelet-flag-migration flag="$flag" file="$file" field="$field"
+        remove_from_file "$file" "$occurrence"
+      done < <(grep -oE -- "--${flag}(=[^[:space:]\"']*|[[:space:]]+[^-[:space:]\"'][^[:space:]\"']*)" "$file" || true)
+    done
+  done < <(kubelet_deprecated_flags)
+
+  if [ ${#unmigratable_flags[@]} -gt 0 ]; then
+    echo "the kubelet of Kubernetes ${kube_version} does not support these flags, which cannot be migrated to its configuration file:"
+    printf '  %s\n' "${unmigratable_flags[@]}"
+    echo "Remove them, or replace them with their documented alternatives, then retry the upgrade."
+    return 1
+  fi
 }
 
 update_kubelet_extra_args() {
-  kubelet_conf="/etc/sysconfig/kubelet"
+  kubelet_conf=$KUBELET_EXTRA_ARGS_FILE
   if [ ! -f ${kubelet_conf} ]; then
     echo "kubelet config file ${kubelet_conf} not found, skipping"
     return
@@ -180,54 +1364,622 @@ update_kubelet_extra_args() {
   fi
 }
 
+# Prints the path of the kubelet's image credential provider configuration, or nothing if the kubelet does not
+# use credential providers.
+credential_provider_config() {
+  echo "${CREDENTIAL_PROVIDER_CONFIG:-$(kubelet_flag_value image-credential-provider-config)}"
+}
+
+# Prints the directory the kubelet runs the image credential provider binaries from.
+credential_provider_bin_dir() {
+  echo "${CREDENTIAL_PROVIDER_BIN_DIR:-$(kubelet_flag_value image-credential-provider-bin-dir)}"
+}
+
+# Prints the command-line arguments and environment variables the credential provider configuration sets for
+# a provider, as "arg <value>" and "env <name>=<value>" lines. Only block-style YAML lists are supported.
+credential_provider_exec_settings() {
+  local -r config_file=$1
+  local -r provider=$2
+  awk -v provider="$provider" '
+    function unquote(value) { gsub(/^["\047]|["\047]$/, "", value); return value }
+    $0 ~ "^[[:space:]]*-?[[:space:]]*name:[[:space:]]*[\"\047]?" provider "[\"\047]?[[:space:]]*$" && !in_provider {
+      in_provider = 1; indent = match($0, /[^ -]/); next
+    }
+    !in_provider { next }
+    match($0, /[^ -]/) <= indent && /^[[:space:]]*-/ { exit }
+    match($0, /[^ -]/) <= indent { block = "" }
+    match($0, /[^ -]/) == indent && /^[[:space:]]*args:[[:space:]]*$/ { block = "args"; next }
+    match($0, /[^ -]/) == indent && /^[[:space:]]*env:[[:space:]]*$/ { block = "env"; next }
+    block == "args" && /^[[:space:]]*-/ { value = $0; sub(/^[[:space:]]*-[[:space:]]*/, "", value); print "arg " unquote(value) }
+    block == "env" && /name:/ { name = $0; sub(/.*name:[[:space:]]*/, "", name); name = unquote(name) }
+    block == "env" && /value:/ { value = $0; sub(/.*value:[[:space:]]*/, "", value); print "env " name "=" unquote(value) }
+  ' "$config_file"
+}
+
+# Pulls CREDENTIAL_PROVIDER_VALIDATION_IMAGE, an image in a registry the credential provider authenticates to,
+# with the credentials the provider returns when run the way the kubelet runs it, to check that the node can
+# still pull images from the registry. Tracing is turned off while the credentials are handled so that they
+# are not written to the upgrade logs, and they are passed to crictl in the CRICTL_AUTH environment variable,
+# read from an auth file only root can read that is deleted after the pull, rather than on its command line,
+# where any user on the node could read them from the process list.
+validate_credential_provider_pull() {
+  local -r config_file=$1
+  local -r bin_dir=$2
+  local -r image=$CREDENTIAL_PROVIDER_VALIDATION_IMAGE
+  if [ -z "$image" ]; then
+    echo "CREDENTIAL_PROVIDER_VALIDATION_IMAGE is not set, skipping the image pull validation"
+    return
+  fi
+
+  local -r provider=$(sed -nE 's/^[[:space:]]*-?[[:space:]]*name:[[:space:]]*["\x27]?([a-z0-9][a-z0-9.-]*)["\x27]?[[:space:]]*$/\1/p' "$config_file" | head -n 1)
+  local -r api_version=$(grep -oE 'credentialprovider\.kubelet\.k8s\.io/v[0-9a-z]+' "$config_file" | head -n 1)
+  if [ -z "$provider" ] || [ -z "$api_version" ] || [ ! -x "${bin_dir}/${provider}" ]; then
+    echo "unable to find the credential provider to validate in ${config_file} and ${bin_dir}"
+    return 1
+  fi
+
+  local args=() envs=() kind value
+  while read -r kind value; do
+    if [ "$kind" = "arg" ]; then
+      args+=("$value")
+    else
+      envs+=("$value")
+    fi
+  done < <(credential_provider_exec_settings "$config_file" "$provider")
+
+  echo "Pulling ${image} with the credentials of ${provider}"
+  { set +x; } 2> /dev/null
+  local response username password auth_file
+  local pulled=true
+  response=$(echo "{\"apiVersion\":\"${api_version}\",\"kind\":\"CredentialProviderRequest\",\"image\":\"${image}\"}" \
+    | env "${envs[@]}" "${bin_dir}/${provider}" "${args[@]}") || pulled=false
+  username=$(echo "$response" | sed -nE 's/.*"username":"([^"]*)".*/\1/p')
+  password=$(echo "$response" | sed -nE 's/.*"password":"([^"]*)".*/\1/p')
+  if [ "$pulled" = false ] || [ -z "$username" ] || [ -z "$password" ]; then
+    echo "${provider} did not return credentials for ${image}"
+    pulled=false
+  else
+    auth_file=$(mktemp)
+    chmod 600 "$auth_file"
+    printf '%s:%s' "$username" "$password" | base64 -w 0 > "$auth_file"
+    if ! CRICTL_AUTH=$(< "$auth_file") crictl pull "$image"; then
+      echo "unable to pull ${image} with the credentials of ${provider}"
+      pulled=false
+    fi
+    rm -f "$auth_file"
+  fi
+  set -x
+
+  [ "$pulled" = true ]
+}
+
+# Installs the image credential provider binaries shipped in the upgrade bundle, such as the ECR credential
+# provider, in the directory the kubelet runs them from, for the providers the kubelet is configured with,
+# and migrates the credential provider configuration to the v1 APIs from Kubernetes v1.26, where they became
+# GA. Nodes whose kubelet is not configured with credential providers are left as they are. The image pulls
+# are validated afterwards, and the previous binaries and configuration are restored if they fail.
+refresh_credential_provider() {
+  local -r kube_version=$1
+  local -r target_minor=$(echo "$kube_version" | cut -d. -f2)
+  local -r config_file=$(credential_provider_config)
+  local -r bin_dir=$(credential_provider_bin_dir)
+  if [ -z "$config_file" ] || [ -z "$bin_dir" ] || [ ! -f "$config_file" ]; then
+    echo "The kubelet is not configured with image credential providers, skipping"
+    return
+  fi
+
+  local -r backup_dir="$(upgrade_components_dir)/backup/credential-provider"
+  mkdir -p "$backup_dir"
+  local replaced_files=()
+
+  local source_dir
+  source_dir=$(bundle_artifact_path credential-provider) || return
+  local provider name
+  for provider in "$source_dir"/*; do
+    name=$(basename "$provider")
+    if [ ! -f "$provider" ] || ! grep -qE "name:[[:space:]]*[\"']?${name}[\"']?[[:space:]]*$" "$config_file"; then
+      continue
+    fi
+    if cmp -s "$provider" "${bin_dir}/${name}"; then
+      continue
+    fi
+    echo "Installing credential provider ${name} in ${bin_dir}"
+    verify_artifact "$provider"
+    if [ -f "${bin_dir}/${name}" ]; then
+      backup_file "${bin_dir}/${name}" "$backup_dir"
+    fi
+    mkdir -p "$bin_dir"
+    replace_file_atomically "$provider" "${bin_dir}/${name}"
+    chmod 755 "${bin_dir}/${name}"
+    replaced_files+=("${bin_dir}/${name}")
+  done
+
+  if [ "$target_minor" -ge 26 ] && grep -qE '(kubelet\.config|credentialprovider\.kubelet)\.k8s\.io/v1(alpha1|beta1)' "$config_file"; then
+    echo "Migrating ${config_file} to the v1 credential provider APIs"
+    backup_file "$config_file" "$backup_dir"
+    sed -i -E 's#(kubelet\.config|credentialprovider\.kubelet)\.k8s\.io/v1(alpha1|beta1)#\1.k8s.io/v1#g' "$config_file"
+    replaced_files+=("$config_file")
+  fi
+
+  if ! validate_credential_provider_pull "$config_file" "$bin_dir"; then
+    local file
+    for file in "${replaced_files[@]}"; do
+      if [ -f "${backup_dir}/$(basename "$file").bk" ]; then
+        replace_file_atomically "${backup_dir}/$(basename "$file").bk" "$file"
+      else
+        rm -f "$file"
+      fi
+    done
+    record_status event=credential-provider outcome=failed config="$config_file" bin_dir="$bin_dir"
+    echo "restored the previous credential provider binaries and configuration"
+    return 1
+  fi
+  record_status event=credential-provider outcome=succeeded config="$config_file" bin_dir="$bin_dir" updated="${#replaced_files[@]}"
+}
+
+# Prints the sandbox image containerd is configured with.
+containerd_sandbox_image() {
+  sed -nE 's/^[[:space:]]*sandbox_image[[:space:]]*=[[:space:]]*"([^"]*)".*/\1/p' "$CONTAINERD_CONFIG" | head -n1
+}
+
+# Prints the sandbox image to configure for a target pause image. When the configured image is pulled
+# through a registry mirror, such as in air-gapped environments, only its tag is updated so that the new
+# image is pulled through the same mirror.
+resolve_sandbox_image() {
+  local -r current_image=$1
+  local -r pause_image=$2
+  local -r pause_repository=${pause_image%:*}
+  local -r current_repository=${current_image%:*}
+
+  if [ "$current_repository" != "$pause_repository" ] && [[ "$current_repository" == */"${pause_repository#*/}" ]]; then
+    echo "${current_repository}:${pause_image##*:}"
+  else
+    echo "$pause_image"
+  fi
+}
+
+wait_for_containerd() {
+  local -r deadline=$(($(date +%s) + CONTAINERD_READY_TIMEOUT_SECONDS))
+  while [ "$(date +%s)" -lt "$deadline" ]; do
+    if crictl info > /dev/null 2>&1; then
+      return 0
+    fi
+    sleep 5
+  done
+
+  echo "containerd did not become ready"
+  return 1
+}
+
+# Runs and removes a pod sandbox to check that containerd can create sandboxes with the configured image.
+validate_pod_sandbox_creation() {
+  local -r sandbox_config=$(mktemp)
+  cat > "$sandbox_config" << EOF
+{
+  "metadata": {
+    "name": "eksa-upgrader-sandbox-check",
+    "namespace": "kube-system",
+    "uid": "eksa-upgrader-sandbox-check-$(date +%s)",
+    "attempt": 1
+  },
+  "log_directory": "/tmp",
+  "linux": {}
+}
+EOF
+  local sandbox
+  sandbox=$(crictl runp "$sandbox_config") || {
+    rm -f "$sandbox_config"
+    echo "unable to create a pod sandbox with the sandbox image $(containerd_sandbox_image)"
+    return 1
+  }
+  rm -f "$sandbox_config"
+  crictl stopp "$sandbox"
+  crictl rmp "$sandbox"
+}
+
+restart_containerd() {
+  systemctl daemon-reload
+  systemctl restart "$CONTAINERD_SERVICE"
+  wait_for_containerd
+}
+
+# Updates the sandbox image containerd creates pods with to the pause image the target Kubernetes version
+# requires. The image is pulled before containerd is restarted so that an unreachable registry or mirror
+# does not leave the node unable to create pods, and the previous configuration is restored if containerd
+# cannot create a sandbox once restarted. The kubelet's --pod-infra-container-image flag, which keeps the
+# image from being garbage collected, is updated to match.
+update_pause_image() {
+  local -r pause_image=$1
+  local -r current_image=$(containerd_sandbox_image)
+  if [ -z "$current_image" ]; then
+    echo "sandbox_image not found in ${CONTAINERD_CONFIG}"
+    return 1
+  fi
+
+  local -r sandbox_image=$(resolve_sandbox_image "$current_image" "$pause_image")
+  if [ "$sandbox_image" = "$current_image" ]; then
+    echo "containerd already uses the sandbox image ${sandbox_image}"
+    return
+  fi
+
+  retry crictl pull "$sandbox_image"
+
+  local -r config_backup="$(upgrade_components_dir)/containerd-config.backup.toml"
+  cp "$CONTAINERD_CONFIG" "$config_backup"
+  sed -i -E "s|^([[:space:]]*sandbox_image[[:space:]]*=[[:space:]]*)\"[^\"]*\"|\1\"${sandbox_image}\"|" "$CONTAINERD_CONFIG"
+  if ! "$CONTAINERD_BIN" --config "$CONTAINERD_CONFIG" config dump > /dev/null; then
+    echo "updated containerd configuration ${CONTAINERD_CONFIG} is invalid, restoring it"
+    cp "$config_backup" "$CONTAINERD_CONFIG"
+    return 1
+  fi
+
+  if ! restart_containerd || ! validate_pod_sandbox_creation; then
+    echo "restoring the containerd configuration with the sandbox image ${current_image}"
+    cp "$config_backup" "$CONTAINERD_CONFIG"
+    restart_containerd
+    record_status event=pause-image outcome=failed from="$current_image" to="$sandbox_image"
+    return 1
+  fi
+  record_status event=pause-image outcome=succeeded from="$current_image" to="$sandbox_image"
+
+  local -r kubeadm_flags_file="$(dirname "$KUBELET_CONFIG_FILE")/kubeadm-flags.env"
+  if [ -f "$kubeadm_flags_file" ]; then
+    sed -i -E "s|--pod-infra-container-image=[^[:space:]\"']*|--pod-infra-container-image=${sandbox_image}|" "$kubeadm_flags_file"
+  fi
+}
+
 upgrade_containerd() {
-  components_dir=$(upgrade_components_bin_dir)
+  cgroups_preflight
+  upgrade_from_plan containerd
+  wait_for_service "$CONTAINERD_SERVICE" "$CONTAINERD_READY_TIMEOUT_SECONDS"
+  validate_cgroups
+}
 
-  containerd --version
+cni_plugins() {
+  upgrade_from_plan cni-plugins
+}
 
-  # before nsenter, copy /eksa-upgrades to /tmp/eksa-upgrades
-  # IDEA: similar to the kubeadm flow, we could loop the folder structure
-  # and for each binary/config file backup the existing file before copying 
-  # so there is a rollback path
-  cp -rf $components_dir/containerd/* /
+upgrade_plan_file() {
+  echo "${UPGRADE_PLAN_FILE:-$(script_dir)/upgrade-plan}"
+}
 
-  containerd --version
+# Upgrades the components listed in the plan file, in the order they are listed.
+# If component names are given, only those components are upgraded. The plan file
+# shipped next to this script is used unless UPGRADE_PLAN_FILE is set. Each line of the plan file describes one component, with the fields separated by "|":
+#   <component>|<target version>|<artifact source>|<pre-upgrade hook>|<post-upgrade hook>
+# The artifact source is a directory laid out like the root filesystem, relative to the
+# binaries directory of the upgrade components unless absolute. The hooks are shell commands
+# that can call the functions of this script, and the target version is only informational.
+upgrade_from_plan() {
+  local -r plan_file=$(upgrade_plan_file)
+  local -r requested_components=" $* "
 
+  if [ ! -f "$plan_file" ]; then
+    echo "upgrade plan ${plan_file} not found"
+    return 1
+  fi
+
+  create_backup_bundle
+
+  local component version source pre_hook post_hook
+  local upgraded_components=" "
+  # The plan is read from a separate file descriptor so that hooks reading stdin do not consume it.
+  while IFS='|' read -r -u 3 component version source pre_hook post_hook; do
+    if [[ -z "$component" || "$component" == \#* ]]; then
+      continue
+    fi
+    if [ -n "${requested_components// /}" ] && [[ "$requested_components" != *" $component "* ]]; then
+      continue
+    fi
+    upgrade_component "$component" "$version" "$source" "$pre_hook" "$post_hook"
+    upgraded_components+="$component "
+  done 3< "$plan_file"
+
+  for component in $requested_components; do
+    if [[ "$upgraded_components" != *" $component "* ]]; then
+      echo "component ${component} not found in upgrade plan ${plan_file}"
+      return 1
+    fi
+  done
+}
+
+upgrade_component() {
+  local -r component=$1
+  local -r version=$2
+  local source=$3
+  local -r pre_hook=$4
+  local -r post_hook=$5
+
+  if [[ "$source" != /* ]]; then
+    source=$(bundle_artifact_path "$source")
+  fi
+  if [ ! -d "$source" ]; then
+    echo "artifact source ${source} for component ${component} not found"
+    return 1
+  fi
+
+  verify_artifacts_in_dir "$source"
+
+  echo "Upgrading ${component}${version:+ to ${version}}"
+  if [ -n "$pre_hook" ]; then
+    eval "$pre_hook"
+  fi
+
+  copy_tree_with_backup "$source" "$(upgrade_components_dir)/backup/${component}"
+
+  if [ -n "$post_hook" ]; then
+    eval "$post_hook"
+  fi
+}
+
+# Copies a directory laid out like the root filesystem onto the root filesystem,
+# backing up the files it overwrites first so there is a rollback path.
+copy_tree_with_backup() {
+  local -r source_dir=$1
+  local -r backup_dir=$2
+
+  local relative_path
+  while IFS= read -r -d '' relative_path; do
+    if [ -e "/${relative_path}" ] && [ ! -e "${backup_dir}/${relative_path}" ]; then
+      mkdir -p "${backup_dir}/$(dirname "$relative_path")"
+      cp -a "/${relative_path}" "${backup_dir}/${relative_path}"
+    fi
+  done < <(cd "$source_dir" && find . \( -type f -o -type l \) -printf '%P\0')
+
+  # Files are replaced one at a time so that a binary in use, such as containerd, is never left truncated.
+  # systemd units are installed separately, so that the ones customized on the node are kept.
+  while IFS= read -r -d '' relative_path; do
+    if [[ "$relative_path" == etc/systemd/system/* ]]; then
+      continue
+    fi
+    mkdir -p "/$(dirname "$relative_path")"
+    if [ -L "${source_dir}/${relative_path}" ]; then
+      cp -af "${source_dir}/${relative_path}" "/${relative_path}"
+    else
+      replace_file_atomically "${source_dir}/${relative_path}" "/${relative_path}"
+    fi
+  done < <(cd "$source_dir" && find . \( -type f -o -type l \) -printf '%P\0')
+
+  install_systemd_units "$source_dir"
+}
+
+systemd_units_state_file() {
+  echo "$(upgrader_state_dir)/systemd-units.sha256"
+}
+
+# Records the checksum of a systemd unit file or drop-in installed by the upgrader.
+record_systemd_unit_file() {
+  local -r unit_file=$1
+  local -r state_file=$(systemd_units_state_file)
+
+  mkdir -p "$(upgrader_state_dir)"
+  {
+    if [ -f "$state_file" ]; then
+      awk -v path="$unit_file" '$2 != path' "$state_file"
+    fi
+    sha256sum "$unit_file"
+  } > "${state_file}.new"
+  mv -f "${state_file}.new" "$state_file"
+}
+
+shipped_systemd_units_file() {
+  echo "$(upgrade_components_bin_dir)/shipped-systemd-units.sha256"
+}
+
+# Records the systemd units and drop-ins on the node that match a version shipped by this or a previous
+# upgrader image, as listed with their paths relative to SYSTEMD_UNIT_DIR in the shipped units file, as
+# installed by the upgrader. Units installed by upgraders that did not record them, or by the OS image
+# from the same component artifacts, are then replaced like the ones the upgrader recorded.
+seed_systemd_units_state() {
+  local -r shipped_units_file=$(shipped_systemd_units_file)
+  if [ ! -f "$shipped_units_file" ]; then
+    return
+  fi
+  verify_artifact "$shipped_units_file"
+
+  local checksum relative_path unit_file
+  while read -r checksum relative_path; do
+    unit_file="${SYSTEMD_UNIT_DIR}/${relative_path}"
+    if [ ! -f "$unit_file" ] || grep -qxF "$(sha256sum "$unit_file")" "$(systemd_units_state_file)" 2>/dev/null; then
+      continue
+    fi
+    if [ "$(sha256sum "$unit_file" | cut -d' ' -f1)" = "$checksum" ]; then
+      record_systemd_unit_file "$unit_file"
+    fi
+  done < "$shipped_units_file"
+}
+
+# Installs a systemd unit file or drop-in shipped with an upgraded component. An installed file is only
+# replaced when it matches a version installed or shipped by the upgrader, which the checksums recorded in
+# the state directory tell once seed_systemd_units_state ran, so that units and drop-ins provided or
+# customized by operators or by the OS image are kept, with a warning when they differ from the shipped ones.
+install_systemd_unit_file() {
+  local -r new_file=$1
+  local -r unit_file=$2
+
+  if [ -f "$unit_file" ] && cmp -s "$new_file" "$unit_file"; then
+    record_systemd_unit_file "$unit_file"
+    return
+  fi
+  if [ -f "$unit_file" ] && ! grep -qxF "$(sha256sum "$unit_file")" "$(systemd_units_state_file)" 2>/dev/null; then
+    echo "warning: keeping ${unit_file}, which matches no version shipped by the upgrader and differs from the one shipped with the upgrade"
+    record_status event=systemd-unit action=kept path="$unit_file"
+    return
+  fi
+
+  mkdir -p "$(dirname "$unit_file")"
+  replace_file_atomically "$new_file" "$unit_file"
+  record_systemd_unit_file "$unit_file"
+  record_status event=systemd-unit action=installed path="$unit_file"
+}
+
+# Installs the systemd units and drop-ins shipped under etc/systemd/system in a component's artifacts to
+# SYSTEMD_UNIT_DIR, leaving the other drop-ins of the units in place, and reloads systemd.
+install_systemd_units() {
+  local -r source_units_dir="${1}/etc/systemd/system"
+  if [ ! -d "$source_units_dir" ]; then
+    return
+  fi
+
+  seed_systemd_units_state
+
+  local relative_path
+  while IFS= read -r -d '' relative_path; do
+    install_systemd_unit_file "${source_units_dir}/${relative_path}" "${SYSTEMD_UNIT_DIR}/${relative_path}"
+  done < <(cd "$source_units_dir" && find . -type f -printf '%P\0')
   systemctl daemon-reload
-  # systemctl stop containerd
+}
 
-  systemctl restart containerd
+# Waits for a systemd service to be active after it was restarted, failing with its status otherwise.
+wait_for_service() {
+  local -r service=$1
+  local -r deadline=$(($(date +%s) + $2))
+  while [ "$(date +%s)" -lt "$deadline" ]; do
+    if [ "$(systemctl is-active "$service")" = "active" ]; then
+      return 0
+    fi
+    sleep 5
+  done
 
+  systemctl status "$service" --no-pager || true
+  echo "${service} did not start"
+  return 1
 }
 
-cni_plugins() {  
-  components_dir=$(upgrade_components_bin_dir)
-  
-  /opt/cni/bin/loopback --version
+# Waits for the node to be Ready once the kubelet was restarted, using the kubelet's kubeconfig.
+wait_for_node_ready() {
+  local -r kubeconfig="${KUBERNETES_CONFIG_DIR}/kubelet.conf"
+  local -r node_name=${NODE_NAME:-$(hostname)}
+  if [ ! -f "$kubeconfig" ]; then
+    echo "kubelet kubeconfig ${kubeconfig} not found, skipping the node readiness check"
+    return
+  fi
+
+  local -r deadline=$(($(date +%s) + KUBELET_READY_TIMEOUT_SECONDS))
+  while [ "$(date +%s)" -lt "$deadline" ]; do
+    if [ "$("$KUBECTL_BIN" get node "$node_name" --kubeconfig "$kubeconfig" -ojsonpath='{.status.conditions[?(@.type=="Ready")].status}' 2>/dev/null)" = "True" ]; then
+      return 0
+    fi
+    sleep 5
+  done
+
+  echo "node ${node_name} did not become Ready within ${KUBELET_READY_TIMEOUT_SECONDS} seconds"
+  return 1
+}
 
-  # before nsenter, copy /eksa-upgrades to /tmp/eksa-upgrades
-  # IDEA: similar to the kubeadm flow, we could loop the folder structure
-  # and for each binary/config file backup the existing file before copying 
-  # so there is a rollback path
-  cp -rf $components_dir/cni-plugins/* /
+# Prints a JSON string, or null for an empty value.
+json_value() {
+  if [ -n "$1" ]; then
+    printf '"%s"' "$(json_escape "$1")"
+  else
+    printf 'null'
+  fi
+}
 
-  /opt/cni/bin/loopback --version
+# Prints the last record of the upgrade status file containing the given text, or null.
+last_status_record() {
+  local record=""
+  if [ -f "$(upgrade_status_file)" ]; then
+    record=$(grep -F "$1" "$(upgrade_status_file)" | tail -n 1 || true)
+  fi
+  printf '%s' "${record:-null}"
+}
 
-  # rm -rf /foo/eksa-upgrades
+# Prints the steps the controller runs to upgrade the node, in order. Alternative steps, of which only
+# one runs on a given node, are separated by |.
+upgrade_steps() {
+  local kubeadm_step=kubeadm_in_worker
+  if [ -f "${STATIC_POD_MANIFESTS_DIR}/kube-apiserver.yaml" ]; then
+    kubeadm_step="kubeadm_in_first_cp|kubeadm_in_rest_cp"
+  fi
+  echo upgrade_containerd cni_plugins "$kubeadm_step" kubelet_and_kubectl print_status_and_cleanup
+}
+
+# Prints the upgrade state of the node as a JSON object: the versions of the installed components, the
+# state of the kubelet and containerd services and of the cgroup configuration, whether the node requires
+# FIPS, whether the upgrade bundle has FIPS builds and whether the installed binaries are FIPS builds,
+# the last step and upgrade recorded in the status file, the upgrade in progress with the steps it has left, and the most recent
+# backup and failure bundles. The upgrade in progress starts with the step creating the backup bundle,
+# and its steps are pending until they have succeeded since.
+status() {
+  local components="" key value
+  while IFS='=' read -r key value; do
+    components+="${components:+,}\"${key}\":$(json_value "$value")"
+  done < <(installed_component_versions)
+
+  local -r marker_file="$(upgrade_components_dir)/backup-bundle"
+  local started_at="" step alternative completed
+  local pending_steps=""
+  if [ -f "$marker_file" ]; then
+    started_at=$(date -u -d "@$(stat -c %Y "$marker_file")" +%Y-%m-%dT%H:%M:%SZ)
+  fi
+  for step in $(upgrade_steps); do
+    completed=false
+    if [ -n "$started_at" ] && [ -f "$(upgrade_status_file)" ]; then
+      for alternative in ${step//|/ }; do
+        if grep -F "\"event\":\"phase\",\"phase\":\"${alternative}\",\"outcome\":\"succeeded\"" "$(upgrade_status_file)" \
+          | sed -E 's/^\{"time":"([^"]*)".*/\1/' | awk -v since="$started_at" '$0 >= since { found = 1 } END { exit !found }'; then
+          completed=true
+        fi
+      done
+    fi
+    if [ "$completed" = false ]; then
+      pending_steps+="${pending_steps:+,}$(json_value "$step")"
+    fi
+  done
+
+  local latest_backup="" latest_failure_bundle=""
+  if [ -d "$(upgrade_backups_dir)" ]; then
+    latest_backup=$(find "$(upgrade_backups_dir)" -mindepth 1 -maxdepth 1 -type d | sort | tail -n 1)
+  fi
+  if [ -d "$(upgrade_failure_bundles_dir)" ]; then
+    latest_failure_bundle=$(find "$(upgrade_failure_bundles_dir)" -mindepth 1 -maxdepth 1 -name '*.tar.gz' -printf '%T@ %p\n' | sort -n | tail -n 1 | cut -d' ' -f2)
+  fi
+
+  local sandbox_image=""
+  if [ -f "$CONTAINERD_CONFIG" ]; then
+    sandbox_image=$(containerd_sandbox_image)
+  fi
+
+  printf '{"node":%s,"components":{%s},"sandboxImage":%s,' "$(json_value "${NODE_NAME:-$(hostname)}")" "$components" "$(json_value "$sandbox_image")"
+  printf '"services":{"kubelet":%s,"containerd":%s},' "$(json_value "$(systemctl is-active "$KUBELET_SERVICE" 2>/dev/null || true)")" "$(json_value "$(systemctl is-active "$CONTAINERD_SERVICE" 2>/dev/null || true)")"
+  printf '"cgroups":{"version":%s,"kubeletDriver":%s,"containerdDriver":%s},' "$(cgroup_version)" "$(json_value "$(kubelet_cgroup_driver)")" "$(json_value "$(containerd_cgroup_driver)")"
+  printf '"fips":{"mode":%s,"required":%s,"bundled":%s,"installed":%s},' "$(json_value "$FIPS_MODE")" "$(fips_required && echo true || echo false)" "$([ -d "$(upgrade_components_bin_dir)/fips" ] && echo true || echo false)" "$(fips_compliance)"
+  printf '"upgrade":{"startedAt":%s,"pendingSteps":[%s]},' "$(json_value "$started_at")" "$pending_steps"
+  printf '"lastStep":%s,"lastUpgrade":%s,' "$(last_status_record '"event":"phase"')" "$(last_status_record '"event":"upgrade"')"
+  printf '"latestBackup":%s,"latestFailureBundle":%s}\n' "$(json_value "$latest_backup")" "$(json_value "$latest_failure_bundle")"
 }
 
 print_status() {
-  systemctl status containerd
-  systemctl status kubelet
-  kubeadm version
+  systemctl status "$CONTAINERD_SERVICE"
+  systemctl status "$KUBELET_SERVICE"
+  "$KUBEADM_BIN" version
 }
 
 print_status_and_cleanup() {
   print_status
 
   components_dir=$(upgrade_components_dir)
+  # The backup bundle marker is written by the first step of the upgrade.
+  if [ -f "${components_dir}/backup-bundle" ]; then
+    record_status event=upgrade outcome=succeeded duration_seconds="$(($(date +%s) - $(stat -c %Y "${components_dir}/backup-bundle")))"
+  fi
   echo "Deleting all leftover upgrade components at ${components_dir}"
   rm -rf "$components_dir"
 }
 
-$@
+while [[ "${1:-}" == --*=* ]]; do
+  set_flag "$1"
+  shift
+done
+# The config is loaded first so that the host access settings can be set in UPGRADER_CONFIG_FILE. The
+# settings read from it are exported, so they carry over to the script re-executed on the host.
+load_config
+run_on_host "$@"
+
+# status only inspects the node, so it is not recorded as an upgrade step.
+if [ "${1:-}" = "status" ]; then
+  status
+elif [ $# -gt 0 ]; then
+  run_phase $@
+fi
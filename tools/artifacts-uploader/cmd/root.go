@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/artifacts-uploader/pkg/util/logger"
+)
+
+// rootCmd is the top-level artifacts-uploader command used to upload build artifacts to S3.
+var rootCmd = &cobra.Command{
+	Use:              "artifacts-uploader",
+	Short:            "Amazon EKS Anywhere Build-tooling Artifacts Uploader",
+	Long:             "Use artifacts-uploader to upload build artifacts from aws/eks-anywhere-build-tooling projects to S3 with integrity checks",
+	PersistentPreRun: rootPersistentPreRun,
+}
+
+func init() {
+	rootCmd.PersistentFlags().IntP("verbosity", "v", 0, "Set the logging verbosity level")
+	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
+		log.Fatalf("failed to bind flags to root command: %v", err)
+	}
+}
+
+func Execute() error {
+	return rootCmd.Execute()
+}
+
+func rootPersistentPreRun(cmd *cobra.Command, args []string) {
+	if err := initLogger(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func initLogger() error {
+	if err := logger.Init(viper.GetInt("verbosity")); err != nil {
+		return fmt.Errorf("failed to init Zap logger in root command: %v", err)
+	}
+
+	return nil
+}
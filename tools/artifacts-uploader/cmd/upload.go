@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/artifacts-uploader/pkg/commands/upload"
+	"github.com/aws/eks-anywhere-build-tooling/tools/artifacts-uploader/pkg/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/artifacts-uploader/pkg/types"
+)
+
+var uploadOptions = &types.UploadOptions{}
+
+// uploadCmd is the command used to upload a directory of build artifacts to S3.
+var uploadCmd = &cobra.Command{
+	Use:   "upload --source-dir <directory> --destination <s3 URI>",
+	Short: "Upload a directory of build artifacts to S3",
+	Long:  "Use this command to upload a directory of build artifacts to an S3 location, skipping artifacts that are already present with the same digest and retrying failed uploads",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := upload.Run(uploadOptions)
+		if err != nil {
+			log.Fatalf("Error uploading artifacts: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(uploadCmd)
+	uploadCmd.Flags().StringVar(&uploadOptions.SourceDirectory, "source-dir", "", "Specify the local directory containing the artifacts to upload")
+	uploadCmd.Flags().StringVar(&uploadOptions.Destination, "destination", "", "Specify the S3 URI (s3://<bucket>/<prefix>) to upload the artifacts to")
+	uploadCmd.Flags().BoolVar(&uploadOptions.Delete, "delete", false, "Delete objects under the destination prefix that don't exist in the source directory, which requires a non-empty prefix")
+	uploadCmd.Flags().BoolVar(&uploadOptions.PublicACL, "public-acl", false, "Grant public read access to the uploaded objects")
+	uploadCmd.Flags().BoolVar(&uploadOptions.DryRun, "dry-run", false, "Print the operations that would be performed without uploading or deleting anything")
+	uploadCmd.Flags().IntVar(&uploadOptions.MaxAttempts, "max-attempts", constants.DefaultMaxUploadAttempts, "Maximum number of attempts for uploading each artifact")
+	for _, flag := range []string{"source-dir", "destination"} {
+		if err := uploadCmd.MarkFlagRequired(flag); err != nil {
+			log.Fatalf("Error marking flag %q as required: %v", flag, err)
+		}
+	}
+}
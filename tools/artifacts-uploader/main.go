@@ -0,0 +1,14 @@
+package main
+
+import (
+	"os"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/artifacts-uploader/cmd"
+)
+
+func main() {
+	if cmd.Execute() == nil {
+		os.Exit(0)
+	}
+	os.Exit(-1)
+}
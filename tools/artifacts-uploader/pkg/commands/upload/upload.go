@@ -0,0 +1,41 @@
+package upload
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/artifacts-uploader/pkg/s3"
+	"github.com/aws/eks-anywhere-build-tooling/tools/artifacts-uploader/pkg/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/artifacts-uploader/pkg/util/logger"
+)
+
+// Run contains the business logic to execute the `upload` subcommand.
+func Run(uploadOptions *types.UploadOptions) error {
+	if uploadOptions.MaxAttempts < 1 {
+		return fmt.Errorf("max attempts must be at least 1, got %d", uploadOptions.MaxAttempts)
+	}
+
+	bucket, prefix, err := s3.ParseS3URI(uploadOptions.Destination)
+	if err != nil {
+		return fmt.Errorf("parsing destination S3 URI: %v", err)
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		return fmt.Errorf("loading AWS configuration: %v", err)
+	}
+	client := awss3.NewFromConfig(cfg)
+
+	uploader := s3.NewUploader(client, uploadOptions.MaxAttempts, uploadOptions.PublicACL, uploadOptions.DryRun)
+	summary, err := uploader.UploadDirectory(context.Background(), uploadOptions.SourceDirectory, bucket, prefix, uploadOptions.Delete)
+	if err != nil {
+		return fmt.Errorf("uploading artifacts to %s: %v", uploadOptions.Destination, err)
+	}
+
+	logger.Info("Completed artifacts upload", "Destination", uploadOptions.Destination, "Uploaded", len(summary.Uploaded), "Skipped", len(summary.Skipped), "Deleted", len(summary.Deleted))
+
+	return nil
+}
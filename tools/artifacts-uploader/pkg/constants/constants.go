@@ -0,0 +1,13 @@
+package constants
+
+import "time"
+
+// Constants used across the artifacts-uploader source code.
+const (
+	S3URIScheme              = "s3://"
+	DigestMetadataKey        = "sha256"
+	DefaultMaxUploadAttempts = 5
+	MultipartPartSize        = 64 * 1024 * 1024
+	MultipartConcurrency     = 5
+	UploadRetryBaseDelay     = 2 * time.Second
+)
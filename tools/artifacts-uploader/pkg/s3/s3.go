@@ -0,0 +1,298 @@
+package s3
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/artifacts-uploader/pkg/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/artifacts-uploader/pkg/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/artifacts-uploader/pkg/util/logger"
+)
+
+// Client is the subset of the S3 API used by the uploader.
+type Client interface {
+	manager.UploadAPIClient
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+}
+
+// Uploader uploads directories of build artifacts to S3, tagging each object with its SHA256 digest
+// and skipping objects that are already up-to-date.
+type Uploader struct {
+	client      Client
+	uploader    *manager.Uploader
+	maxAttempts int
+	retryDelay  time.Duration
+	publicACL   bool
+	dryRun      bool
+}
+
+// NewUploader returns an Uploader that uses the given S3 client.
+func NewUploader(client Client, maxAttempts int, publicACL, dryRun bool) *Uploader {
+	return &Uploader{
+		client: client,
+		uploader: manager.NewUploader(client, func(u *manager.Uploader) {
+			u.PartSize = constants.MultipartPartSize
+			u.Concurrency = constants.MultipartConcurrency
+		}),
+		maxAttempts: maxAttempts,
+		retryDelay:  constants.UploadRetryBaseDelay,
+		publicACL:   publicACL,
+		dryRun:      dryRun,
+	}
+}
+
+// ParseS3URI splits an S3 URI of the form s3://<bucket>/<prefix> into bucket and prefix.
+func ParseS3URI(uri string) (string, string, error) {
+	if !strings.HasPrefix(uri, constants.S3URIScheme) {
+		return "", "", fmt.Errorf("S3 URI [%s] must start with %s", uri, constants.S3URIScheme)
+	}
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(uri, constants.S3URIScheme), "/")
+	if bucket == "" {
+		return "", "", fmt.Errorf("S3 URI [%s] does not specify a bucket", uri)
+	}
+
+	return bucket, strings.Trim(prefix, "/"), nil
+}
+
+// UploadDirectory uploads all files in the source directory under the given bucket and prefix. If deleteExtra is
+// set, objects under the prefix that don't correspond to a file in the source directory are deleted, which requires
+// a non-empty prefix so that the rest of the bucket is never deleted.
+func (u *Uploader) UploadDirectory(ctx context.Context, sourceDirectory, bucket, prefix string, deleteExtra bool) (types.UploadSummary, error) {
+	var summary types.UploadSummary
+
+	if deleteExtra && prefix == "" {
+		return summary, fmt.Errorf("refusing to delete stale objects in the root of bucket %s, specify a prefix to upload to", bucket)
+	}
+
+	relativePaths, err := listFiles(sourceDirectory)
+	if err != nil {
+		return summary, fmt.Errorf("listing files in source directory %s: %v", sourceDirectory, err)
+	}
+
+	uploadedKeys := map[string]bool{}
+	for _, relativePath := range relativePaths {
+		key := objectKey(prefix, relativePath)
+		uploadedKeys[key] = true
+
+		uploaded, err := u.uploadFile(ctx, filepath.Join(sourceDirectory, relativePath), bucket, key)
+		if err != nil {
+			return summary, fmt.Errorf("uploading %s to s3://%s/%s: %v", relativePath, bucket, key, err)
+		}
+		if uploaded {
+			summary.Uploaded = append(summary.Uploaded, key)
+		} else {
+			summary.Skipped = append(summary.Skipped, key)
+		}
+	}
+
+	if deleteExtra {
+		deleted, err := u.deleteExtraObjects(ctx, bucket, prefix, uploadedKeys)
+		if err != nil {
+			return summary, fmt.Errorf("deleting stale objects under s3://%s/%s: %v", bucket, prefix, err)
+		}
+		summary.Deleted = deleted
+	}
+
+	return summary, nil
+}
+
+// uploadFile uploads a single file to S3 unless an object with the same digest already exists at the key.
+// It returns whether the file was uploaded.
+func (u *Uploader) uploadFile(ctx context.Context, filePath, bucket, key string) (bool, error) {
+	digest, err := fileDigest(filePath)
+	if err != nil {
+		return false, fmt.Errorf("computing digest of %s: %v", filePath, err)
+	}
+
+	existingDigest, err := u.objectDigest(ctx, bucket, key)
+	if err != nil {
+		return false, fmt.Errorf("getting digest of existing object: %v", err)
+	}
+	if existingDigest == digest {
+		logger.V(4).Info("Skipping upload of unchanged artifact", "Key", key, "Digest", digest)
+		return false, nil
+	}
+
+	if u.dryRun {
+		logger.Info("(dry run) Uploading artifact", "Source", filePath, "Destination", fmt.Sprintf("s3://%s/%s", bucket, key))
+		return true, nil
+	}
+
+	var uploadErr error
+	for attempt := 1; attempt <= u.maxAttempts; attempt++ {
+		uploadErr = u.putFile(ctx, filePath, bucket, key, digest)
+		if uploadErr == nil {
+			break
+		}
+		logger.Info("Artifact upload attempt failed", "Key", key, "Attempt", attempt, "Error", uploadErr)
+		if attempt < u.maxAttempts {
+			time.Sleep(u.retryDelay * time.Duration(attempt))
+		}
+	}
+	if uploadErr != nil {
+		return false, fmt.Errorf("uploading artifact after %d attempts: %v", u.maxAttempts, uploadErr)
+	}
+
+	// Verify that the object stored in S3 carries the digest of the local file.
+	uploadedDigest, err := u.objectDigest(ctx, bucket, key)
+	if err != nil {
+		return false, fmt.Errorf("verifying digest of uploaded object: %v", err)
+	}
+	if uploadedDigest != digest {
+		return false, fmt.Errorf("digest mismatch after upload: expected %s, got %s", digest, uploadedDigest)
+	}
+	logger.Info("Uploaded artifact", "Destination", fmt.Sprintf("s3://%s/%s", bucket, key), "Digest", digest)
+
+	return true, nil
+}
+
+// putFile performs a single (possibly multipart) upload of the file, asking S3 to verify its SHA256 checksum.
+func (u *Uploader) putFile(ctx context.Context, filePath, bucket, key, digest string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("opening %s: %v", filePath, err)
+	}
+	defer file.Close()
+
+	input := &s3.PutObjectInput{
+		Bucket:            aws.String(bucket),
+		Key:               aws.String(key),
+		Body:              file,
+		ChecksumAlgorithm: s3types.ChecksumAlgorithmSha256,
+		Metadata: map[string]string{
+			constants.DigestMetadataKey: digest,
+		},
+	}
+	if u.publicACL {
+		input.ACL = s3types.ObjectCannedACLPublicRead
+	}
+
+	_, err = u.uploader.Upload(ctx, input)
+	return err
+}
+
+// objectDigest returns the digest recorded in the metadata of the object at the given key, or an empty
+// string if the object doesn't exist.
+func (u *Uploader) objectDigest(ctx context.Context, bucket, key string) (string, error) {
+	output, err := u.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && (apiErr.ErrorCode() == "NotFound" || apiErr.ErrorCode() == "NoSuchKey") {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return output.Metadata[constants.DigestMetadataKey], nil
+}
+
+// deleteExtraObjects deletes the objects under the prefix that are not in the set of keys to keep.
+func (u *Uploader) deleteExtraObjects(ctx context.Context, bucket, prefix string, keep map[string]bool) ([]string, error) {
+	var deleted []string
+	if prefix == "" {
+		return nil, fmt.Errorf("prefix must not be empty")
+	}
+
+	paginator := s3.NewListObjectsV2Paginator(u.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix + "/"),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing objects: %v", err)
+		}
+		for _, object := range page.Contents {
+			key := aws.ToString(object.Key)
+			if keep[key] {
+				continue
+			}
+			if u.dryRun {
+				logger.Info("(dry run) Deleting stale artifact", "Destination", fmt.Sprintf("s3://%s/%s", bucket, key))
+			} else {
+				_, err := u.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+					Bucket: aws.String(bucket),
+					Key:    aws.String(key),
+				})
+				if err != nil {
+					return nil, fmt.Errorf("deleting object %s: %v", key, err)
+				}
+				logger.Info("Deleted stale artifact", "Destination", fmt.Sprintf("s3://%s/%s", bucket, key))
+			}
+			deleted = append(deleted, key)
+		}
+	}
+
+	return deleted, nil
+}
+
+// listFiles returns the sorted slash-separated paths of all regular files under the directory.
+func listFiles(directory string) ([]string, error) {
+	var relativePaths []string
+	err := filepath.WalkDir(directory, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(directory, filePath)
+		if err != nil {
+			return err
+		}
+		relativePaths = append(relativePaths, filepath.ToSlash(relativePath))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(relativePaths)
+
+	return relativePaths, nil
+}
+
+// objectKey joins the prefix and relative path into an S3 object key.
+func objectKey(prefix, relativePath string) string {
+	if prefix == "" {
+		return relativePath
+	}
+	return path.Join(prefix, relativePath)
+}
+
+// fileDigest returns the hex-encoded SHA256 digest of the file.
+func fileDigest(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,190 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+type fakeClient struct {
+	objects   map[string]map[string]string
+	putCalls  int
+	putErrors int
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{objects: map[string]map[string]string{}}
+}
+
+func (f *fakeClient) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	f.putCalls++
+	if f.putErrors > 0 {
+		f.putErrors--
+		return nil, &smithy.GenericAPIError{Code: "InternalError"}
+	}
+	if _, err := io.ReadAll(params.Body); err != nil {
+		return nil, err
+	}
+	f.objects[aws.ToString(params.Key)] = params.Metadata
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeClient) UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+	return &s3.UploadPartOutput{}, nil
+}
+
+func (f *fakeClient) CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	return &s3.CreateMultipartUploadOutput{}, nil
+}
+
+func (f *fakeClient) CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	return &s3.CompleteMultipartUploadOutput{}, nil
+}
+
+func (f *fakeClient) AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+func (f *fakeClient) HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	metadata, ok := f.objects[aws.ToString(params.Key)]
+	if !ok {
+		return nil, &smithy.GenericAPIError{Code: "NotFound"}
+	}
+	return &s3.HeadObjectOutput{Metadata: metadata}, nil
+}
+
+func (f *fakeClient) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	var keys []string
+	for key := range f.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	output := &s3.ListObjectsV2Output{}
+	for _, key := range keys {
+		output.Contents = append(output.Contents, s3types.Object{Key: aws.String(key)})
+	}
+	return output, nil
+}
+
+func (f *fakeClient) DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	delete(f.objects, aws.ToString(params.Key))
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func writeArtifacts(t *testing.T, files map[string]string) string {
+	t.Helper()
+	directory := t.TempDir()
+	for name, contents := range files {
+		filePath := filepath.Join(directory, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return directory
+}
+
+func TestParseS3URI(t *testing.T) {
+	tests := []struct {
+		uri        string
+		wantBucket string
+		wantPrefix string
+		wantErr    bool
+	}{
+		{uri: "s3://bucket/projects/aws/upgrader/", wantBucket: "bucket", wantPrefix: "projects/aws/upgrader"},
+		{uri: "s3://bucket", wantBucket: "bucket", wantPrefix: ""},
+		{uri: "/local/path", wantErr: true},
+		{uri: "s3:///prefix", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			bucket, prefix, err := ParseS3URI(tt.uri)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseS3URI() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if bucket != tt.wantBucket || prefix != tt.wantPrefix {
+				t.Errorf("ParseS3URI() = (%s, %s), want (%s, %s)", bucket, prefix, tt.wantBucket, tt.wantPrefix)
+			}
+		})
+	}
+}
+
+func TestUploadDirectorySkipsUnchangedArtifacts(t *testing.T) {
+	directory := writeArtifacts(t, map[string]string{
+		"a.tar.gz":        "a",
+		"nested/b.tar.gz": "b",
+	})
+	client := newFakeClient()
+	uploader := NewUploader(client, 1, false, false)
+
+	summary, err := uploader.UploadDirectory(context.Background(), directory, "bucket", "latest", false)
+	if err != nil {
+		t.Fatalf("UploadDirectory() error = %v", err)
+	}
+	if want := []string{"latest/a.tar.gz", "latest/nested/b.tar.gz"}; !reflect.DeepEqual(summary.Uploaded, want) {
+		t.Errorf("UploadDirectory() uploaded = %v, want %v", summary.Uploaded, want)
+	}
+
+	summary, err = uploader.UploadDirectory(context.Background(), directory, "bucket", "latest", false)
+	if err != nil {
+		t.Fatalf("UploadDirectory() error = %v", err)
+	}
+	if len(summary.Uploaded) != 0 || len(summary.Skipped) != 2 {
+		t.Errorf("UploadDirectory() re-upload = %+v, want all artifacts skipped", summary)
+	}
+	if client.putCalls != 2 {
+		t.Errorf("PutObject called %d times, want 2", client.putCalls)
+	}
+}
+
+func TestUploadDirectoryRetriesAndDeletesStaleObjects(t *testing.T) {
+	directory := writeArtifacts(t, map[string]string{"a.tar.gz": "a"})
+	client := newFakeClient()
+	client.objects["latest/stale.tar.gz"] = map[string]string{}
+	client.putErrors = 1
+	uploader := NewUploader(client, 2, false, false)
+	uploader.retryDelay = 0
+
+	summary, err := uploader.UploadDirectory(context.Background(), directory, "bucket", "latest", true)
+	if err != nil {
+		t.Fatalf("UploadDirectory() error = %v", err)
+	}
+	if want := []string{"latest/a.tar.gz"}; !reflect.DeepEqual(summary.Uploaded, want) {
+		t.Errorf("UploadDirectory() uploaded = %v, want %v", summary.Uploaded, want)
+	}
+	if want := []string{"latest/stale.tar.gz"}; !reflect.DeepEqual(summary.Deleted, want) {
+		t.Errorf("UploadDirectory() deleted = %v, want %v", summary.Deleted, want)
+	}
+	if client.putCalls != 2 {
+		t.Errorf("PutObject called %d times, want 2", client.putCalls)
+	}
+}
+
+func TestUploadDirectoryRefusesDeleteWithoutPrefix(t *testing.T) {
+	directory := writeArtifacts(t, map[string]string{"a.tar.gz": "a"})
+	client := newFakeClient()
+	client.objects["other-project/artifact.tar.gz"] = map[string]string{}
+	uploader := NewUploader(client, 1, false, false)
+
+	if _, err := uploader.UploadDirectory(context.Background(), directory, "bucket", "", true); err == nil {
+		t.Fatal("UploadDirectory() error = nil, want error for delete without prefix")
+	}
+	if client.putCalls != 0 {
+		t.Errorf("PutObject called %d times, want 0", client.putCalls)
+	}
+	if _, ok := client.objects["other-project/artifact.tar.gz"]; !ok {
+		t.Error("UploadDirectory() deleted an object outside the destination prefix")
+	}
+}
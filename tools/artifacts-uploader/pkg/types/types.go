@@ -0,0 +1,18 @@
+package types
+
+// UploadOptions represents the options that can be passed to the `upload` command.
+type UploadOptions struct {
+	SourceDirectory string
+	Destination     string
+	Delete          bool
+	PublicACL       bool
+	DryRun          bool
+	MaxAttempts     int
+}
+
+// UploadSummary represents the outcome of uploading a directory of artifacts.
+type UploadSummary struct {
+	Uploaded []string
+	Skipped  []string
+	Deleted  []string
+}
@@ -0,0 +1,595 @@
+package constants
+
+import (
+	"time"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+)
+
+// Constants used across the version-tracker source code.
+const (
+	BaseRepoOwnerEnvvar                     = "BASE_REPO_OWNER"
+	HeadRepoOwnerEnvvar                     = "HEAD_REPO_OWNER"
+	GitHubTokenEnvvar                       = "GITHUB_TOKEN"
+	CommitAuthorNameEnvvar                  = "COMMIT_AUTHOR_NAME"
+	CommitAuthorEmailEnvvar                 = "COMMIT_AUTHOR_EMAIL"
+	GitAuthorNameEnvvar                     = "GIT_AUTHOR_NAME"
+	GitAuthorEmailEnvvar                    = "GIT_AUTHOR_EMAIL"
+	GitCommitterNameEnvvar                  = "GIT_COMMITTER_NAME"
+	GitCommitterEmailEnvvar                 = "GIT_COMMITTER_EMAIL"
+	RepoCloneFilterEnvvar                   = "REPO_CLONE_FILTER"
+	SSLCertFileEnvvar                       = "SSL_CERT_FILE"
+	OTLPEndpointEnvvar                      = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	OTLPTracesEndpointEnvvar                = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	SelfUpdateArtifactsURLEnvvar            = "VERSION_TRACKER_ARTIFACTS_URL"
+	SelfUpdatePublicKeyEnvvar               = "VERSION_TRACKER_PUBLIC_KEY"
+	DefaultCommitAuthorName                 = "EKS Distro PR Bot"
+	DefaultCommitAuthorEmail                = "aws-model-rocket-bots+eksdistroprbot@amazon.com"
+	BuildToolingRepoName                    = "eks-anywhere-build-tooling"
+	DefaultBaseRepoOwner                    = "aws"
+	BuildToolingRepoURL                     = "https://github.com/%s/eks-anywhere-build-tooling"
+	ReadmeFile                              = "README.md"
+	ReadmeUpdateScriptFile                  = "build/lib/readme_check.sh"
+	LicenseBoilerplateFile                  = "hack/boilerplate.yq.txt"
+	EKSDistroLatestReleasesFile             = "EKSD_LATEST_RELEASES"
+	EKSDistroProdReleaseNumberFileFormat    = "release/%s/production/RELEASE"
+	KubernetesGitTagFileFormat              = "projects/kubernetes/kubernetes/%s/GIT_TAG"
+	EKSDistroProdDomain                     = "distro.eks.amazonaws.com"
+	EKSDistroDevDomain                      = "eks-d-postsubmit-artifacts.s3.us-west-2.amazonaws.com"
+	EKSDistroReleaseManifestPathFormat      = "kubernetes-%[1]s/kubernetes-%[1]s-eks-%[2]d.yaml"
+	EKSDistroArchiveAssetType               = "Archive"
+	EKSDistroImageAssetType                 = "Image"
+	SkippedProjectsFile                     = "SKIPPED_PROJECTS"
+	UpstreamProjectsTrackerFile             = "UPSTREAM_PROJECTS.yaml"
+	SupportedReleaseBranchesFile            = "release/SUPPORTED_RELEASE_BRANCHES"
+	GitTagFile                              = "GIT_TAG"
+	HelmChartsGlob                          = "projects/*/*/chart/Chart.yaml"
+	HelmSedfileTemplate                     = "helm/sedfile.template"
+	ChartImageReferenceRegexFormat          = `(?m)(image:[ \t]*["']?[^\s"']*/%s/%s):%s(["']?[ \t]*$)`
+	ChartVersionRegex                       = `(?m)^version:[ \t]*["']?([0-9]+\.[0-9]+\.[0-9]+)["']?[ \t]*$`
+	BatchUpgradeBranchName                  = "update-low-risk-batch"
+	BatchUpgradePullRequestTitle            = "Bump low-risk project versions to latest patch releases"
+	BottlerocketUpgradeBranchName           = "update-bottlerocket-releases"
+	AutomationBranchPrefix                  = "update-"
+	DefaultStaleBranchAge                   = 30 * 24 * time.Hour
+	WeeklyUpgradeInterval                   = 7 * 24 * time.Hour
+	BiweeklyUpgradeInterval                 = 14 * 24 * time.Hour
+	DisplayScanStateFile                    = "display-scan-state.yaml"
+	UpstreamMirrorsDirectory                = "upstream-mirrors"
+	FullCloneMode                           = "full"
+	PartialCloneMode                        = "partial"
+	ShallowCloneMode                        = "shallow"
+	PartialCloneFilter                      = "blob:none"
+	DefaultMinimumFreeDiskSpaceGiB          = 5
+	CommitHashRegex                         = `^[0-9a-f]{40}$`
+	UpgradeHistoryDirectory                 = "upgrade-history"
+	ReleasesVersionSource                   = "releases"
+	TagsVersionSource                       = "tags"
+	ReleasesAndTagsVersionSource            = "releases-and-tags"
+	TableOutputFormat                       = "table"
+	JSONOutputFormat                        = "json"
+	MarkdownOutputFormat                    = "markdown"
+	TextLogFormat                           = "text"
+	JSONLogFormat                           = "json"
+	TracingServiceName                      = "version-tracker"
+	TracingShutdownTimeout                  = 10 * time.Second
+	ProgressReportInterval                  = 30 * time.Second
+	PullRequestTemplatesFile                = "tools/version-tracker/pull-request-templates.yaml"
+	GithubCompareURLFormat                  = "https://github.com/%s/%s/compare/%s...%s"
+	GithubReleaseURLFormat                  = "https://github.com/%s/%s/releases/%s"
+	ProjectGoVersionFilesGlob               = "projects/*/*/GOLANG_VERSION"
+	ReleaseBranchGoVersionFilesGlob         = "projects/*/*/*/GOLANG_VERSION"
+	RevisionPrefixRegex                     = `^(.*?)(v?[0-9]+\.[0-9]+\.[0-9]+.*)$`
+	PerReleaseBranchPullRequestPolicy       = "per-branch"
+	CombinedPullRequestPolicy               = "combined"
+	GoVersionFile                           = "GOLANG_VERSION"
+	ChecksumsFile                           = "CHECKSUMS"
+	AttributionsFilePattern                 = "*ATTRIBUTION.txt"
+	PatchesDirectory                        = "patches"
+	FailedPatchApplyMarker                  = "patch does not apply"
+	FailedPatchApplyRegex                   = "Patch failed at .*"
+	FailedPatchFilesRegex                   = "error: (.*): patch does not apply"
+	PatchFileGlob                           = "*.patch"
+	PatchProvenanceDirectory                = "patches-provenance"
+	PatchCommitterName                      = "Prow Bot"
+	PatchCommitterEmail                     = "prow@amazonaws.com"
+	PatchDiffHeaderRegex                    = `^diff --git a/(.*) b/(.*)$`
+	DefaultShell                            = "bash"
+	BottlerocketReleasesFile                = "BOTTLEROCKET_RELEASES"
+	BottlerocketContainerMetadataFileFormat = "BOTTLEROCKET_%s_CONTAINER_METADATA"
+	BottlerocketHostContainersTOMLFile      = "sources/models/shared-defaults/public-host-containers.toml"
+	CiliumImageRepository                   = "public.ecr.aws/isovalent/cilium"
+	GithubPerPage                           = 100
+	MaxBreakingChangesInPullRequest         = 20
+	DefaultServeListenAddress               = ":8080"
+	DefaultChecksumDriftRebuilds            = 2
+	ReproducibleChecksumStatus              = "Reproducible"
+	DriftedChecksumStatus                   = "Drifted"
+	NondeterministicChecksumStatus          = "Nondeterministic"
+	TrailingWhitespacePatchCheck            = "trailing-whitespace"
+	MissingFinalNewlinePatchCheck           = "missing-final-newline"
+	FileModeChangePatchCheck                = "file-mode-change"
+	AbsolutePathPatchCheck                  = "absolute-path"
+	CRLFPatchCheck                          = "crlf"
+	DefaultServeRefreshInterval             = 10 * time.Minute
+	ServeReadHeaderTimeout                  = 10 * time.Second
+	MaxCommitsInPullRequest                 = 50
+	GoModFile                               = "go.mod"
+	OSVQueryBatchURL                        = "https://api.osv.dev/v1/querybatch"
+	OSVQueryBatchSize                       = 1000
+	OSVGoEcosystem                          = "Go"
+	MinimumGNUMakeMajorVersion              = 4
+	MinimumBashMajorVersion                 = 4
+	MinimumBashMinorVersion                 = 2
+	SelfUpdateBuildInfoFile                 = "build-info.json"
+	SelfUpdateBinaryPathFormat              = "%s-%s/version-tracker"
+	SelfUpdateChecksumFileSuffix            = ".sha256"
+	SelfUpdateSignatureFileSuffix           = ".sig"
+	datetimeFormat                          = "%Y-%m-%dT%H:%M:%SZ"
+	MainBranchName                          = "main"
+	BaseRepoHeadRevision                    = "refs/remotes/origin/main"
+	EKSDistroUpgradePullRequestBody         = `This PR bumps EKS Distro releases to the latest available release versions.
+
+/hold
+/area dependencies
+
+By submitting this pull request, I confirm that you can use, modify, copy, and redistribute this contribution, under the terms of your choice.`
+	DefaultUpgradePullRequestBody = `This PR bumps %[1]s/%[2]s to the latest Git revision.
+
+[Compare changes](https://github.com/%[1]s/%[2]s/compare/%[3]s...%[4]s)
+[Release notes](https://github.com/%[1]s/%[2]s/releases/%[4]s)
+
+/hold
+/area dependencies
+
+By submitting this pull request, I confirm that you can use, modify, copy, and redistribute this contribution, under the terms of your choice.`
+	BottlerocketUpgradePullRequestBody = `This PR bumps Bottlerocket releases to the latest Git revision.
+
+[Compare changes](https://github.com/bottlerocket-os/bottlerocket/compare/%[1]s...%[2]s)
+[Release notes](https://github.com/bottlerocket-os/bottlerocket/releases/%[2]s)
+
+/hold
+/area dependencies
+
+By submitting this pull request, I confirm that you can use, modify, copy, and redistribute this contribution, under the terms of your choice.`
+
+	CombinedImageBuilderBottlerocketUpgradePullRequestBody = `This PR bumps kubernetes-sigs/image-builder and Bottlerocket releases to the latest Git revision.
+
+[Compare changes for image-builder](https://github.com/kubernetes-sigs/image-builder/compare/%[1]s...%[2]s)
+[Release notes for image-builder](https://github.com/kubernetes-sigs/image-builder/releases/%[2]s)
+
+[Compare changes for Bottlerocket](https://github.com/bottlerocket-os/bottlerocket/compare/%[3]s...%[4]s)
+[Release notes for Bottlerocket](https://github.com/bottlerocket-os/bottlerocket/releases/%[4]s)
+
+/hold
+/area dependencies
+
+By submitting this pull request, I confirm that you can use, modify, copy, and redistribute this contribution, under the terms of your choice.`
+	ReleaseBranchedUpgradePullRequestBody = `This PR bumps %[1]s/%[2]s to the latest Git revision for the following release branches.
+
+%[3]s
+
+/hold
+/area dependencies
+
+By submitting this pull request, I confirm that you can use, modify, copy, and redistribute this contribution, under the terms of your choice.`
+	ReleaseBranchUpgradeLineFormat      = "* %[3]s: [%[4]s...%[5]s](https://github.com/%[1]s/%[2]s/compare/%[4]s...%[5]s)"
+	ReleaseBranchPatchesPullRequestNote = `
+
+**Note:** The %s release branch has patches, so its checksums and attribution files were not updated. Apply the patches to the new revision and regenerate them before merging this PR.`
+	GoToolchainUnavailablePullRequestSection = `
+
+## Go toolchain not available
+The go.mod file at %[1]s requires Go %[2]s (toolchain: %[3]s), but the newest Go version used to build projects in this repository is %[4]s. Make sure Go %[2]s is available in the builder-base image before merging this PR.`
+	CommitPinnedUpgradePullRequestBody = `This PR bumps %[1]s/%[2]s to the latest commit on the %[3]s branch.
+
+[Compare changes](https://github.com/%[1]s/%[2]s/compare/%[4]s...%[5]s)
+
+/hold
+/area dependencies
+
+By submitting this pull request, I confirm that you can use, modify, copy, and redistribute this contribution, under the terms of your choice.`
+	CommitLogPullRequestSection = `
+
+## Commits
+The following commits were made upstream between %[1]s and %[2]s.
+
+%[3]s`
+	BatchUpgradePullRequestBody = `This PR bumps the following projects to their latest patch releases. These upgrades are considered low-risk since they do not cross minor versions, no potential breaking changes were found in the upstream release notes and commit messages, the required Go versions are available and the projects' patches apply cleanly.
+
+%s
+
+/hold
+/area dependencies
+
+By submitting this pull request, I confirm that you can use, modify, copy, and redistribute this contribution, under the terms of your choice.`
+	BreakingChangesPullRequestSection = `
+
+## Potential breaking changes
+The following lines in the upstream release notes and commit messages between %[1]s and %[2]s indicate potentially breaking changes. Review them before merging this PR.
+
+%[3]s`
+	BuildSimulationPullRequestSection = `
+
+## Build simulation
+**Result:** %s
+
+%s`
+	ReleaseBranchBuildSimulationPullRequestSection = `
+
+## Build simulation for %s release branch
+**Result:** %s
+
+%s`
+	LicenseHeader = `Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.`
+	SupersededPullRequestComment = `This pull request has been superseded by %s, which includes these changes. Closing this pull request.`
+	PatchesCommentBody           = `# This pull request is incomplete!
+## Failed patch details
+**Only %d/%d patches were applied!**
+%s
+The following files in the above patch did not apply successfully:
+%s
+
+The project being upgraded in this pull request needs changes to patches that cannot be handled automatically. A developer will need to regenerate the patches locally and update the pull request. In addition to patches, the checksums and attribution file(s) corresponding to the project will need to be updated.`
+)
+
+var (
+	// ProjectReleaseAssets is the mapping of project name to release tarball configurations.
+	ProjectReleaseAssets = map[string]types.ReleaseTarball{
+		"apache/cloudstack-cloudmonkey": {
+			AssetName:  "cmk.linux.x86-64",
+			BinaryName: "cmk.linux.x86-64",
+			Extract:    false,
+		},
+		"aquasecurity/harbor-scanner-trivy": {
+			AssetName:                "harbor-scanner-trivy_%s_Linux_x86_64.tar.gz",
+			BinaryName:               "scanner-trivy",
+			Extract:                  true,
+			TrimLeadingVersionPrefix: true,
+		},
+		"aquasecurity/trivy": {
+			AssetName:                "trivy_%s_Linux-64bit.tar.gz",
+			BinaryName:               "trivy",
+			Extract:                  true,
+			TrimLeadingVersionPrefix: true,
+		},
+		"aws/rolesanywhere-credential-helper": {
+			OverrideAssetURL:         "https://rolesanywhere.amazonaws.com/releases/%s/X86_64/Linux/aws_signing_helper",
+			AssetName:                "aws_signing_helper",
+			BinaryName:               "aws_signing_helper",
+			Extract:                  false,
+			TrimLeadingVersionPrefix: true,
+		},
+		"cert-manager/cert-manager": {
+			AssetName:  "cmctl-linux-amd64.tar.gz",
+			BinaryName: "cmctl",
+			Extract:    true,
+		},
+		"containerd/containerd": {
+			AssetName:                "containerd-%s-linux-amd64.tar.gz",
+			BinaryName:               "bin/containerd",
+			Extract:                  true,
+			TrimLeadingVersionPrefix: true,
+		},
+		"distribution/distribution": {
+			AssetName:                "registry_%s_linux_amd64.tar.gz",
+			BinaryName:               "registry",
+			Extract:                  true,
+			TrimLeadingVersionPrefix: true,
+		},
+		"fluxcd/flux2": {
+			AssetName:                "flux_%s_linux_amd64.tar.gz",
+			BinaryName:               "flux",
+			Extract:                  true,
+			TrimLeadingVersionPrefix: true,
+		},
+		"helm/helm": {
+			OverrideAssetURL: "https://get.helm.sh/helm-%s-linux-amd64.tar.gz",
+			AssetName:        "helm-%s-linux-amd64.tar.gz",
+			BinaryName:       "linux-amd64/helm",
+			Extract:          true,
+		},
+		"kubernetes-sigs/cluster-api": {
+			AssetName:  "clusterctl-linux-amd64",
+			BinaryName: "clusterctl-linux-amd64",
+			Extract:    false,
+		},
+		"kubernetes-sigs/cri-tools": {
+			AssetName:  "crictl-%s-linux-amd64.tar.gz",
+			BinaryName: "crictl",
+			Extract:    true,
+		},
+		"kubernetes-sigs/kind": {
+			AssetName:  "kind-linux-amd64",
+			BinaryName: "kind-linux-amd64",
+			Extract:    false,
+		},
+		"opencontainers/runc": {
+			AssetName:  "runc.amd64",
+			BinaryName: "runc.amd64",
+			Extract:    false,
+		},
+		"prometheus/prometheus": {
+			AssetName:                "prometheus-%s.linux-amd64.tar.gz",
+			BinaryName:               "prometheus-%s.linux-amd64/prometheus",
+			Extract:                  true,
+			TrimLeadingVersionPrefix: true,
+		},
+		"prometheus/node_exporter": {
+			AssetName:                "node_exporter-%s.linux-amd64.tar.gz",
+			BinaryName:               "node_exporter-%s.linux-amd64/node_exporter",
+			Extract:                  true,
+			TrimLeadingVersionPrefix: true,
+		},
+		"rancher/local-path-provisioner": {
+			AssetName:  "local-path-provisioner-amd64",
+			BinaryName: "local-path-provisioner-amd64",
+			Extract:    false,
+		},
+		"replicatedhq/troubleshoot": {
+			AssetName:  "support-bundle_linux_amd64.tar.gz",
+			BinaryName: "support-bundle",
+			Extract:    true,
+		},
+		"vmware/govmomi": {
+			AssetName:  "govc_Linux_x86_64.tar.gz",
+			BinaryName: "govc",
+			Extract:    true,
+		},
+	}
+
+	// ProjectCommitTrackingPolicies is the mapping of project name to the policy used to upgrade projects that are
+	// pinned to a commit hash. Commit-pinned projects without a policy are not upgraded.
+	ProjectCommitTrackingPolicies = map[string]types.CommitTrackingPolicy{}
+
+	// ProjectVersionResolutionPolicies is the mapping of project name to the policy used to determine the latest
+	// revision of the project. Projects without a policy use their GitHub releases, or tags if they have no releases.
+	ProjectVersionResolutionPolicies = map[string]types.VersionResolutionPolicy{}
+
+	// ProjectUpgradeSchedules is the mapping of project name to the schedule the `batch-upgrade` command follows
+	// when proposing upgrades of the project. Projects without a schedule are upgraded on every run, except during
+	// a release freeze. Upstreams that publish releases every few days are upgraded at most weekly or biweekly, with
+	// their security releases still picked up immediately, and the container runtimes are upgraded during release
+	// freezes as well.
+	ProjectUpgradeSchedules = map[string]types.UpgradeSchedule{
+		"aquasecurity/trivy": {
+			Interval:                    WeeklyUpgradeInterval,
+			SecurityReleasesImmediately: true,
+		},
+		"aws-observability/aws-otel-collector": {
+			Interval:                    BiweeklyUpgradeInterval,
+			SecurityReleasesImmediately: true,
+		},
+		"cilium/cilium": {
+			Interval:                    WeeklyUpgradeInterval,
+			SecurityReleasesImmediately: true,
+		},
+		"containerd/containerd": {
+			ReleaseFreezeException:      true,
+			SecurityReleasesImmediately: true,
+		},
+		"envoyproxy/envoy": {
+			Interval:                    WeeklyUpgradeInterval,
+			SecurityReleasesImmediately: true,
+		},
+		"fluxcd/flux2": {
+			Interval:                    BiweeklyUpgradeInterval,
+			SecurityReleasesImmediately: true,
+		},
+		"fluxcd/helm-controller": {
+			Interval:                    BiweeklyUpgradeInterval,
+			SecurityReleasesImmediately: true,
+		},
+		"fluxcd/kustomize-controller": {
+			Interval:                    BiweeklyUpgradeInterval,
+			SecurityReleasesImmediately: true,
+		},
+		"fluxcd/notification-controller": {
+			Interval:                    BiweeklyUpgradeInterval,
+			SecurityReleasesImmediately: true,
+		},
+		"fluxcd/source-controller": {
+			Interval:                    BiweeklyUpgradeInterval,
+			SecurityReleasesImmediately: true,
+		},
+		"opencontainers/runc": {
+			ReleaseFreezeException:      true,
+			SecurityReleasesImmediately: true,
+		},
+		"prometheus/prometheus": {
+			Interval:                    BiweeklyUpgradeInterval,
+			SecurityReleasesImmediately: true,
+		},
+		"vmware/govmomi": {
+			Interval:                    BiweeklyUpgradeInterval,
+			SecurityReleasesImmediately: true,
+		},
+	}
+
+	// ProjectGoVersionSourceOfTruth is the mapping of project name to Go version source of truth files configuration.
+	ProjectGoVersionSourceOfTruth = map[string]types.GoVersionSourceOfTruth{
+		"aws/etcdadm-bootstrap-provider": {
+			SourceOfTruthFile:     "go.mod",
+			GoVersionSearchString: `go (1\.\d\d)`,
+		},
+		"aws/etcdadm-controller": {
+			SourceOfTruthFile:     "go.mod",
+			GoVersionSearchString: `go (1\.\d\d)`,
+		},
+		"brancz/kube-rbac-proxy": {
+			SourceOfTruthFile:     ".github/workflows/build.yml",
+			GoVersionSearchString: `go-version: '(1\.\d\d)\.\d+'`,
+		},
+		"emissary-ingress/emissary": {
+			SourceOfTruthFile:     "go.mod",
+			GoVersionSearchString: `go (1\.\d\d)`,
+		},
+		"goharbor/harbor": {
+			SourceOfTruthFile:     "Makefile",
+			GoVersionSearchString: `GOBUILDIMAGE=golang:(1\.\d\d)`,
+		},
+		"fluxcd/helm-controller": {
+			SourceOfTruthFile:     "Dockerfile",
+			GoVersionSearchString: `ARG GO_VERSION=(1\.\d\d)`,
+		},
+		"fluxcd/kustomize-controller": {
+			SourceOfTruthFile:     "Dockerfile",
+			GoVersionSearchString: `ARG GO_VERSION=(1\.\d\d)`,
+		},
+		"fluxcd/notification-controller": {
+			SourceOfTruthFile:     "Dockerfile",
+			GoVersionSearchString: `ARG GO_VERSION=(1\.\d\d)`,
+		},
+		"fluxcd/source-controller": {
+			SourceOfTruthFile:     "Dockerfile",
+			GoVersionSearchString: `ARG GO_VERSION=(1\.\d\d)`,
+		},
+		"kube-vip/kube-vip": {
+			SourceOfTruthFile:     "Dockerfile",
+			GoVersionSearchString: `golang:(1\.\d\d)`,
+		},
+		"kubernetes-sigs/cluster-api-provider-cloudstack": {
+			SourceOfTruthFile:     "go.mod",
+			GoVersionSearchString: `go (1\.\d\d)`,
+		},
+		"kubernetes-sigs/cluster-api-provider-vsphere": {
+			SourceOfTruthFile:     "Makefile",
+			GoVersionSearchString: `GO_VERSION \?= (1\.\d\d)\.\d+`,
+		},
+		"kubernetes-sigs/etcdadm": {
+			SourceOfTruthFile:     "Makefile",
+			GoVersionSearchString: `GO_IMAGE \?= golang:(1\.\d\d)`,
+		},
+		"nutanix-cloud-native/cluster-api-provider-nutanix": {
+			SourceOfTruthFile:     "go.mod",
+			GoVersionSearchString: `go (1\.\d\d)`,
+		},
+		"metallb/metallb": {
+			SourceOfTruthFile:     "go.mod",
+			GoVersionSearchString: `go (1\.\d\d)`,
+		},
+		"tinkerbell/boots": {
+			SourceOfTruthFile:     "go.mod",
+			GoVersionSearchString: `go (1\.\d\d)`,
+		},
+		"tinkerbell/cluster-api-provider-tinkerbell": {
+			SourceOfTruthFile:     "Makefile",
+			GoVersionSearchString: `GOLANG_VERSION := (1\.\d\d)`,
+		},
+		"tinkerbell/hegel": {
+			SourceOfTruthFile:     ".github/workflows/ci.yaml",
+			GoVersionSearchString: `GO_VERSION: "(1\.\d\d)"`,
+		},
+		"tinkerbell/tink": {
+			SourceOfTruthFile:     ".github/workflows/ci.yaml",
+			GoVersionSearchString: `GO_VERSION: "(1\.\d\d)"`,
+		},
+	}
+
+	ProjectsWithUnconventionalUpgradeFlows = []string{
+		"cilium/cilium",
+		"kubernetes-sigs/image-builder",
+	}
+
+	BottlerocketImageFormats = []string{"ami", "ova", "raw"}
+
+	ProxyEnvvars = []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY"}
+
+	// CABundleEnvvars are the environment variables read by Git, curl, the AWS CLI and Go, respectively, for the
+	// CA bundle to trust instead of the system certificates.
+	CABundleEnvvars = []string{"GIT_SSL_CAINFO", "CURL_CA_BUNDLE", "AWS_CA_BUNDLE", SSLCertFileEnvvar}
+
+	// GNUMakeCommands are the names GNU Make is installed under, in order of preference. Homebrew installs GNU
+	// Make as gmake on macOS, where make is an older version.
+	GNUMakeCommands = []string{"make", "gmake"}
+
+	// HomebrewPrefixes are the default Homebrew installation prefixes on Apple silicon and Intel Macs.
+	HomebrewPrefixes = []string{"/opt/homebrew", "/usr/local"}
+
+	// HomebrewGNUToolFormulae are the Homebrew formulae providing the GNU variants of the tools used by the Make
+	// recipes, which install them with a g prefix and under their unprefixed names in a gnubin directory.
+	HomebrewGNUToolFormulae = []string{"coreutils", "findutils", "gnu-sed", "gnu-tar", "make"}
+
+	BottlerocketHostContainers = []string{"admin", "control"}
+
+	CiliumImageDirectories = []string{"cilium", "operator-generic", "cilium-chart"}
+
+	// BreakingChangeMarkers are the patterns used to identify breaking changes in upstream release notes and
+	// commit messages.
+	BreakingChangeMarkers = []string{
+		`(?i)breaking[ -]changes?`,
+		`(?i)action required`,
+		`(?i)\bBREAKING\b`,
+		`^[a-z]+(\([^)]*\))?!: `,
+		`(?i)\b(removed?|removal of|drop(ped)? support for)\b.*\b(API|apiVersion|CRD|v1alpha[0-9]|v1beta[0-9])\b`,
+		`(?i)\b(flag|option|argument)s?\b.*\b(renamed|removed)\b`,
+	}
+
+	// SecurityFixMarkers are the patterns used to identify security fixes in upstream release notes and commit
+	// messages.
+	SecurityFixMarkers = []string{
+		`\bCVE-\d{4}-\d{4,}\b`,
+		`\bGHSA(-[23456789cfghjmpqrvwx]{4}){3}\b`,
+		`(?i)\bsecurity (fix|release|update|vulnerability|advisory)`,
+		`(?i)\bvulnerabilit(y|ies)\b`,
+	}
+
+	// BuildFailureRules is the ordered list of rules used to classify build failures. The suggestion for
+	// each rule is formatted with the path to the project directory.
+	BuildFailureRules = []types.BuildFailureRule{
+		{
+			Category: "Patch conflict",
+			Patterns: []string{
+				`error: (.*): patch does not apply`,
+				`Patch failed at .*`,
+				`error: patch failed: .*`,
+			},
+			Suggestion: "Regenerate the failing patches: run `make -C %[1]s checkout-repo`, apply the patches with `git am --3way` and resolve the conflicts, then export them to %[1]s/patches with `git format-patch`",
+		},
+		{
+			Category: "Checksum mismatch",
+			Patterns: []string{
+				`WARNING: [0-9]+ computed checksums? did NOT match`,
+				`: FAILED$`,
+				`The correct checksums are printed below`,
+			},
+			Suggestion: "Update the checksums and attribution files: run `make -C %[1]s attribution-checksums` and commit the updated files",
+		},
+		{
+			Category: "Go toolchain",
+			Patterns: []string{
+				`go\.mod requires go >= ?[0-9.]+`,
+				`module requires Go [0-9.]+`,
+				`requires go[0-9.]+ or later`,
+				`go: unknown directive: toolchain`,
+				`invalid go version '[0-9.]+'`,
+			},
+			Suggestion: "Bump the Go version in %[1]s/GOLANG_VERSION to the version required upstream and regenerate the checksums and attribution files with `make -C %[1]s attribution-checksums`",
+		},
+		{
+			Category: "Upstream download",
+			Patterns: []string{
+				`fatal: unable to access '.*'`,
+				`fatal: couldn't find remote ref .*`,
+				`fatal: repository '.*' not found`,
+				`Could not resolve host: .*`,
+				`curl: \([0-9]+\) .*`,
+				`dial tcp .*: (i/o timeout|connection refused)`,
+				`TLS handshake timeout`,
+			},
+			Suggestion: "Verify that the revision in %[1]s/GIT_TAG and any release assets the project downloads exist upstream, then retry the build",
+		},
+	}
+)
@@ -0,0 +1,3 @@
+module github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api
+
+go 1.20
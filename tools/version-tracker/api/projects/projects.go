@@ -0,0 +1,108 @@
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+)
+
+// Directory is the directory of the build-tooling repository that holds the projects, relative to the repository root.
+const Directory = "projects"
+
+// Path returns the path of the project's directory relative to the root of the build-tooling repository.
+func Path(projectName string) string {
+	return filepath.Join(Directory, projectName)
+}
+
+// RootPath returns the path of the project's directory in the given build-tooling repository checkout.
+func RootPath(buildToolingRepoPath, projectName string) string {
+	return filepath.Join(buildToolingRepoPath, Path(projectName))
+}
+
+// Exists returns whether the project's directory exists in the given build-tooling repository checkout. The
+// organization and repository names are matched case-sensitively, including on case-insensitive file systems
+// such as the macOS default, where a project name with the wrong case would otherwise find the directory but
+// none of the project's entries in the upstream projects tracker file.
+func Exists(buildToolingRepoPath, projectName string) bool {
+	org, repo := SplitName(projectName)
+	parentDirectory := filepath.Join(buildToolingRepoPath, Directory)
+	for _, name := range []string{org, repo} {
+		entries, err := os.ReadDir(parentDirectory)
+		if err != nil {
+			return false
+		}
+		found := false
+		for _, entry := range entries {
+			if entry.IsDir() && entry.Name() == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+		parentDirectory = filepath.Join(parentDirectory, name)
+	}
+
+	return true
+}
+
+// List returns the names of the projects in the given build-tooling repository checkout, in the form <org>/<repo>
+// and sorted alphabetically.
+func List(buildToolingRepoPath string) ([]string, error) {
+	projectDirectories, err := filepath.Glob(filepath.Join(buildToolingRepoPath, Directory, "*", "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	var projectNames []string
+	for _, projectDirectory := range projectDirectories {
+		info, err := os.Stat(projectDirectory)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		projectNames = append(projectNames, filepath.ToSlash(filepath.Join(filepath.Base(filepath.Dir(projectDirectory)), filepath.Base(projectDirectory))))
+	}
+	sort.Strings(projectNames)
+
+	return projectNames, nil
+}
+
+// ReleaseBranchPath returns the directory holding the project's release branch-specific files, such as GIT_TAG,
+// CHECKSUMS and ATTRIBUTION files. For projects without release branches, this is the project's directory.
+func ReleaseBranchPath(buildToolingRepoPath, projectName, releaseBranch string) string {
+	return filepath.Join(RootPath(buildToolingRepoPath, projectName), releaseBranch)
+}
+
+// PatchesDirectories returns the directories holding the project's patches in the given build-tooling repository
+// checkout, the project's own patches directory first followed by those of its release branches. Directories without
+// patches are skipped.
+func PatchesDirectories(buildToolingRepoPath, projectName string) ([]string, error) {
+	projectRootFilepath := RootPath(buildToolingRepoPath, projectName)
+	releaseBranchPatchesDirectories, err := filepath.Glob(filepath.Join(projectRootFilepath, "*", constants.PatchesDirectory))
+	if err != nil {
+		return nil, err
+	}
+
+	patchesDirectories := []string{}
+	for _, patchesDirectory := range append([]string{filepath.Join(projectRootFilepath, constants.PatchesDirectory)}, releaseBranchPatchesDirectories...) {
+		patches, err := filepath.Glob(filepath.Join(patchesDirectory, constants.PatchFileGlob))
+		if err != nil {
+			return nil, err
+		}
+		if len(patches) > 0 {
+			patchesDirectories = append(patchesDirectories, patchesDirectory)
+		}
+	}
+
+	return patchesDirectories, nil
+}
+
+// SplitName splits a project name of the form <org>/<repo> into the upstream organization and repository names.
+func SplitName(projectName string) (string, string) {
+	org, repo, _ := strings.Cut(projectName, "/")
+	return org, repo
+}
@@ -0,0 +1,579 @@
+package types
+
+import "time"
+
+// DisplayOptions represents the options that can be passed to the `display` command.
+type DisplayOptions struct {
+	ProjectName        string
+	PrintLatestVersion bool
+	MaxAPICalls        int
+	ContinueOnError    bool
+}
+
+// UpgradeOptions represents the options that can be passed to the `upgrade` command.
+type UpgradeOptions struct {
+	ProjectName         string
+	DryRun              bool
+	BreakingChangeLabel string
+	PullRequestPolicy   string
+	RequireGoToolchain  bool
+	Simulate            bool
+
+	PullRequestTemplatesFile string
+}
+
+// AuditGoModulesOptions represents the options that can be passed to the `audit-go-modules` command.
+type AuditGoModulesOptions struct {
+	ProjectName          string
+	CheckVulnerabilities bool
+}
+
+// TriageOptions represents the options that can be passed to the `triage` command.
+type TriageOptions struct {
+	ProjectName   string
+	ReleaseBranch string
+	LogFile       string
+}
+
+// CheckoutOptions represents the options that can be passed to the `checkout` command.
+type CheckoutOptions struct {
+	ProjectName   string
+	ReleaseBranch string
+}
+
+// ReauthorPatchesOptions represents the options that can be passed to the `reauthor-patches` command.
+type ReauthorPatchesOptions struct {
+	ProjectName   string
+	ReleaseBranch string
+}
+
+// PatchedFilesOptions represents the options that can be passed to the `patched-files` command.
+type PatchedFilesOptions struct {
+	FilePattern string
+	ProjectName string
+}
+
+// VerifyPatchesOptions represents the options that can be passed to the `verify-patches` command.
+type VerifyPatchesOptions struct {
+	ProjectName      string
+	RecordProvenance bool
+}
+
+// DiffUpstreamOptions represents the options that can be passed to the `diff-upstream` command.
+type DiffUpstreamOptions struct {
+	ProjectName   string
+	ReleaseBranch string
+	StatOnly      bool
+	OutputFormat  string
+}
+
+// VerifyOptions represents the options that can be passed to the `verify` command.
+type VerifyOptions struct {
+	ProjectName   string
+	ReleaseBranch string
+}
+
+// ChecksumDriftOptions represents the options that can be passed to the `checksum-drift` command.
+type ChecksumDriftOptions struct {
+	ProjectNames    []string
+	ReleaseBranch   string
+	Rebuilds        int
+	OutputFormat    string
+	ContinueOnError bool
+}
+
+// LintPatchesOptions represents the options that can be passed to the `lint-patches` command.
+type LintPatchesOptions struct {
+	ProjectNames []string
+	PatchFiles   []string
+	OutputFormat string
+}
+
+// RenumberPatchesOptions represents the options that can be passed to the `renumber-patches` command.
+type RenumberPatchesOptions struct {
+	ProjectNames []string
+	Check        bool
+}
+
+// PruneBranchesOptions represents the options that can be passed to the `prune-branches` command.
+type PruneBranchesOptions struct {
+	MaxAge          time.Duration
+	DryRun          bool
+	ContinueOnError bool
+}
+
+// HistoryOptions represents the options that can be passed to the `history` command.
+type HistoryOptions struct {
+	ProjectName  string
+	OutputFormat string
+}
+
+// BatchUpgradeOptions represents the options that can be passed to the `batch-upgrade` command.
+type BatchUpgradeOptions struct {
+	DryRun          bool
+	ContinueOnError bool
+	ReleaseFreeze   bool
+}
+
+// CompatibilityMatrixOptions represents the options that can be passed to the `compatibility-matrix` command.
+type CompatibilityMatrixOptions struct {
+	ProjectName  string
+	OutputFormat string
+}
+
+// VersionOptions represents the options that can be passed to the `version` command.
+type VersionOptions struct {
+	OutputFormat string
+}
+
+// SelfUpdateOptions represents the options that can be passed to the `self-update` command.
+type SelfUpdateOptions struct {
+	ArtifactsURL          string
+	PublicKeyFile         string
+	InsecureSkipSignature bool
+	CheckOnly             bool
+}
+
+// ServeOptions represents the options that can be passed to the `serve` command.
+type ServeOptions struct {
+	ListenAddress   string
+	RefreshInterval time.Duration
+}
+
+// ProjectsList represents the top-level projects list in the upstream projects tracker file.
+type ProjectsList struct {
+	Projects []Project `yaml:"projects"`
+}
+
+// Project represents a list of repositories that share the same owner or organization.
+type Project struct {
+	Org   string `yaml:"org"`
+	Repos []Repo `yaml:"repos"`
+}
+
+// Repo represents a GitHub repository with a list of versions being built.
+type Repo struct {
+	Name     string    `yaml:"name"`
+	Versions []Version `yaml:"versions"`
+}
+
+// Version represents a Git tag or commit for a repository and the Go version corresponding to the revision.
+type Version struct {
+	Tag       string `yaml:"tag,omitempty"`
+	Commit    string `yaml:"commit,omitempty"`
+	GoVersion string `yaml:"go_version,omitempty"`
+}
+
+// ProjectVersionInfo represents the current and latest revision for a project.
+type ProjectVersionInfo struct {
+	Org            string
+	Repo           string
+	CurrentVersion string
+	LatestVersion  string
+}
+
+// ReleaseTarball represents the GitHub release asset name, binary name and related settings to get the
+// Go version for a particular project.
+type ReleaseTarball struct {
+	AssetName                string
+	BinaryName               string
+	Extract                  bool
+	OverrideAssetURL         string
+	TrimLeadingVersionPrefix bool
+}
+
+// GoVersionSourceOfTruth represents the source of truth file and search string to get the Go version
+// for a particular project.
+type GoVersionSourceOfTruth struct {
+	SourceOfTruthFile     string
+	GoVersionSearchString string
+}
+
+// CommitTrackingPolicy represents how a project pinned to a commit hash is upgraded, since its upstream doesn't
+// publish tags. The project follows the head of Branch, or the repository's default branch if it is empty, and
+// is upgraded at most once every Cadence if it is non-zero.
+type CommitTrackingPolicy struct {
+	Branch  string
+	Cadence time.Duration
+}
+
+// UpgradeSchedule represents when the `batch-upgrade` command proposes upgrades of a particular project. The
+// project is upgraded at most once every Interval if it is non-zero, and during a release freeze only if
+// ReleaseFreezeException is set. If SecurityReleasesImmediately is set, upgrades whose upstream release notes or
+// commit messages mention security fixes are proposed regardless of the interval and the release freeze.
+type UpgradeSchedule struct {
+	Interval                    time.Duration
+	ReleaseFreezeException      bool
+	SecurityReleasesImmediately bool
+}
+
+// VersionResolutionPolicy represents how the latest revision of a particular project is determined. Source is
+// one of `releases`, `tags` or `releases-and-tags`, TagRegex optionally restricts the revisions considered and
+// revisions matching any of the ExcludePatterns, such as release candidates or nightly builds, are never considered.
+type VersionResolutionPolicy struct {
+	Source          string
+	TagRegex        string
+	ExcludePatterns []string
+}
+
+type ImageMetadata struct {
+	Tag         string `yaml:"tag,omitempty"`
+	ImageDigest string `yaml:"imageDigest,omitempty"`
+}
+
+type EKSDistroRelease struct {
+	Branch      string `json:"branch"`
+	KubeVersion string `json:"kubeVersion"`
+	Number      int    `json:"number"`
+	Dev         *bool  `json:"dev,omitempty"`
+}
+
+type EKSDistroLatestReleases struct {
+	Releases []EKSDistroRelease `json:"releases"`
+	Latest   string             `json:"latest"`
+}
+
+// EKSDistroReleaseManifest represents the EKS Distro release manifest published for a
+// particular release branch and release number.
+type EKSDistroReleaseManifest struct {
+	Metadata EKSDistroReleaseMetadata `json:"metadata"`
+	Spec     EKSDistroReleaseSpec     `json:"spec"`
+	Status   EKSDistroReleaseStatus   `json:"status"`
+}
+
+type EKSDistroReleaseMetadata struct {
+	Name string `json:"name"`
+}
+
+type EKSDistroReleaseSpec struct {
+	Channel string `json:"channel"`
+	Number  int    `json:"number"`
+}
+
+type EKSDistroReleaseStatus struct {
+	Date       string               `json:"date,omitempty"`
+	Components []EKSDistroComponent `json:"components"`
+}
+
+// EKSDistroComponent represents a component built and released by EKS Distro, such as
+// Kubernetes, etcd or CNI plugins.
+type EKSDistroComponent struct {
+	Name      string           `json:"name"`
+	GitTag    string           `json:"gitTag"`
+	GitCommit string           `json:"gitCommit,omitempty"`
+	Assets    []EKSDistroAsset `json:"assets"`
+}
+
+// EKSDistroAsset represents an artifact of an EKS Distro component. Archive assets
+// have the Archive field set and Image assets have the Image field set.
+type EKSDistroAsset struct {
+	Name        string                 `json:"name"`
+	Type        string                 `json:"type"`
+	Description string                 `json:"description,omitempty"`
+	OS          string                 `json:"os,omitempty"`
+	Arch        []string               `json:"arch,omitempty"`
+	Archive     *EKSDistroAssetArchive `json:"archive,omitempty"`
+	Image       *EKSDistroAssetImage   `json:"image,omitempty"`
+}
+
+type EKSDistroAssetArchive struct {
+	URI    string `json:"uri"`
+	SHA256 string `json:"sha256"`
+	SHA512 string `json:"sha512"`
+}
+
+type EKSDistroAssetImage struct {
+	URI string `json:"uri"`
+}
+
+// GoModFile represents the JSON output of the `go mod edit -json` command.
+type GoModFile struct {
+	Module    GoModule
+	Go        string
+	Toolchain string
+	Require   []GoModuleRequire
+	Replace   []GoModuleReplace
+}
+
+// GoModule represents a Go module path and version.
+type GoModule struct {
+	Path    string
+	Version string
+}
+
+// GoModuleRequire represents a require directive in a go.mod file.
+type GoModuleRequire struct {
+	Path     string
+	Version  string
+	Indirect bool
+}
+
+// GoModuleReplace represents a replace directive in a go.mod file.
+type GoModuleReplace struct {
+	Old GoModule
+	New GoModule
+}
+
+// GoModuleUsage represents the version of a Go module required by a particular project revision.
+type GoModuleUsage struct {
+	Project  string
+	Revision string
+	Version  string
+}
+
+// OSVBatchQuery represents the request body for the OSV batch query API.
+type OSVBatchQuery struct {
+	Queries []OSVQuery `json:"queries"`
+}
+
+// OSVQuery represents a single package query in an OSV batch query.
+type OSVQuery struct {
+	Version string     `json:"version"`
+	Package OSVPackage `json:"package"`
+}
+
+// OSVPackage represents a package identifier in the OSV database.
+type OSVPackage struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+// OSVBatchResponse represents the response body of the OSV batch query API.
+type OSVBatchResponse struct {
+	Results []OSVBatchResult `json:"results"`
+}
+
+// OSVBatchResult represents the list of vulnerabilities matching a single OSV query.
+type OSVBatchResult struct {
+	Vulns []OSVVulnerability `json:"vulns"`
+}
+
+// OSVVulnerability represents a vulnerability entry in the OSV database.
+type OSVVulnerability struct {
+	ID string `json:"id"`
+}
+
+// BuildFailureRule describes how to detect a category of build failure in a build log and how to fix it.
+type BuildFailureRule struct {
+	Category   string
+	Patterns   []string
+	Suggestion string
+}
+
+// BuildFailure represents a classified build failure along with the log line that identified it and
+// the suggested fix.
+type BuildFailure struct {
+	Category   string
+	Evidence   string
+	Suggestion string
+}
+
+// PatchedFile represents an upstream file modified by a project patch.
+type PatchedFile struct {
+	Project string
+	Patch   string
+	File    string
+}
+
+// PatchVerificationResult represents the outcome of applying a project's patch series for a release branch.
+type PatchVerificationResult struct {
+	ReleaseBranch         string
+	GitTag                string
+	PatchesDirectory      string
+	Succeeded             bool
+	Details               string
+	MissingLicenseHeaders []string
+}
+
+// UpstreamDiff represents the effective changes a project's patch series makes to its upstream repository.
+type UpstreamDiff struct {
+	ProjectName   string             `json:"projectName"`
+	ReleaseBranch string             `json:"releaseBranch,omitempty"`
+	GitTag        string             `json:"gitTag"`
+	Patches       int                `json:"patches"`
+	Files         []UpstreamDiffFile `json:"files"`
+	Additions     int                `json:"additions"`
+	Deletions     int                `json:"deletions"`
+	Diff          string             `json:"diff,omitempty"`
+}
+
+// UpstreamDiffFile represents the changes made to a single upstream file by a project's patch series.
+type UpstreamDiffFile struct {
+	Path      string `json:"path"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Binary    bool   `json:"binary,omitempty"`
+}
+
+// SnapshotVerificationResult represents the outcome of comparing a regenerated project file against the checked-in version.
+type SnapshotVerificationResult struct {
+	File    string
+	Drifted bool
+	Details string
+}
+
+// ChecksumDriftResult represents the outcome of rebuilding a project binary and comparing its checksums against the
+// checksum committed in the project's CHECKSUMS file. Binaries whose rebuilds have different checksums are
+// nondeterministic, while binaries that rebuild consistently to a different checksum than the committed one have
+// drifted, for example because of a change in the builder toolchain.
+type ChecksumDriftResult struct {
+	Project           string   `json:"project"`
+	ReleaseBranch     string   `json:"releaseBranch,omitempty"`
+	Binary            string   `json:"binary"`
+	Status            string   `json:"status"`
+	CommittedChecksum string   `json:"committedChecksum,omitempty"`
+	RebuiltChecksums  []string `json:"rebuiltChecksums"`
+}
+
+// PatchLintFinding represents a problem found in a patch file, along with the line of the file it is on.
+type PatchLintFinding struct {
+	Patch   string `json:"patch"`
+	Line    int    `json:"line"`
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
+
+// MarkedChange represents a line from upstream release notes or commit messages that matches a marker, such as
+// one indicating a potentially breaking change or a security fix, along with the release tag or commit it was
+// found in.
+type MarkedChange struct {
+	Source string
+	Line   string
+}
+
+// ReleaseBranchUpgrade represents the upgrade of the revision tracked for a release branch of a release-branched project.
+type ReleaseBranchUpgrade struct {
+	Index           int
+	ReleaseBranch   string
+	CurrentRevision string
+	LatestRevision  string
+}
+
+// PullRequestTemplates represents the pull request templates file, with the default templates and optional
+// overrides for specific projects.
+type PullRequestTemplates struct {
+	Default  PullRequestTemplate            `json:"default"`
+	Projects map[string]PullRequestTemplate `json:"projects,omitempty"`
+}
+
+// PullRequestTemplate represents the Go templates for the title and body of a pull request. An empty template
+// falls back to the default.
+type PullRequestTemplate struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+// PullRequestTemplateData represents the fields available to pull request templates.
+type PullRequestTemplateData struct {
+	Project         string
+	Org             string
+	Repo            string
+	ReleaseBranch   string
+	CurrentVersion  string
+	LatestVersion   string
+	CompareURL      string
+	ReleaseNotesURL string
+	BreakingChanges []MarkedChange
+	DefaultTitle    string
+	DefaultBody     string
+}
+
+// PatchProvenance represents the provenance file kept alongside a patch, which records where a carried
+// modification to upstream code comes from and when it was last verified to apply.
+type PatchProvenance struct {
+	Patch              string `json:"patch"`
+	Author             string `json:"author"`
+	Reason             string `json:"reason"`
+	OriginPullRequest  string `json:"originPullRequest,omitempty"`
+	UpstreamIssue      string `json:"upstreamIssue,omitempty"`
+	LastVerifiedGitTag string `json:"lastVerifiedGitTag,omitempty"`
+	LastVerifiedDate   string `json:"lastVerifiedDate,omitempty"`
+}
+
+// UpgradeHistory represents the upgrade history directory, which records the version upgrades performed by the
+// `upgrade` command, one entry file per upgrade.
+type UpgradeHistory struct {
+	Upgrades []UpgradeHistoryEntry `json:"upgrades"`
+}
+
+// UpgradeHistoryEntry represents a single version upgrade of a project, or of a release branch of a
+// release-branched project, as recorded in its upgrade history entry file.
+type UpgradeHistoryEntry struct {
+	Project         string `json:"project"`
+	ReleaseBranch   string `json:"releaseBranch,omitempty"`
+	PreviousVersion string `json:"previousVersion"`
+	Version         string `json:"version"`
+	Date            string `json:"date"`
+	PullRequest     string `json:"pullRequest,omitempty"`
+}
+
+// CompatibilityMatrix represents the versions of projects that ship in each supported Kubernetes release branch.
+type CompatibilityMatrix struct {
+	ReleaseBranches []string                   `json:"releaseBranches"`
+	Projects        []CompatibilityMatrixEntry `json:"projects"`
+}
+
+// CompatibilityMatrixEntry represents the version of a project that ships in each supported Kubernetes release
+// branch, keyed by release branch.
+type CompatibilityMatrixEntry struct {
+	Project  string            `json:"project"`
+	Versions map[string]string `json:"versions"`
+}
+
+// BatchProjectUpgrade represents a pending upgrade of a project without release branches, considered for
+// inclusion in the combined pull request of a batch upgrade.
+type BatchProjectUpgrade struct {
+	Project        string
+	CurrentVersion Version
+	LatestRevision string
+}
+
+// ScanState represents the progress of a scan of all projects, persisted so that a scan interrupted by the GitHub
+// API budget or rate limit can be resumed. It records the latest revision of each project scanned so far.
+type ScanState struct {
+	LatestRevisions map[string]string `json:"latestRevisions"`
+}
+
+// ProjectsMetadata represents the metadata of all projects served by the `serve` command, as of a commit of the
+// build-tooling repository.
+type ProjectsMetadata struct {
+	Commit      string            `json:"commit"`
+	GeneratedAt string            `json:"generatedAt"`
+	Projects    []ProjectMetadata `json:"projects"`
+}
+
+// ProjectMetadata represents the versions and patches a project is built with, how long ago its version last
+// changed and its last successful build, which is when the build last updated its CHECKSUMS files.
+type ProjectMetadata struct {
+	Project                string                  `json:"project"`
+	ReleaseBranches        []ReleaseBranchMetadata `json:"releaseBranches"`
+	Patches                int                     `json:"patches"`
+	LastVersionUpdate      *CommitReference        `json:"lastVersionUpdate,omitempty"`
+	DaysSinceVersionUpdate *int                    `json:"daysSinceVersionUpdate,omitempty"`
+	LastBuild              *CommitReference        `json:"lastBuild,omitempty"`
+}
+
+// ReleaseBranchMetadata represents the Git tag, Go version and number of patches a project is built with for a
+// release branch. Projects without release branches have a single entry with an empty release branch.
+type ReleaseBranchMetadata struct {
+	ReleaseBranch string `json:"releaseBranch,omitempty"`
+	GitTag        string `json:"gitTag,omitempty"`
+	GoVersion     string `json:"goVersion,omitempty"`
+	Patches       int    `json:"patches"`
+}
+
+// CommitReference represents a commit of the build-tooling repository and its date.
+type CommitReference struct {
+	Commit string `json:"commit"`
+	Date   string `json:"date"`
+}
+
+// BuildInfo represents the build of the version-tracker binary, as embedded at build time.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+}
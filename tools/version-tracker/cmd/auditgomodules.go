@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/auditgomodules"
+)
+
+var auditGoModulesOptions = &types.AuditGoModulesOptions{}
+
+// auditGoModulesCmd is the command used to audit the Go module dependencies of projects.
+var auditGoModulesCmd = &cobra.Command{
+	Use:   "audit-go-modules --project <project name>",
+	Short: "Audit the Go module dependencies shared across one or all projects",
+	Long:  "Use this command to report Go module dependencies that are shared between projects at conflicting versions or that have known vulnerabilities, based on the go.mod file at each project's pinned Git revision",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := auditgomodules.Run(auditGoModulesOptions)
+		if err != nil {
+			log.Fatalf("Error auditing Go modules: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(auditGoModulesCmd)
+	auditGoModulesCmd.Flags().StringVar(&auditGoModulesOptions.ProjectName, "project", "", "Specify the project name to audit Go modules for")
+	auditGoModulesCmd.Flags().BoolVar(&auditGoModulesOptions.CheckVulnerabilities, "check-vulnerabilities", false, "Flag to check Go module versions against the OSV vulnerability database")
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/batchupgrade"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/tracing"
+)
+
+var batchUpgradeOptions = &types.BatchUpgradeOptions{}
+
+// batchUpgradeCmd is the command used to upgrade versions for all projects, combining low-risk upgrades into a single PR.
+var batchUpgradeCmd = &cobra.Command{
+	Use:   "batch-upgrade",
+	Short: "Upgrade the versions for all projects, combining low-risk upgrades into a single PR",
+	Long:  "Use this command to upgrade the versions of all projects in the EKS-A build-tooling repository, proposing the patch-level upgrades without breaking changes or patch conflicts in a single PR and the remaining upgrades in separate PRs",
+	Run: func(cmd *cobra.Command, args []string) {
+		span := tracing.Start("batch-upgrade")
+		err := batchupgrade.Run(batchUpgradeOptions)
+		span.End(err)
+		tracing.Shutdown()
+		if err != nil {
+			log.Fatalf("Error upgrading project versions: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(batchUpgradeCmd)
+	batchUpgradeCmd.Flags().BoolVar(&batchUpgradeOptions.DryRun, "dry-run", false, "Upgrade the projects locally but do not push changes and create PRs")
+	batchUpgradeCmd.Flags().BoolVar(&batchUpgradeOptions.ReleaseFreeze, "release-freeze", false, "Only upgrade projects whose upgrade schedule allows release freeze exceptions, and projects with security fixes that are upgraded immediately")
+	batchUpgradeCmd.Flags().BoolVar(&batchUpgradeOptions.ContinueOnError, "continue-on-error", false, "Leave projects whose low-risk upgrade fails out of the combined PR instead of stopping the batch, and summarize the failures at the end")
+}
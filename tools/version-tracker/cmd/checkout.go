@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/checkout"
+)
+
+var checkoutOptions = &types.CheckoutOptions{}
+
+// checkoutCmd is the command used to set up a patched working tree of a project's upstream repository.
+var checkoutCmd = &cobra.Command{
+	Use:   "checkout --project <project name>",
+	Short: "Set up a working tree of a project's upstream repository with patches applied",
+	Long:  "Use this command to clone a project's upstream repository at the tracked Git tag and apply the project's patch series with git am",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkout.Run(checkoutOptions)
+		if err != nil {
+			log.Fatalf("Error checking out project: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(checkoutCmd)
+	checkoutCmd.Flags().StringVar(&checkoutOptions.ProjectName, "project", "", "Specify the project name to check out")
+	checkoutCmd.Flags().StringVar(&checkoutOptions.ReleaseBranch, "release-branch", "", "Specify the release branch to check out the project for, if the project is release-branched")
+	if err := checkoutCmd.MarkFlagRequired("project"); err != nil {
+		log.Fatalf("Error marking flag %q as required: %v", "project", err)
+	}
+}
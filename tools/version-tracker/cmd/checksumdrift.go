@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/checksumdrift"
+)
+
+var checksumDriftOptions = &types.ChecksumDriftOptions{}
+
+// checksumDriftCmd is the command used to detect projects whose binaries no longer rebuild to their committed checksums.
+var checksumDriftCmd = &cobra.Command{
+	Use:   "checksum-drift --projects <project names>",
+	Short: "Detect nondeterministic builds and toolchain drift in project checksums",
+	Long:  "Use this command to rebuild the binaries of one or more projects in the builder base container, with a cold Go build cache each time, and compare their checksums against each other and against the committed CHECKSUMS files, reporting binaries that do not rebuild reproducibly or that have drifted from the committed checksums",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checksumdrift.Run(checksumDriftOptions)
+		if err != nil {
+			log.Fatalf("Error detecting checksum drift: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(checksumDriftCmd)
+	checksumDriftCmd.Flags().StringSliceVar(&checksumDriftOptions.ProjectNames, "projects", nil, "Comma-separated names of the projects to rebuild (default is all projects with CHECKSUMS files)")
+	checksumDriftCmd.Flags().StringVar(&checksumDriftOptions.ReleaseBranch, "release-branch", "", "Specify the release branch to rebuild release-branched projects for (default is the latest supported release branch)")
+	checksumDriftCmd.Flags().IntVar(&checksumDriftOptions.Rebuilds, "rebuilds", constants.DefaultChecksumDriftRebuilds, "Number of times to rebuild each project, at least 2 to detect nondeterministic builds")
+	checksumDriftCmd.Flags().StringVarP(&checksumDriftOptions.OutputFormat, "output", "o", constants.TableOutputFormat, "Output format for the results (table or json)")
+	checksumDriftCmd.Flags().BoolVar(&checksumDriftOptions.ContinueOnError, "continue-on-error", false, "Keep rebuilding the remaining projects when a project fails to build, and summarize the failures at the end")
+}
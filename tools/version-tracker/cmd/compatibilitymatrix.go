@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/compatibilitymatrix"
+)
+
+var compatibilityMatrixOptions = &types.CompatibilityMatrixOptions{}
+
+// compatibilityMatrixCmd is the command used to display the project versions shipped in each supported Kubernetes release branch.
+var compatibilityMatrixCmd = &cobra.Command{
+	Use:   "compatibility-matrix --project <project name>",
+	Short: "Display the compatibility matrix of projects versus supported Kubernetes release branches",
+	Long:  "Use this command to display the version of each project, or of a particular project, that ships in each supported Kubernetes release branch, as tracked in the upstream projects tracker file",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := compatibilitymatrix.Run(compatibilityMatrixOptions)
+		if err != nil {
+			log.Fatalf("Error generating compatibility matrix: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compatibilityMatrixCmd)
+	compatibilityMatrixCmd.Flags().StringVar(&compatibilityMatrixOptions.ProjectName, "project", "", "Specify the project name to display the compatibility matrix for")
+	compatibilityMatrixCmd.Flags().StringVarP(&compatibilityMatrixOptions.OutputFormat, "output", "o", constants.TableOutputFormat, "Output format for the compatibility matrix (table, json or markdown)")
+}
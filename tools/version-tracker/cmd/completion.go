@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+)
+
+// outputFormats are the values completed for the --output flag of the commands that accept more than the table and
+// JSON output formats.
+var outputFormats = map[string][]string{
+	compatibilityMatrixCmd.Name(): {constants.TableOutputFormat, constants.JSONOutputFormat, constants.MarkdownOutputFormat},
+}
+
+// registerCompletions registers the functions completing the values of the flags in the shell completion scripts
+// generated by the `completion` command. Project names and release branches are read from the build-tooling
+// repository checkout the commands would operate on, so they are only completed once it has been cloned or when
+// the --repo-root flag is given.
+func registerCompletions() {
+	cobra.CheckErr(rootCmd.RegisterFlagCompletionFunc("log-format", cobra.FixedCompletions([]string{constants.TextLogFormat, constants.JSONLogFormat}, cobra.ShellCompDirectiveNoFileComp)))
+	cobra.CheckErr(rootCmd.RegisterFlagCompletionFunc("clone-mode", cobra.FixedCompletions([]string{constants.FullCloneMode, constants.PartialCloneMode, constants.ShallowCloneMode}, cobra.ShellCompDirectiveNoFileComp)))
+	cobra.CheckErr(rootCmd.MarkPersistentFlagDirname("repo-root"))
+	cobra.CheckErr(rootCmd.MarkPersistentFlagDirname("workspace-dir"))
+	cobra.CheckErr(rootCmd.MarkPersistentFlagFilename("ca-bundle"))
+
+	for _, subCmd := range rootCmd.Commands() {
+		if subCmd.Flags().Lookup("project") != nil {
+			cobra.CheckErr(subCmd.RegisterFlagCompletionFunc("project", completeProjectNames))
+		}
+		if subCmd.Flags().Lookup("release-branch") != nil {
+			cobra.CheckErr(subCmd.RegisterFlagCompletionFunc("release-branch", completeReleaseBranches))
+		}
+		if subCmd.Flags().Lookup("output") != nil {
+			formats, ok := outputFormats[subCmd.Name()]
+			if !ok {
+				formats = []string{constants.TableOutputFormat, constants.JSONOutputFormat}
+			}
+			cobra.CheckErr(subCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(formats, cobra.ShellCompDirectiveNoFileComp)))
+		}
+	}
+	cobra.CheckErr(selfUpdateCmd.MarkFlagFilename("public-key"))
+}
+
+// completeProjectNames completes the --project flag with the names of the projects in the build-tooling repository.
+func completeProjectNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	projectNames, err := projects.List(completionBuildToolingRepoPath())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	return projectNames, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeReleaseBranches completes the --release-branch flag with the supported release branches. If the --project
+// flag is given and the project is release-branched, only the release branches the project has files for are
+// completed.
+func completeReleaseBranches(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	buildToolingRepoPath := completionBuildToolingRepoPath()
+	supportedReleaseBranchesFileContents, err := os.ReadFile(filepath.Join(buildToolingRepoPath, constants.SupportedReleaseBranchesFile))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	releaseBranches := strings.Fields(string(supportedReleaseBranchesFileContents))
+
+	projectName, _ := cmd.Flags().GetString("project")
+	if projectName != "" {
+		var projectReleaseBranches []string
+		for _, releaseBranch := range releaseBranches {
+			if _, err := os.Stat(projects.ReleaseBranchPath(buildToolingRepoPath, projectName, releaseBranch)); err == nil {
+				projectReleaseBranches = append(projectReleaseBranches, releaseBranch)
+			}
+		}
+		if len(projectReleaseBranches) > 0 {
+			releaseBranches = projectReleaseBranches
+		}
+	}
+
+	return releaseBranches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionBuildToolingRepoPath returns the path of the build-tooling repository checkout the command being
+// completed would operate on. The workspace is not initialized when completing, so that completing a flag never
+// creates the workspace directory.
+func completionBuildToolingRepoPath() string {
+	if repoRoot := viper.GetString("repo-root"); repoRoot != "" {
+		return repoRoot
+	}
+
+	return filepath.Join(viper.GetString("workspace-dir"), constants.BuildToolingRepoName)
+}
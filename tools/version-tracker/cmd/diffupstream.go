@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/diffupstream"
+)
+
+var diffUpstreamOptions = &types.DiffUpstreamOptions{}
+
+// diffUpstreamCmd is the command used to display the effective changes a project's patches make to upstream.
+var diffUpstreamCmd = &cobra.Command{
+	Use:   "diff-upstream --project <project name>",
+	Short: "Display the effective diff of a project's patched repository against upstream",
+	Long:  "Use this command to apply a project's patch series to its Git tag and display the combined changes the patches make to the pristine upstream repository, along with per-file and total line counts",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := diffupstream.Run(diffUpstreamOptions)
+		if err != nil {
+			log.Fatalf("Error diffing project against upstream: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffUpstreamCmd)
+	diffUpstreamCmd.Flags().StringVar(&diffUpstreamOptions.ProjectName, "project", "", "Specify the project name to diff against upstream")
+	diffUpstreamCmd.Flags().StringVar(&diffUpstreamOptions.ReleaseBranch, "release-branch", "", "Specify the release branch whose patches to apply, if the project is release-branched")
+	diffUpstreamCmd.Flags().BoolVar(&diffUpstreamOptions.StatOnly, "stat", false, "Only display the per-file and total line counts, not the diff itself")
+	diffUpstreamCmd.Flags().StringVarP(&diffUpstreamOptions.OutputFormat, "output", "o", constants.TableOutputFormat, "Output format for the diff (table or json)")
+	if err := diffUpstreamCmd.MarkFlagRequired("project"); err != nil {
+		log.Fatalf("Error marking flag %q as required: %v", "project", err)
+	}
+}
@@ -5,8 +5,8 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/display"
-	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/types"
 )
 
 var displayOptions = &types.DisplayOptions{}
@@ -28,4 +28,6 @@ func init() {
 	rootCmd.AddCommand(displayCmd)
 	displayCmd.Flags().StringVar(&displayOptions.ProjectName, "project", "", "Specify the project name to track versions for")
 	displayCmd.Flags().BoolVar(&displayOptions.PrintLatestVersion, "print-latest-version", false, "Flag to print only the latest version of the project")
+	displayCmd.Flags().IntVar(&displayOptions.MaxAPICalls, "max-api-calls", 0, "Maximum number of GitHub API calls to make, after which the scan stops and can be resumed by rerunning the command (0 means unlimited)")
+	displayCmd.Flags().BoolVar(&displayOptions.ContinueOnError, "continue-on-error", false, "Keep scanning the remaining projects when the latest version of a project cannot be retrieved, and summarize the failures at the end")
 }
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/history"
+)
+
+var historyOptions = &types.HistoryOptions{}
+
+// historyCmd is the command used to display the version upgrades recorded for projects.
+var historyCmd = &cobra.Command{
+	Use:   "history --project <project name>",
+	Short: "Display the version upgrade history for one or all projects",
+	Long:  "Use this command to display the version upgrades performed by the upgrade command for a particular project or for all projects, along with when they happened and the PRs that proposed them",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := history.Run(historyOptions)
+		if err != nil {
+			log.Fatalf("Error displaying upgrade history: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().StringVar(&historyOptions.ProjectName, "project", "", "Specify the project name to display the upgrade history for")
+	historyCmd.Flags().StringVarP(&historyOptions.OutputFormat, "output", "o", constants.TableOutputFormat, "Output format for the upgrade history (table or json)")
+}
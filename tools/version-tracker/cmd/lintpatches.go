@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/lintpatches"
+)
+
+var lintPatchesOptions = &types.LintPatchesOptions{}
+
+// lintPatchesCmd is the command used to check patch files for formatting problems.
+var lintPatchesCmd = &cobra.Command{
+	Use:   "lint-patches [--projects <project names> | --patches <patch files>]",
+	Short: "Check patch files for formatting problems",
+	Long:  "Use this command to check the patches of one or more projects, or the given patch files, for trailing whitespace, missing final newlines, file mode changes, absolute paths and CRLF line endings, reporting each problem with the line it is on so that malformed patches fail before they are applied and built",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := lintpatches.Run(lintPatchesOptions)
+		if err != nil {
+			log.Fatalf("Error linting patches: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lintPatchesCmd)
+	lintPatchesCmd.Flags().StringSliceVar(&lintPatchesOptions.ProjectNames, "projects", nil, "Comma-separated names of the projects to lint patches for (default is all projects)")
+	lintPatchesCmd.Flags().StringSliceVar(&lintPatchesOptions.PatchFiles, "patches", nil, "Comma-separated paths of patch files to lint instead of project patches, which does not require the build-tooling repository")
+	lintPatchesCmd.Flags().StringVarP(&lintPatchesOptions.OutputFormat, "output", "o", constants.TableOutputFormat, "Output format for the findings (table or json)")
+	lintPatchesCmd.MarkFlagsMutuallyExclusive("projects", "patches")
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/patchedfiles"
+)
+
+var patchedFilesOptions = &types.PatchedFilesOptions{}
+
+// patchedFilesCmd is the command used to search the upstream files modified by project patches.
+var patchedFilesCmd = &cobra.Command{
+	Use:   "patched-files --file <regex>",
+	Short: "Search the upstream files modified by patches across projects",
+	Long:  "Use this command to index the upstream files modified by the patches of every project and list the projects and patches that modify files matching a pattern",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := patchedfiles.Run(patchedFilesOptions)
+		if err != nil {
+			log.Fatalf("Error searching patched files: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(patchedFilesCmd)
+	patchedFilesCmd.Flags().StringVar(&patchedFilesOptions.FilePattern, "file", "", "Regular expression to match against the upstream file paths modified by patches")
+	patchedFilesCmd.Flags().StringVar(&patchedFilesOptions.ProjectName, "project", "", "Specify the project name to restrict the search to")
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/prunebranches"
+)
+
+var pruneBranchesOptions = &types.PruneBranchesOptions{}
+
+// pruneBranchesCmd is the command used to delete stale upgrade branches from the head repository.
+var pruneBranchesCmd = &cobra.Command{
+	Use:   "prune-branches",
+	Short: "Delete stale upgrade branches from the head repository",
+	Long:  "Use this command to delete the upgrade branches created by version-tracker in the head repository whose PRs were merged or closed longer ago than the maximum age",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := prunebranches.Run(pruneBranchesOptions)
+		if err != nil {
+			log.Fatalf("Error pruning stale branches: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pruneBranchesCmd)
+	pruneBranchesCmd.Flags().DurationVar(&pruneBranchesOptions.MaxAge, "max-age", constants.DefaultStaleBranchAge, "Minimum time since a branch's PR was merged or closed for the branch to be deleted")
+	pruneBranchesCmd.Flags().BoolVar(&pruneBranchesOptions.DryRun, "dry-run", false, "List the stale branches but do not delete them")
+	pruneBranchesCmd.Flags().BoolVar(&pruneBranchesOptions.ContinueOnError, "continue-on-error", false, "Keep processing the remaining branches when a branch cannot be checked or deleted, and summarize the failures at the end")
+}
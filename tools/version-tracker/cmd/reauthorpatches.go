@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/reauthorpatches"
+)
+
+var reauthorPatchesOptions = &types.ReauthorPatchesOptions{}
+
+// reauthorPatchesCmd is the command used to interactively fix and re-export a project's patches.
+var reauthorPatchesCmd = &cobra.Command{
+	Use:   "reauthor-patches --project <project name>",
+	Short: "Interactively resolve patch conflicts and re-export a project's patches",
+	Long:  "Use this command to check out a project's upstream repository with its patches applied, open a shell in the checkout to resolve conflicts or edit commits with the usual Git tools, and re-export the resulting commits to the project's patches directory",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := reauthorpatches.Run(reauthorPatchesOptions)
+		if err != nil {
+			log.Fatalf("Error re-authoring patches: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reauthorPatchesCmd)
+	reauthorPatchesCmd.Flags().StringVar(&reauthorPatchesOptions.ProjectName, "project", "", "Specify the project name to re-author patches for")
+	reauthorPatchesCmd.Flags().StringVar(&reauthorPatchesOptions.ReleaseBranch, "release-branch", "", "Specify the release branch to re-author patches for, if the project is release-branched")
+	if err := reauthorPatchesCmd.MarkFlagRequired("project"); err != nil {
+		log.Fatalf("Error marking flag %q as required: %v", "project", err)
+	}
+}
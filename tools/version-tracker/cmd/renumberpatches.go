@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/renumberpatches"
+)
+
+var renumberPatchesOptions = &types.RenumberPatchesOptions{}
+
+// renumberPatchesCmd is the command used to renumber the patches of projects after patches are added, removed or rewritten.
+var renumberPatchesCmd = &cobra.Command{
+	Use:   "renumber-patches --projects <project names>",
+	Short: "Renumber the subjects and filenames of project patch series",
+	Long:  "Use this command to regenerate the [PATCH n/m] numbering in the subjects of each patch series of one or more projects, and the number prefixes of the patch filenames, from the order in which the patches are applied, after patches are added, removed or rewritten",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := renumberpatches.Run(renumberPatchesOptions)
+		if err != nil {
+			log.Fatalf("Error renumbering patches: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(renumberPatchesCmd)
+	renumberPatchesCmd.Flags().StringSliceVar(&renumberPatchesOptions.ProjectNames, "projects", nil, "Comma-separated names of the projects to renumber patches for (default is all projects)")
+	renumberPatchesCmd.Flags().BoolVar(&renumberPatchesOptions.Check, "check", false, "Only check whether the patches are numbered consistently, exiting with an error if any patch needs renumbering")
+}
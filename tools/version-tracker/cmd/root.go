@@ -7,7 +7,15 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/buildinfo"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/cleanup"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/disk"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/environment"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/network"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/tracing"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
 )
 
 // rootCmd is the top-level version-tracker command used to track projects and their versions.
@@ -15,17 +23,28 @@ var rootCmd = &cobra.Command{
 	Use:              "version-tracker",
 	Short:            "Amazon EKS Anywhere Build-tooling Version Tracker",
 	Long:             "Use version-tracker to check and update the Git tag and Go version used to build projects in aws/eks-anywhere-build-tooling",
+	Version:          buildinfo.String(),
 	PersistentPreRun: rootPersistentPreRun,
 }
 
 func init() {
-	rootCmd.PersistentFlags().IntP("verbosity", "v", 0, "Set the logging verbosity level")
+	rootCmd.PersistentFlags().IntP("verbosity", "v", 0, "Set the logging verbosity level, 6 and above logs the executed Git and Make commands and their full output")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Only log warnings and errors")
+	rootCmd.PersistentFlags().String("log-format", constants.TextLogFormat, "Set the logging output format (text, json)")
+	rootCmd.PersistentFlags().String("repo-root", "", "Path to an existing build-tooling repository checkout to operate on instead of cloning the repository")
+	rootCmd.PersistentFlags().String("workspace-dir", "", "Directory to clone the build-tooling repository and check out upstream repositories in (default is the current directory)")
+	rootCmd.PersistentFlags().String("ca-bundle", "", "Path to a PEM-encoded CA bundle to trust in addition to the system certificates, for example the CA of a TLS-intercepting proxy")
+	rootCmd.PersistentFlags().String("clone-mode", constants.FullCloneMode, "Set how upstream repositories are cloned (full, partial, shallow), partial clones download file contents on demand and shallow clones only download the checked-out revisions of the upstream mirrors, while the checkouts of the project Makefiles remain partial clones unless --worktree-checkouts is set")
+	rootCmd.PersistentFlags().Int("min-free-disk-space", constants.DefaultMinimumFreeDiskSpaceGiB, "Minimum free disk space in GiB required on the workspace volume before cloning upstream repositories or building projects, 0 disables the check")
+	rootCmd.PersistentFlags().Bool("worktree-checkouts", false, "Check out upstream repositories for the project Makefiles as worktrees of mirrors kept in the workspace directory instead of cloning them each time")
 	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
 		log.Fatalf("failed to bind flags to root command: %v", err)
 	}
 }
 
 func Execute() error {
+	registerCompletions()
+
 	return rootCmd.Execute()
 }
 
@@ -33,10 +52,40 @@ func rootPersistentPreRun(cmd *cobra.Command, args []string) {
 	if err := initLogger(); err != nil {
 		log.Fatal(err)
 	}
+
+	if err := network.Init(viper.GetString("ca-bundle")); err != nil {
+		log.Fatalf("Error configuring network settings: %v", err)
+	}
+
+	buildInfo := buildinfo.Get()
+	logger.V(6).Info("Running version-tracker", "Version", buildInfo.Version, "Git commit", buildInfo.GitCommit, "Build date", buildInfo.BuildDate)
+
+	if err := environment.Init(); err != nil {
+		log.Fatalf("Error configuring host environment: %v", err)
+	}
+
+	if err := disk.Init(viper.GetInt("min-free-disk-space")); err != nil {
+		log.Fatalf("Error configuring disk space check: %v", err)
+	}
+
+	if err := workspace.Init(viper.GetString("repo-root"), viper.GetString("workspace-dir"), viper.GetBool("worktree-checkouts"), viper.GetString("clone-mode")); err != nil {
+		log.Fatalf("Error configuring workspace: %v", err)
+	}
+
+	if err := tracing.Init(); err != nil {
+		log.Fatalf("Error configuring tracing: %v", err)
+	}
+
+	// Restore the workspace if the command is interrupted partway through an operation.
+	cleanup.HandleSignals()
 }
 
 func initLogger() error {
-	if err := logger.Init(viper.GetInt("verbosity")); err != nil {
+	if viper.GetBool("quiet") && viper.GetInt("verbosity") > 0 {
+		return fmt.Errorf("--quiet and --verbosity flags cannot be used together")
+	}
+
+	if err := logger.Init(viper.GetInt("verbosity"), viper.GetBool("quiet"), viper.GetString("log-format")); err != nil {
 		return fmt.Errorf("failed to init Zap logger in root command: %v", err)
 	}
 
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/selfupdate"
+)
+
+var selfUpdateOptions = &types.SelfUpdateOptions{}
+
+// selfUpdateCmd is the command used to replace the version-tracker binary with the latest published build.
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update the version-tracker binary to the latest published build",
+	Long:  "Use this command to check the published artifacts location for a newer version-tracker build and, after verifying its checksum and signature, atomically replace the running binary with it",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := selfupdate.Run(selfUpdateOptions)
+		if err != nil {
+			log.Fatalf("Error updating version-tracker: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(selfUpdateCmd)
+	selfUpdateCmd.Flags().StringVar(&selfUpdateOptions.ArtifactsURL, "artifacts-url", "", "URL of the location the version-tracker builds are published to (default is the value of the VERSION_TRACKER_ARTIFACTS_URL environment variable)")
+	selfUpdateCmd.Flags().StringVar(&selfUpdateOptions.PublicKeyFile, "public-key", "", "Path to a PEM-encoded Ed25519 public key to verify the signature of the downloaded build with (default is the value of the VERSION_TRACKER_PUBLIC_KEY environment variable)")
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateOptions.InsecureSkipSignature, "insecure-skip-signature", false, "Install the downloaded build without verifying its signature, which also skips running it before the running binary is replaced")
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateOptions.CheckOnly, "check", false, "Only check whether a newer build is available, without downloading it")
+}
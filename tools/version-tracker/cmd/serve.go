@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/serve"
+)
+
+var serveOptions = &types.ServeOptions{}
+
+// serveCmd is the command used to serve project metadata over HTTP.
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve project metadata over HTTP",
+	Long:  "Use this command to serve the metadata of the projects in the build-tooling repository as JSON over HTTP, including the Git tags, Go versions and patch counts they are built with, how long ago their versions last changed and their last successful builds, refreshed periodically from the repository",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := serve.Run(serveOptions)
+		if err != nil {
+			log.Fatalf("Error serving project metadata: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&serveOptions.ListenAddress, "listen-address", constants.DefaultServeListenAddress, "Address to listen for HTTP requests on")
+	serveCmd.Flags().DurationVar(&serveOptions.RefreshInterval, "refresh-interval", constants.DefaultServeRefreshInterval, "Interval to pull the build-tooling repository and regenerate the metadata at, 0 disables refreshing")
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/triage"
+)
+
+var triageOptions = &types.TriageOptions{}
+
+// triageCmd is the command used to classify build failures for a project and suggest fixes.
+var triageCmd = &cobra.Command{
+	Use:   "triage --project <project name>",
+	Short: "Classify a project's build failure and suggest a fix",
+	Long:  "Use this command to reproduce a project's checkout, patch and build locally, or parse an existing build log, and classify the failure as a patch conflict, checksum mismatch, Go toolchain mismatch or upstream download failure along with the command to fix it",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := triage.Run(triageOptions)
+		if err != nil {
+			log.Fatalf("Error triaging build failure: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(triageCmd)
+	triageCmd.Flags().StringVar(&triageOptions.ProjectName, "project", "", "Specify the project name to triage the build failure for")
+	triageCmd.Flags().StringVar(&triageOptions.ReleaseBranch, "release-branch", "", "Specify the release branch to build the project for, if the project is release-branched")
+	triageCmd.Flags().StringVar(&triageOptions.LogFile, "log-file", "", "Path to an existing build log (e.g. from a Prow job) to classify instead of reproducing the build locally")
+	if err := triageCmd.MarkFlagRequired("project"); err != nil {
+		log.Fatalf("Error marking flag %q as required: %v", "project", err)
+	}
+}
@@ -5,8 +5,10 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/upgrade"
-	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/tracing"
 )
 
 var upgradeOptions = &types.UpgradeOptions{}
@@ -17,7 +19,10 @@ var upgradeCmd = &cobra.Command{
 	Short: "Upgrade the version for a single project",
 	Long:  "Use this command to upgrade the Git tag and related versions for a particular project in the EKS-A build-tooling repository",
 	Run: func(cmd *cobra.Command, args []string) {
+		span := tracing.Start("upgrade", "Project", upgradeOptions.ProjectName)
 		err := upgrade.Run(upgradeOptions)
+		span.End(err)
+		tracing.Shutdown()
 		if err != nil {
 			log.Fatalf("Error upgrading project version: %v", err)
 		}
@@ -28,6 +33,11 @@ func init() {
 	rootCmd.AddCommand(upgradeCmd)
 	upgradeCmd.Flags().StringVar(&upgradeOptions.ProjectName, "project", "", "Specify the project name to upgrade versions for")
 	upgradeCmd.Flags().BoolVar(&upgradeOptions.DryRun, "dry-run", false, "Upgrade the project locally but do not push changes and create PR")
+	upgradeCmd.Flags().StringVar(&upgradeOptions.BreakingChangeLabel, "breaking-change-label", "", "Label to add to the PR when potential breaking changes are found in the upstream release")
+	upgradeCmd.Flags().StringVar(&upgradeOptions.PullRequestPolicy, "pull-request-policy", constants.PerReleaseBranchPullRequestPolicy, "Create one PR per release branch (per-branch) or a single PR for all release branches (combined) when upgrading release-branched projects")
+	upgradeCmd.Flags().BoolVar(&upgradeOptions.RequireGoToolchain, "require-go-toolchain", false, "Fail the upgrade instead of annotating the PR when the Go version required by the latest revision is not available")
+	upgradeCmd.Flags().StringVar(&upgradeOptions.PullRequestTemplatesFile, "pull-request-templates", "", "Path to a file with Go templates for the PR title and body, defaults to tools/version-tracker/pull-request-templates.yaml in the build-tooling repository if present")
+	upgradeCmd.Flags().BoolVar(&upgradeOptions.Simulate, "simulate", false, "Build the project at the latest revision before creating the PR and add the result to the PR description")
 	if err := upgradeCmd.MarkFlagRequired("project"); err != nil {
 		log.Fatalf("Error marking flag %q as required: %v", "project", err)
 	}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/verify"
+)
+
+var verifyOptions = &types.VerifyOptions{}
+
+// verifyCmd is the command used to verify that a project's checked-in checksums, attribution and go.sum snapshots are up to date.
+var verifyCmd = &cobra.Command{
+	Use:   "verify --project <project name>",
+	Short: "Verify a project's checksums, attribution and go.sum snapshots are up to date",
+	Long:  "Use this command to regenerate a project's CHECKSUMS, ATTRIBUTION and go.sum snapshots for the current Git tag and patches in the builder base container and report any files that differ from the checked-in versions",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := verify.Run(verifyOptions)
+		if err != nil {
+			log.Fatalf("Error verifying project: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+	verifyCmd.Flags().StringVar(&verifyOptions.ProjectName, "project", "", "Specify the project name to verify")
+	verifyCmd.Flags().StringVar(&verifyOptions.ReleaseBranch, "release-branch", "", "Specify the release branch to verify the project for, if the project is release-branched")
+	if err := verifyCmd.MarkFlagRequired("project"); err != nil {
+		log.Fatalf("Error marking flag %q as required: %v", "project", err)
+	}
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/verifypatches"
+)
+
+var verifyPatchesOptions = &types.VerifyPatchesOptions{}
+
+// verifyPatchesCmd is the command used to verify that a project's patches apply on every supported release branch.
+var verifyPatchesCmd = &cobra.Command{
+	Use:   "verify-patches --project <project name>",
+	Short: "Verify a project's patches apply on every supported release branch",
+	Long:  "Use this command to apply each release branch's patch series to that branch's Git tag in parallel worktrees of the project's upstream repository and report which release branches pass or fail",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := verifypatches.Run(verifyPatchesOptions)
+		if err != nil {
+			log.Fatalf("Error verifying patches: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(verifyPatchesCmd)
+	verifyPatchesCmd.Flags().StringVar(&verifyPatchesOptions.ProjectName, "project", "", "Specify the project name to verify patches for")
+	verifyPatchesCmd.Flags().BoolVar(&verifyPatchesOptions.RecordProvenance, "record-provenance", false, "Record the Git tag each patch was verified against in the patch provenance files of the release branches that pass")
+	if err := verifyPatchesCmd.MarkFlagRequired("project"); err != nil {
+		log.Fatalf("Error marking flag %q as required: %v", "project", err)
+	}
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/version"
+)
+
+var versionOptions = &types.VersionOptions{}
+
+// versionCmd is the command used to display the build information of the version-tracker binary.
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Display the version-tracker build information",
+	Long:  "Use this command to display the version, Git commit, build date and Go version of the version-tracker binary, to identify the build in bug reports and CI logs",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := version.Run(versionOptions)
+		if err != nil {
+			log.Fatalf("Error displaying build information: %v", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+	versionCmd.Flags().StringVarP(&versionOptions.OutputFormat, "output", "o", constants.TableOutputFormat, "Output format for the build information (table or json)")
+}
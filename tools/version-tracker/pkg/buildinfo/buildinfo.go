@@ -0,0 +1,68 @@
+package buildinfo
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+)
+
+// These variables are set at build time with the -X linker flag by the build target of the Makefile.
+var (
+	version   string
+	gitCommit string
+	buildDate string
+)
+
+const unknown = "unknown"
+
+// Get returns the build information of the running binary. Values that were not set at build time fall back to
+// the module version and Git commit that the Go toolchain embeds in binaries built from a module checkout, such
+// as with `go install`, and are reported as unknown otherwise.
+func Get() types.BuildInfo {
+	buildInfo := types.BuildInfo{
+		Version:   version,
+		GitCommit: gitCommit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+		Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+	}
+
+	if goBuildInfo, ok := debug.ReadBuildInfo(); ok {
+		if buildInfo.Version == "" && goBuildInfo.Main.Version != "" && goBuildInfo.Main.Version != "(devel)" {
+			buildInfo.Version = goBuildInfo.Main.Version
+		}
+		if buildInfo.GitCommit == "" {
+			var revision string
+			var modified bool
+			for _, setting := range goBuildInfo.Settings {
+				switch setting.Key {
+				case "vcs.revision":
+					revision = setting.Value
+				case "vcs.modified":
+					modified = setting.Value == "true"
+				}
+			}
+			if revision != "" && modified {
+				revision = fmt.Sprintf("%s-dirty", revision)
+			}
+			buildInfo.GitCommit = revision
+		}
+	}
+
+	for _, value := range []*string{&buildInfo.Version, &buildInfo.GitCommit, &buildInfo.BuildDate} {
+		if *value == "" {
+			*value = unknown
+		}
+	}
+
+	return buildInfo
+}
+
+// String returns a one-line summary of the build information, as printed by the --version flag.
+func String() string {
+	buildInfo := Get()
+
+	return fmt.Sprintf("%s (commit %s, built %s, %s %s)", buildInfo.Version, buildInfo.GitCommit, buildInfo.BuildDate, buildInfo.GoVersion, buildInfo.Platform)
+}
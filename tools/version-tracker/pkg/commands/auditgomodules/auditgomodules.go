@@ -0,0 +1,230 @@
+package auditgomodules
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	gogithub "github.com/google/go-github/v53/github"
+	"github.com/rodaine/table"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/github"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/osv"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/gomod"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// Run contains the business logic to execute the `audit-go-modules` subcommand.
+func Run(auditGoModulesOptions *types.AuditGoModulesOptions) error {
+	// Check if GitHub token environment variable has been set.
+	githubToken, ok := os.LookupEnv(constants.GitHubTokenEnvvar)
+	if !ok {
+		return fmt.Errorf("GITHUB_TOKEN environment variable is not set")
+	}
+	client := gogithub.NewTokenClient(context.Background(), githubToken)
+
+	// Get base repository owner environment variable if set.
+	baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+	if baseRepoOwner == "" {
+		baseRepoOwner = constants.DefaultBaseRepoOwner
+	}
+
+	// Clone the eks-anywhere-build-tooling repository.
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+	if err != nil {
+		return fmt.Errorf("cloning build-tooling repo: %v", err)
+	}
+
+	if auditGoModulesOptions.ProjectName != "" {
+		// Validate if the project name provided exists in the repository.
+		if !projects.Exists(buildToolingRepoPath, auditGoModulesOptions.ProjectName) {
+			return fmt.Errorf("invalid project name %s", auditGoModulesOptions.ProjectName)
+		}
+	}
+
+	// Load upstream projects tracker file.
+	upstreamProjectsTrackerFilePath := filepath.Join(buildToolingRepoPath, constants.UpstreamProjectsTrackerFile)
+	contents, err := os.ReadFile(upstreamProjectsTrackerFilePath)
+	if err != nil {
+		return fmt.Errorf("reading upstream projects tracker file: %v", err)
+	}
+
+	// Unmarshal upstream projects tracker file
+	var projectsList types.ProjectsList
+	err = yaml.Unmarshal(contents, &projectsList)
+	if err != nil {
+		return fmt.Errorf("unmarshalling upstream projects tracker file: %v", err)
+	}
+
+	// Build the mapping of each Go module to the projects requiring it, along with the version required.
+	moduleUsages := map[string][]types.GoModuleUsage{}
+	for _, project := range projectsList.Projects {
+		org := project.Org
+		for _, repo := range project.Repos {
+			fullRepoName := fmt.Sprintf("%s/%s", org, repo.Name)
+			if auditGoModulesOptions.ProjectName != "" && auditGoModulesOptions.ProjectName != fullRepoName {
+				continue
+			}
+
+			for _, version := range repo.Versions {
+				// Projects that are not built from Go source code don't have a module graph to audit.
+				if version.GoVersion == "N/A" {
+					continue
+				}
+				revision := version.Tag
+				if revision == "" {
+					revision = version.Commit
+				}
+
+				requirements, err := getModuleRequirements(client, org, repo.Name, revision)
+				if err != nil {
+					logger.Warn("Skipping project revision without a readable go.mod file", "Project", fullRepoName, "Revision", revision, "Error", err)
+					continue
+				}
+
+				for modulePath, moduleVersion := range requirements {
+					moduleUsages[modulePath] = append(moduleUsages[modulePath], types.GoModuleUsage{
+						Project:  fullRepoName,
+						Revision: revision,
+						Version:  moduleVersion,
+					})
+				}
+			}
+		}
+	}
+
+	modulePaths := make([]string, 0, len(moduleUsages))
+	for modulePath := range moduleUsages {
+		modulePaths = append(modulePaths, modulePath)
+	}
+	sort.Strings(modulePaths)
+
+	printConflictingModules(modulePaths, moduleUsages)
+
+	if auditGoModulesOptions.CheckVulnerabilities {
+		err = printVulnerableModules(modulePaths, moduleUsages)
+		if err != nil {
+			return fmt.Errorf("checking Go modules for known vulnerabilities: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// getModuleRequirements retrieves the go.mod file of the given project at the given revision and returns
+// its effective module requirements.
+func getModuleRequirements(client *gogithub.Client, org, repo, revision string) (map[string]string, error) {
+	logger.V(6).Info(fmt.Sprintf("Getting Go module graph for [%s/%s] repository at revision %s", org, repo, revision))
+	goModContents, err := github.GetFileContents(client, org, repo, constants.GoModFile, revision)
+	if err != nil {
+		return nil, fmt.Errorf("getting contents of go.mod file: %v", err)
+	}
+
+	goModFile, err := gomod.ParseModFile(goModContents)
+	if err != nil {
+		return nil, fmt.Errorf("parsing go.mod file: %v", err)
+	}
+
+	return gomod.EffectiveRequirements(goModFile), nil
+}
+
+// printConflictingModules tabulates the Go modules that are shared between projects but required at different versions.
+func printConflictingModules(modulePaths []string, moduleUsages map[string][]types.GoModuleUsage) {
+	tbl := table.New("Module", "Version", "Projects").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	})
+
+	conflictCount := 0
+	for _, modulePath := range modulePaths {
+		projectsByVersion := groupProjectsByVersion(moduleUsages[modulePath])
+		if len(projectsByVersion) < 2 {
+			continue
+		}
+		conflictCount++
+
+		versions := make([]string, 0, len(projectsByVersion))
+		for moduleVersion := range projectsByVersion {
+			versions = append(versions, moduleVersion)
+		}
+		sort.Strings(versions)
+
+		for _, moduleVersion := range versions {
+			tbl.AddRow(modulePath, moduleVersion, strings.Join(projectsByVersion[moduleVersion], ", "))
+		}
+	}
+
+	logger.Info(fmt.Sprintf("Found %d shared Go modules with conflicting versions", conflictCount))
+	if conflictCount > 0 {
+		tbl.Print()
+	}
+}
+
+// printVulnerableModules tabulates the Go module versions that have known vulnerabilities in the OSV database.
+func printVulnerableModules(modulePaths []string, moduleUsages map[string][]types.GoModuleUsage) error {
+	var modules []types.GoModule
+	for _, modulePath := range modulePaths {
+		for moduleVersion := range groupProjectsByVersion(moduleUsages[modulePath]) {
+			modules = append(modules, types.GoModule{Path: modulePath, Version: moduleVersion})
+		}
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		if modules[i].Path != modules[j].Path {
+			return modules[i].Path < modules[j].Path
+		}
+		return modules[i].Version < modules[j].Version
+	})
+
+	vulnerabilities, err := osv.GetVulnerabilities(modules)
+	if err != nil {
+		return fmt.Errorf("getting vulnerabilities from OSV database: %v", err)
+	}
+
+	tbl := table.New("Module", "Version", "Vulnerabilities", "Projects").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	})
+
+	vulnerableCount := 0
+	for _, module := range modules {
+		vulnIDs, ok := vulnerabilities[module]
+		if !ok {
+			continue
+		}
+		vulnerableCount++
+		sort.Strings(vulnIDs)
+		projects := groupProjectsByVersion(moduleUsages[module.Path])[module.Version]
+		tbl.AddRow(module.Path, module.Version, strings.Join(vulnIDs, ", "), strings.Join(projects, ", "))
+	}
+
+	logger.Info(fmt.Sprintf("Found %d Go module versions with known vulnerabilities", vulnerableCount))
+	if vulnerableCount > 0 {
+		tbl.Print()
+	}
+
+	return nil
+}
+
+// groupProjectsByVersion returns the sorted list of project revisions requiring each version of a module.
+func groupProjectsByVersion(usages []types.GoModuleUsage) map[string][]string {
+	projectsByVersion := map[string][]string{}
+	for _, usage := range usages {
+		projectsByVersion[usage.Version] = append(projectsByVersion[usage.Version], fmt.Sprintf("%s@%s", usage.Project, usage.Revision))
+	}
+	for moduleVersion := range projectsByVersion {
+		sort.Strings(projectsByVersion[moduleVersion])
+	}
+
+	return projectsByVersion
+}
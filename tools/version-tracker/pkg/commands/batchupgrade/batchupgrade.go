@@ -0,0 +1,390 @@
+package batchupgrade
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere/pkg/semver"
+	gogit "github.com/go-git/go-git/v5"
+	gogithub "github.com/google/go-github/v53/github"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/upgrade"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/github"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/history"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/cleanup"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/failures"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/makefile"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/progress"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/slices"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/tracing"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// Run contains the business logic to execute the `batch-upgrade` subcommand.
+func Run(batchUpgradeOptions *types.BatchUpgradeOptions) error {
+	var lowRiskUpgrades []types.BatchProjectUpgrade
+	var individualProjects []string
+	report := failures.NewReport("Project")
+
+	// Check if base repository owner environment variable has been set.
+	baseRepoOwner, ok := os.LookupEnv(constants.BaseRepoOwnerEnvvar)
+	if !ok {
+		return fmt.Errorf("BASE_REPO_OWNER environment variable is not set")
+	}
+
+	// Check if head repository owner environment variable has been set.
+	headRepoOwner, ok := os.LookupEnv(constants.HeadRepoOwnerEnvvar)
+	if !ok {
+		return fmt.Errorf("HEAD_REPO_OWNER environment variable is not set")
+	}
+
+	// Check if GitHub token environment variable has been set.
+	githubToken, ok := os.LookupEnv(constants.GitHubTokenEnvvar)
+	if !ok {
+		return fmt.Errorf("GITHUB_TOKEN environment variable is not set")
+	}
+	client := gogithub.NewTokenClient(context.Background(), githubToken)
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("retrieving current working directory: %v", err)
+	}
+
+	contents, err := os.ReadFile(filepath.Join(cwd, constants.SkippedProjectsFile))
+	if err != nil {
+		return fmt.Errorf("reading skipped projects file: %v", err)
+	}
+	skippedProjects := strings.Split(string(contents), "\n")
+
+	// Clone the eks-anywhere-build-tooling repository.
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	cloneSpan := tracing.Start("clone-build-tooling-repo")
+	repo, headCommit, err := git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, headRepoOwner)
+	cloneSpan.End(err)
+	if err != nil {
+		return fmt.Errorf("cloning build-tooling repo: %v", err)
+	}
+
+	// Get the worktree corresponding to the cloned repository.
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("getting repo's current worktree: %v", err)
+	}
+
+	// Discard the partially applied upgrade if the command is interrupted.
+	defer cleanup.Register("Discarding uncommitted changes in build-tooling repository", func() error {
+		return git.DiscardChanges(worktree, nil)
+	})()
+
+	// Load upstream projects tracker file.
+	contents, err = os.ReadFile(filepath.Join(buildToolingRepoPath, constants.UpstreamProjectsTrackerFile))
+	if err != nil {
+		return fmt.Errorf("reading upstream projects tracker file: %v", err)
+	}
+	var projectsList types.ProjectsList
+	err = yaml.Unmarshal(contents, &projectsList)
+	if err != nil {
+		return fmt.Errorf("unmarshalling upstream projects tracker file: %v", err)
+	}
+
+	// Classify the pending upgrades of all projects. Only patch-level upgrades of projects without release branches
+	// and with conventional upgrade flows are candidates for the combined pull request.
+	stopProgress := progress.Start("checking projects for upgrades")
+	for _, project := range projectsList.Projects {
+		for _, repository := range project.Repos {
+			projectName := fmt.Sprintf("%s/%s", project.Org, repository.Name)
+			if slices.Contains(skippedProjects, projectName) {
+				logger.V(6).Info("Project is in SKIPPED_PROJECTS list. Skipping upgrade", "Project", projectName)
+				continue
+			}
+
+			currentVersion := repository.Versions[0]
+			if _, ok := constants.ProjectCommitTrackingPolicies[projectName]; currentVersion.Tag == "" && !ok {
+				logger.V(6).Info("Project is tracked with a commit hash and has no commit tracking policy. Skipping upgrade", "Project", projectName)
+				continue
+			}
+
+			deferral, err := getScheduleDeferral(client, buildToolingRepoPath, projectName, project.Org, repository.Name, currentVersion.Tag, batchUpgradeOptions.ReleaseFreeze)
+			if err != nil {
+				report.Add(projectName, "check upgrade schedule", err)
+				continue
+			}
+			if deferral != "" {
+				logger.Info("Project upgrade is deferred by its upgrade schedule", "Project", projectName, "Reason", deferral)
+				continue
+			}
+
+			if currentVersion.Tag == "" {
+				individualProjects = append(individualProjects, projectName)
+				continue
+			}
+			if len(repository.Versions) > 1 || projectName == "cilium/cilium" || slices.Contains(constants.ProjectsWithUnconventionalUpgradeFlows, projectName) {
+				individualProjects = append(individualProjects, projectName)
+				continue
+			}
+
+			latestRevision, needsUpgrade, err := github.GetLatestRevision(client, project.Org, repository.Name, currentVersion.Tag)
+			if err != nil {
+				report.Add(projectName, "get latest revision", err)
+				continue
+			}
+			if !needsUpgrade {
+				logger.V(6).Info("Project is at the latest available version.", "Project", projectName, "Current version", currentVersion.Tag)
+				continue
+			}
+
+			risk, err := getUpgradeRisk(client, buildToolingRepoPath, project.Org, repository.Name, currentVersion, latestRevision)
+			if err != nil {
+				report.Add(projectName, "assess upgrade risk", err)
+				continue
+			}
+			if risk != "" {
+				logger.Info("Project upgrade is not low-risk, proposing it separately", "Project", projectName, "Reason", risk)
+				individualProjects = append(individualProjects, projectName)
+				continue
+			}
+			lowRiskUpgrades = append(lowRiskUpgrades, types.BatchProjectUpgrade{
+				Project:        projectName,
+				CurrentVersion: currentVersion,
+				LatestRevision: latestRevision,
+			})
+		}
+	}
+	stopProgress()
+
+	if len(lowRiskUpgrades) > 0 {
+		upgradedProjects, conflictingProjects, err := upgradeLowRiskProjects(client, repo, worktree, headCommit, lowRiskUpgrades, batchUpgradeOptions.DryRun, batchUpgradeOptions.ContinueOnError, report, buildToolingRepoPath, baseRepoOwner, headRepoOwner, githubToken)
+		if err != nil {
+			if !batchUpgradeOptions.ContinueOnError {
+				return fmt.Errorf("upgrading low-risk projects: %v", err)
+			}
+			for _, lowRiskUpgrade := range lowRiskUpgrades {
+				report.Add(lowRiskUpgrade.Project, "upgrade low-risk projects in a single PR", err)
+			}
+		} else {
+			logger.Info(fmt.Sprintf("Upgraded %d low-risk projects in a single PR", len(upgradedProjects)))
+			individualProjects = append(individualProjects, conflictingProjects...)
+		}
+	}
+
+	// Propose the remaining upgrades individually, using the same flow as the `upgrade` subcommand.
+	for i, projectName := range individualProjects {
+		logger.Info("Upgrading project", "Project", projectName, "Progress", progress.Fraction(i, len(individualProjects)))
+		span := tracing.Start("upgrade", "Project", projectName)
+		err = upgrade.Run(&types.UpgradeOptions{
+			ProjectName: projectName,
+			DryRun:      batchUpgradeOptions.DryRun,
+		})
+		span.End(err)
+		if err != nil {
+			report.Add(projectName, "upgrade project", err)
+		}
+	}
+
+	report.Print()
+
+	return report.Err("upgrade projects")
+}
+
+// getScheduleDeferral returns the reason why the project's upgrade schedule defers its upgrade to a later run, or an
+// empty string if the project can be upgraded in this run. If the schedule has security releases upgraded
+// immediately and the project is tracked with a Git tag, the upgrade is not deferred when the upstream changes up to
+// the latest revision mention security fixes.
+func getScheduleDeferral(client *gogithub.Client, buildToolingRepoPath, projectName, projectOrg, projectRepo, currentTag string, releaseFreeze bool) (string, error) {
+	upgradeSchedule := constants.ProjectUpgradeSchedules[projectName]
+
+	deferral := ""
+	if releaseFreeze && !upgradeSchedule.ReleaseFreezeException {
+		deferral = "release freeze is in effect"
+	} else if upgradeSchedule.Interval > 0 {
+		upgradeDate, upgraded, err := history.LastUpgradeDate(filepath.Join(buildToolingRepoPath, constants.UpgradeHistoryDirectory), projectName)
+		if err != nil {
+			return "", fmt.Errorf("getting last upgrade date from upgrade history: %v", err)
+		}
+		if upgraded && time.Since(upgradeDate) < upgradeSchedule.Interval {
+			deferral = fmt.Sprintf("last upgraded on %s, less than %s ago", upgradeDate.Format(time.RFC3339), upgradeSchedule.Interval)
+		}
+	}
+	if deferral == "" || !upgradeSchedule.SecurityReleasesImmediately || currentTag == "" {
+		return deferral, nil
+	}
+
+	latestRevision, needsUpgrade, err := github.GetLatestRevision(client, projectOrg, projectRepo, currentTag)
+	if err != nil {
+		return "", fmt.Errorf("getting latest revision: %v", err)
+	}
+	if !needsUpgrade {
+		return deferral, nil
+	}
+	hasSecurityFixes, err := github.HasSecurityFixes(client, projectOrg, projectRepo, currentTag, latestRevision)
+	if err != nil {
+		return "", fmt.Errorf("checking for security fixes: %v", err)
+	}
+	if hasSecurityFixes {
+		logger.Info("Project upgrade includes security fixes, ignoring its upgrade schedule", "Project", projectName, "Latest version", latestRevision)
+		return "", nil
+	}
+
+	return deferral, nil
+}
+
+// getUpgradeRisk returns the reason why upgrading the project to the latest revision is not low-risk, or an empty
+// string if it is. Upgrades that cross minor versions, have potential breaking changes or require a Go version that
+// is not yet available are not low-risk.
+func getUpgradeRisk(client *gogithub.Client, buildToolingRepoPath, projectOrg, projectRepo string, currentVersion types.Version, latestRevision string) (string, error) {
+	currentRevisionSemver, err := semver.New(currentVersion.Tag)
+	if err != nil {
+		return "", fmt.Errorf("getting semver for current version: %v", err)
+	}
+	latestRevisionSemver, err := semver.New(latestRevision)
+	if err != nil {
+		return "", fmt.Errorf("getting semver for latest version: %v", err)
+	}
+	if latestRevisionSemver.Major != currentRevisionSemver.Major || latestRevisionSemver.Minor != currentRevisionSemver.Minor {
+		return fmt.Sprintf("%s to %s is not a patch-level upgrade", currentVersion.Tag, latestRevision), nil
+	}
+
+	breakingChanges, err := github.GetBreakingChanges(client, projectOrg, projectRepo, currentVersion.Tag, latestRevision)
+	if err != nil {
+		return "", fmt.Errorf("getting breaking changes: %v", err)
+	}
+	if len(breakingChanges) > 0 {
+		return fmt.Sprintf("found %d potential breaking changes", len(breakingChanges)), nil
+	}
+
+	if currentVersion.GoVersion != "N/A" {
+		goToolchainSection, err := upgrade.CheckGoToolchainAvailability(client, buildToolingRepoPath, projectOrg, projectRepo, latestRevision)
+		if err != nil {
+			return "", fmt.Errorf("checking Go toolchain availability: %v", err)
+		}
+		if goToolchainSection != "" {
+			return "required Go version is not available", nil
+		}
+	}
+
+	return "", nil
+}
+
+// upgradeLowRiskProjects upgrades the given projects in a single branch, with a commit per project, and creates a
+// combined pull request for them. Projects whose patches fail to apply are left out of the branch and returned so
+// they can be proposed separately, along with the projects that were upgraded. If continueOnError is set, projects
+// whose version files cannot be updated are recorded in the report and left out of the branch instead of failing
+// the whole batch.
+func upgradeLowRiskProjects(client *gogithub.Client, repo *gogit.Repository, worktree *gogit.Worktree, headCommit string, lowRiskUpgrades []types.BatchProjectUpgrade, dryRun, continueOnError bool, report *failures.Report, buildToolingRepoPath, baseRepoOwner, headRepoOwner, githubToken string) ([]string, []string, error) {
+	var upgradedProjects, conflictingProjects, upgradeLines []string
+
+	// Checkout a new branch to keep track of version upgrade changes.
+	err := git.Checkout(worktree, constants.BatchUpgradeBranchName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("checking out worktree at branch %s: %v", constants.BatchUpgradeBranchName, err)
+	}
+
+	// Reset current worktree to get a clean index.
+	err = git.ResetToMain(worktree, headCommit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resetting new branch to [origin/main] HEAD: %v", err)
+	}
+
+	for i, lowRiskUpgrade := range lowRiskUpgrades {
+		projectName := lowRiskUpgrade.Project
+		logger.Info("Project is out of date.", "Project", projectName, "Current version", lowRiskUpgrade.CurrentVersion.Tag, "Latest version", lowRiskUpgrade.LatestRevision, "Progress", progress.Fraction(i, len(lowRiskUpgrades)))
+
+		updatedFiles, patchesWarningComment, err := upgrade.UpdateProjectVersionFiles(client, buildToolingRepoPath, projectName, lowRiskUpgrade.CurrentVersion, lowRiskUpgrade.LatestRevision)
+		if err != nil {
+			if !continueOnError {
+				return nil, nil, fmt.Errorf("updating version files for [%s] project: %v", projectName, err)
+			}
+			report.Add(projectName, "update project version files", err)
+			// The previous projects are already committed, so resetting only discards the failed project's changes.
+			err = git.DiscardChanges(worktree, updatedFiles)
+			if err != nil {
+				return nil, nil, fmt.Errorf("discarding changes for [%s] project: %v", projectName, err)
+			}
+			makefile.InvalidateCache(projects.RootPath(buildToolingRepoPath, projectName))
+			continue
+		}
+
+		// Projects with patch conflicts need a human to regenerate the patches, so propose them separately.
+		if patchesWarningComment != "" {
+			logger.Warn("Project patches failed to apply, proposing the upgrade separately", "Project", projectName)
+			err = git.DiscardChanges(worktree, updatedFiles)
+			if err != nil {
+				return nil, nil, fmt.Errorf("discarding changes for [%s] project: %v", projectName, err)
+			}
+			makefile.InvalidateCache(projects.RootPath(buildToolingRepoPath, projectName))
+			conflictingProjects = append(conflictingProjects, projectName)
+			continue
+		}
+
+		if !dryRun {
+			historyEntryRelativePath, err := upgrade.RecordUpgradeInHistory(buildToolingRepoPath, projectName, "", lowRiskUpgrade.CurrentVersion.Tag, lowRiskUpgrade.LatestRevision)
+			if err != nil {
+				return nil, nil, err
+			}
+			updatedFiles = append(updatedFiles, historyEntryRelativePath)
+		}
+
+		err = git.Add(worktree, updatedFiles)
+		if err != nil {
+			return nil, nil, fmt.Errorf("adding updated files to index: %v", err)
+		}
+
+		err = git.Commit(worktree, fmt.Sprintf("Bump %s to latest release", projectName))
+		if err != nil {
+			return nil, nil, fmt.Errorf("committing updated project version files for [%s] project: %v", projectName, err)
+		}
+
+		projectOrg, projectRepo := projects.SplitName(projectName)
+		compareURL := fmt.Sprintf(constants.GithubCompareURLFormat, projectOrg, projectRepo, lowRiskUpgrade.CurrentVersion.Tag, lowRiskUpgrade.LatestRevision)
+		upgradeLines = append(upgradeLines, fmt.Sprintf("* %s: [%s...%s](%s)", projectName, lowRiskUpgrade.CurrentVersion.Tag, lowRiskUpgrade.LatestRevision, compareURL))
+		upgradedProjects = append(upgradedProjects, projectName)
+	}
+
+	if len(upgradedProjects) == 0 {
+		return upgradedProjects, conflictingProjects, nil
+	}
+
+	if dryRun {
+		logger.Info(fmt.Sprintf("Completed dry run of upgrade for projects %s", strings.Join(upgradedProjects, ", ")))
+		return upgradedProjects, conflictingProjects, nil
+	}
+
+	// Push the changes to the target branch in the head repository.
+	pushSpan := tracing.Start("push-branch", "Branch", constants.BatchUpgradeBranchName)
+	err = git.Push(repo, headRepoOwner, constants.BatchUpgradeBranchName, githubToken)
+	pushSpan.End(err)
+	if err != nil {
+		return nil, nil, fmt.Errorf("pushing updated project version files: %v", err)
+	}
+
+	// Create a pull request from the branch in the head repository to the target branch in the aws/eks-anywhere-build-tooling repository.
+	logger.Info("Creating pull request with updated files")
+	pullRequestBody := fmt.Sprintf(constants.BatchUpgradePullRequestBody, strings.Join(upgradeLines, "\n"))
+	pullRequestSpan := tracing.Start("create-pull-request", "Branch", constants.BatchUpgradeBranchName)
+	err = github.CreatePullRequest(client, baseRepoOwner, constants.BuildToolingRepoName, constants.BatchUpgradePullRequestTitle, pullRequestBody, baseRepoOwner, constants.MainBranchName, headRepoOwner, constants.BatchUpgradeBranchName, "", "", false, "", nil)
+	pullRequestSpan.End(err)
+	if err != nil {
+		upgrade.RollBackPullRequestBranch(client, repo, worktree, headCommit, baseRepoOwner, constants.MainBranchName, headRepoOwner, constants.BatchUpgradeBranchName)
+		return nil, nil, fmt.Errorf("creating pull request to %s repository: %v", constants.BuildToolingRepoName, err)
+	}
+
+	err = upgrade.RecordPullRequestInHistory(client, repo, worktree, buildToolingRepoPath, upgradedProjects, baseRepoOwner, constants.MainBranchName, headRepoOwner, constants.BatchUpgradeBranchName, githubToken)
+	if err != nil {
+		return nil, nil, fmt.Errorf("recording pull request in upgrade history: %v", err)
+	}
+
+	return upgradedProjects, conflictingProjects, nil
+}
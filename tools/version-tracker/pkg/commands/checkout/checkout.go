@@ -0,0 +1,146 @@
+package checkout
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/upstream"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/disk"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/environment"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/makefile"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/progress"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// Run contains the business logic to execute the `checkout` subcommand.
+func Run(checkoutOptions *types.CheckoutOptions) error {
+	projectName := checkoutOptions.ProjectName
+	releaseBranch := checkoutOptions.ReleaseBranch
+
+	// Get base repository owner environment variable if set.
+	baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+	if baseRepoOwner == "" {
+		baseRepoOwner = constants.DefaultBaseRepoOwner
+	}
+
+	// Clone the eks-anywhere-build-tooling repository.
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+	if err != nil {
+		return fmt.Errorf("cloning build-tooling repo: %v", err)
+	}
+
+	// Validate if the project name provided exists in the repository.
+	projectRootFilepath := projects.RootPath(buildToolingRepoPath, projectName)
+	if !projects.Exists(buildToolingRepoPath, projectName) {
+		return fmt.Errorf("invalid project name %s", projectName)
+	}
+
+	logger.Info("Checking out project repository and applying patches", "Project", projectName)
+	checkoutRepoOutput, err := CheckoutRepo(projectRootFilepath, releaseBranch)
+	if err != nil {
+		if logger.Verbosity < 6 {
+			fmt.Println(checkoutRepoOutput)
+		}
+		return err
+	}
+
+	projectRepoPath, gitTag, patchesDirectory, err := GetRepoInfo(projectRootFilepath, releaseBranch)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Project repository is ready", "Path", projectRepoPath, "Git tag", gitTag)
+
+	// The checkout has no repository-level Git identity, so new commits use the contributor's own Git
+	// configuration unless the identity from the commit author environment variables is exported.
+	if identityEnv := GitIdentityEnv(); len(identityEnv) > 0 {
+		exports := []string{}
+		for _, variable := range identityEnv {
+			name, value, _ := strings.Cut(variable, "=")
+			exports = append(exports, fmt.Sprintf("%s=%s", name, strconv.Quote(value)))
+		}
+		logger.Info(fmt.Sprintf("To commit with the identity from the commit author environment variables, run `export %s`", strings.Join(exports, " ")))
+	}
+	if patchesDirectory != "" {
+		logger.Info(fmt.Sprintf("After committing changes, regenerate the patches with `git -C %s format-patch --output-directory %s %s`", projectRepoPath, patchesDirectory, gitTag))
+	}
+
+	return nil
+}
+
+// CheckoutRepo runs the project's checkout-repo Make target, which clones the upstream repository at the
+// tracked Git tag and applies the project's patches with git am. The Make output is returned so callers
+// can inspect patch application failures.
+func CheckoutRepo(projectRootFilepath, releaseBranch string) (string, error) {
+	err := disk.CheckFreeSpace(projectRootFilepath)
+	if err != nil {
+		return "", err
+	}
+
+	err = upstream.PrepareCheckout(projectRootFilepath, releaseBranch)
+	if err != nil {
+		return "", fmt.Errorf("preparing upstream worktree checkout: %v", err)
+	}
+
+	checkoutRepoCommandSequence := fmt.Sprintf("%s -C %s checkout-repo", environment.Make(), projectRootFilepath)
+	if releaseBranch != "" {
+		checkoutRepoCommandSequence = fmt.Sprintf("%s RELEASE_BRANCH=%s", checkoutRepoCommandSequence, releaseBranch)
+	}
+	checkoutRepoCmd := exec.Command("bash", "-c", checkoutRepoCommandSequence)
+	stopProgress := progress.Start("checking out upstream repository and applying patches")
+	checkoutRepoOutput, err := command.ExecCommand(checkoutRepoCmd)
+	stopProgress()
+	if err != nil {
+		return checkoutRepoOutput, fmt.Errorf("running checkout-repo Make command: %v", err)
+	}
+
+	return checkoutRepoOutput, nil
+}
+
+// GetRepoInfo returns the path to the project's upstream repository checkout, the tracked Git tag and
+// the project's patches directory, as evaluated by the project Makefile.
+func GetRepoInfo(projectRootFilepath, releaseBranch string) (string, string, string, error) {
+	projectRepo, err := makefile.GetVariableValue(projectRootFilepath, "REPO", releaseBranch)
+	if err != nil {
+		return "", "", "", fmt.Errorf("getting project repository directory: %v", err)
+	}
+	gitTag, err := makefile.GetVariableValue(projectRootFilepath, "GIT_TAG", releaseBranch)
+	if err != nil {
+		return "", "", "", fmt.Errorf("getting project Git tag: %v", err)
+	}
+	patchesDirectory, err := makefile.GetVariableValue(projectRootFilepath, "PATCHES_DIR", releaseBranch)
+	if err != nil {
+		return "", "", "", fmt.Errorf("getting project patches directory: %v", err)
+	}
+
+	return filepath.Join(projectRootFilepath, projectRepo), gitTag, patchesDirectory, nil
+}
+
+// GitIdentityEnv returns the Git author and committer environment variables for the identity read from the
+// commit author environment variables, to be set on Git commands and shells that commit to a checkout. No
+// variables are returned for unset values, in which case Git falls back to the user's own configuration.
+func GitIdentityEnv() []string {
+	identityEnv := []string{}
+	if name := os.Getenv(constants.CommitAuthorNameEnvvar); name != "" {
+		identityEnv = append(identityEnv, fmt.Sprintf("%s=%s", constants.GitAuthorNameEnvvar, name), fmt.Sprintf("%s=%s", constants.GitCommitterNameEnvvar, name))
+	}
+	if email := os.Getenv(constants.CommitAuthorEmailEnvvar); email != "" {
+		identityEnv = append(identityEnv, fmt.Sprintf("%s=%s", constants.GitAuthorEmailEnvvar, email), fmt.Sprintf("%s=%s", constants.GitCommitterEmailEnvvar, email))
+	}
+
+	return identityEnv
+}
@@ -0,0 +1,52 @@
+package checkout
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+)
+
+func TestGitIdentityEnv(t *testing.T) {
+	testcases := []struct {
+		name        string
+		authorName  string
+		authorEmail string
+		wantEnv     []string
+	}{
+		{
+			name:        "Identity from environment",
+			authorName:  "Jane Doe",
+			authorEmail: "jane@example.com",
+			wantEnv: []string{
+				"GIT_AUTHOR_NAME=Jane Doe",
+				"GIT_COMMITTER_NAME=Jane Doe",
+				"GIT_AUTHOR_EMAIL=jane@example.com",
+				"GIT_COMMITTER_EMAIL=jane@example.com",
+			},
+		},
+		{
+			name:       "Only name in environment",
+			authorName: "Jane Doe",
+			wantEnv: []string{
+				"GIT_AUTHOR_NAME=Jane Doe",
+				"GIT_COMMITTER_NAME=Jane Doe",
+			},
+		},
+		{
+			name:    "Identity not configured",
+			wantEnv: []string{},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(constants.CommitAuthorNameEnvvar, tc.authorName)
+			t.Setenv(constants.CommitAuthorEmailEnvvar, tc.authorEmail)
+
+			gotEnv := GitIdentityEnv()
+			if !reflect.DeepEqual(gotEnv, tc.wantEnv) {
+				t.Fatalf("Unexpected Git identity environment variables. Expected: %v, Got: %v", tc.wantEnv, gotEnv)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package checksumdrift
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rodaine/table"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/verify"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/cleanup"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/failures"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/progress"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/slices"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// Run contains the business logic to execute the `checksum-drift` subcommand.
+func Run(checksumDriftOptions *types.ChecksumDriftOptions) error {
+	if checksumDriftOptions.OutputFormat != constants.TableOutputFormat && checksumDriftOptions.OutputFormat != constants.JSONOutputFormat {
+		return fmt.Errorf("invalid output format %s, must be one of %s or %s", checksumDriftOptions.OutputFormat, constants.TableOutputFormat, constants.JSONOutputFormat)
+	}
+	if checksumDriftOptions.Rebuilds < 1 {
+		return fmt.Errorf("invalid number of rebuilds %d, must be at least 1", checksumDriftOptions.Rebuilds)
+	}
+
+	// Get base repository owner environment variable if set.
+	baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+	if baseRepoOwner == "" {
+		baseRepoOwner = constants.DefaultBaseRepoOwner
+	}
+
+	// Clone the eks-anywhere-build-tooling repository.
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+	if err != nil {
+		return fmt.Errorf("cloning build-tooling repo: %v", err)
+	}
+
+	supportedReleaseBranchesFileContents, err := os.ReadFile(filepath.Join(buildToolingRepoPath, constants.SupportedReleaseBranchesFile))
+	if err != nil {
+		return fmt.Errorf("reading supported release branches file: %v", err)
+	}
+	supportedReleaseBranches := strings.Fields(string(supportedReleaseBranchesFileContents))
+	releaseBranch := checksumDriftOptions.ReleaseBranch
+	if releaseBranch == "" {
+		releaseBranch = supportedReleaseBranches[len(supportedReleaseBranches)-1]
+	} else if !slices.Contains(supportedReleaseBranches, releaseBranch) {
+		return fmt.Errorf("invalid release branch %s, must be one of %s", releaseBranch, strings.Join(supportedReleaseBranches, ", "))
+	}
+
+	projectNames := checksumDriftOptions.ProjectNames
+	if len(projectNames) == 0 {
+		projectNames, err = projects.List(buildToolingRepoPath)
+		if err != nil {
+			return fmt.Errorf("listing projects: %v", err)
+		}
+	}
+	for _, projectName := range projectNames {
+		if !projects.Exists(buildToolingRepoPath, projectName) {
+			return fmt.Errorf("invalid project name %s", projectName)
+		}
+	}
+
+	report := failures.NewReport("Project")
+	results := []types.ChecksumDriftResult{}
+	rebuiltProjects := 0
+	for i, projectName := range projectNames {
+		projectRootFilepath := projects.RootPath(buildToolingRepoPath, projectName)
+		projectReleaseBranch, checksumsFilepath := getChecksumsFile(projectRootFilepath, releaseBranch)
+		if checksumsFilepath == "" {
+			if len(checksumDriftOptions.ProjectNames) > 0 {
+				logger.Warn("Project does not have a CHECKSUMS file to compare against, skipping", "Project", projectName)
+			}
+			continue
+		}
+
+		logger.Info("Rebuilding project binaries in builder base container", "Project", projectName, "Release branch", projectReleaseBranch, "Rebuilds", checksumDriftOptions.Rebuilds, "Progress", progress.Fraction(i+1, len(projectNames)))
+		committedChecksums, rebuiltChecksums, err := rebuildChecksums(projectRootFilepath, checksumsFilepath, projectReleaseBranch, checksumDriftOptions.Rebuilds)
+		if err != nil {
+			if !checksumDriftOptions.ContinueOnError {
+				return fmt.Errorf("rebuilding project %s: %v", projectName, err)
+			}
+			report.Add(projectName, "rebuild project", err)
+			continue
+		}
+		rebuiltProjects++
+		results = append(results, compareChecksums(projectName, projectReleaseBranch, committedChecksums, rebuiltChecksums)...)
+	}
+
+	driftedProjects := []string{}
+	for _, result := range results {
+		if result.Status != constants.ReproducibleChecksumStatus && !slices.Contains(driftedProjects, result.Project) {
+			driftedProjects = append(driftedProjects, result.Project)
+		}
+	}
+
+	if checksumDriftOptions.OutputFormat == constants.JSONOutputFormat {
+		resultsJSON, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshalling checksum drift results: %v", err)
+		}
+		fmt.Println(string(resultsJSON))
+	} else {
+		logger.Info(fmt.Sprintf("Rebuilt %d projects, %d of them with binaries that did not rebuild to their committed checksums", rebuiltProjects, len(driftedProjects)))
+		if len(driftedProjects) > 0 {
+			tbl := table.New("Project", "Release Branch", "Binary", "Result", "Committed", "Rebuilt").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+				return strings.ToUpper(fmt.Sprintf(format, vals...))
+			})
+			for _, result := range results {
+				if result.Status == constants.ReproducibleChecksumStatus {
+					continue
+				}
+				releaseBranch := result.ReleaseBranch
+				if releaseBranch == "" {
+					releaseBranch = "N/A"
+				}
+				rebuilt := []string{}
+				for _, checksum := range result.RebuiltChecksums {
+					rebuilt = append(rebuilt, abbreviateChecksum(checksum))
+				}
+				tbl.AddRow(result.Project, releaseBranch, result.Binary, result.Status, abbreviateChecksum(result.CommittedChecksum), strings.Join(rebuilt, ", "))
+			}
+			tbl.Print()
+		}
+	}
+	report.Print()
+
+	if len(driftedProjects) > 0 {
+		return fmt.Errorf("binaries did not rebuild to their committed checksums for projects: %s", strings.Join(driftedProjects, ", "))
+	}
+
+	return report.Err("rebuild projects")
+}
+
+// getChecksumsFile returns the release branch a project is built for and the path of its CHECKSUMS file, which is in
+// the release branch directory for release-branched projects and in the project's directory otherwise. The path is
+// empty if the project does not have a CHECKSUMS file.
+func getChecksumsFile(projectRootFilepath, releaseBranch string) (string, string) {
+	releaseBranchChecksumsFilepath := filepath.Join(projectRootFilepath, releaseBranch, constants.ChecksumsFile)
+	if _, err := os.Stat(releaseBranchChecksumsFilepath); err == nil {
+		return releaseBranch, releaseBranchChecksumsFilepath
+	}
+
+	checksumsFilepath := filepath.Join(projectRootFilepath, constants.ChecksumsFile)
+	if _, err := os.Stat(checksumsFilepath); err == nil {
+		return "", checksumsFilepath
+	}
+
+	return "", ""
+}
+
+// rebuildChecksums rebuilds the project's binaries the given number of times and returns the committed checksums and
+// the checksums of each rebuild. The checksums are regenerated with the `checksums` Make target in the builder base
+// container, the same way the `verify` command and the periodic job generate them, after removing the build output
+// and the container's Go build cache so that every rebuild starts from scratch. The committed CHECKSUMS file is
+// restored afterwards.
+func rebuildChecksums(projectRootFilepath, checksumsFilepath, releaseBranch string, rebuilds int) (map[string]string, []map[string]string, error) {
+	committedContents, err := os.ReadFile(checksumsFilepath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading committed checksums file: %v", err)
+	}
+	restoreChecksumsFile := func() error {
+		return os.WriteFile(checksumsFilepath, committedContents, 0o644)
+	}
+	defer restoreChecksumsFile()
+	defer cleanup.Register("Restoring committed checksums file", restoreChecksumsFile)()
+
+	rebuiltChecksums := []map[string]string{}
+	for i := 0; i < rebuilds; i++ {
+		for _, target := range []string{"clean-output", "run-in-docker/clean-go-cache", "run-in-docker/checksums"} {
+			err = verify.RunMakeTarget(projectRootFilepath, target, releaseBranch)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		contents, err := os.ReadFile(checksumsFilepath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading regenerated checksums file: %v", err)
+		}
+		rebuiltChecksums = append(rebuiltChecksums, parseChecksums(string(contents)))
+	}
+
+	return parseChecksums(string(committedContents)), rebuiltChecksums, nil
+}
+
+// parseChecksums parses the contents of a CHECKSUMS file, with a `<sha256>  <binary path>` line per binary, into the
+// checksum of each binary keyed by path.
+func parseChecksums(contents string) map[string]string {
+	checksums := map[string]string{}
+	for _, line := range strings.Split(contents, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		checksums[strings.TrimPrefix(fields[1], "*")] = fields[0]
+	}
+
+	return checksums
+}
+
+// compareChecksums compares the checksums of each rebuild of the project's binaries against each other and against
+// the committed checksums, and returns a result per binary sorted by path. Binaries missing from the committed file
+// or from a rebuild are reported as drifted, with an empty checksum in their place.
+func compareChecksums(projectName, releaseBranch string, committedChecksums map[string]string, rebuiltChecksums []map[string]string) []types.ChecksumDriftResult {
+	binaries := []string{}
+	for binary := range committedChecksums {
+		binaries = append(binaries, binary)
+	}
+	for _, checksums := range rebuiltChecksums {
+		for binary := range checksums {
+			if _, ok := committedChecksums[binary]; !ok && !slices.Contains(binaries, binary) {
+				binaries = append(binaries, binary)
+			}
+		}
+	}
+	sort.Strings(binaries)
+
+	results := []types.ChecksumDriftResult{}
+	for _, binary := range binaries {
+		result := types.ChecksumDriftResult{
+			Project:           projectName,
+			ReleaseBranch:     releaseBranch,
+			Binary:            binary,
+			Status:            constants.ReproducibleChecksumStatus,
+			CommittedChecksum: committedChecksums[binary],
+			RebuiltChecksums:  []string{},
+		}
+		for _, checksums := range rebuiltChecksums {
+			result.RebuiltChecksums = append(result.RebuiltChecksums, checksums[binary])
+		}
+		for _, checksum := range result.RebuiltChecksums {
+			if checksum != result.RebuiltChecksums[0] {
+				result.Status = constants.NondeterministicChecksumStatus
+				break
+			}
+		}
+		if result.Status == constants.ReproducibleChecksumStatus && result.RebuiltChecksums[0] != result.CommittedChecksum {
+			result.Status = constants.DriftedChecksumStatus
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// abbreviateChecksum shortens a checksum for display in a table, or returns "N/A" for a missing checksum.
+func abbreviateChecksum(checksum string) string {
+	if checksum == "" {
+		return "N/A"
+	}
+	if len(checksum) > 12 {
+		return checksum[:12]
+	}
+
+	return checksum
+}
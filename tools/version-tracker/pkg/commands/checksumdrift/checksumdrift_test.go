@@ -0,0 +1,28 @@
+package checksumdrift
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+)
+
+func TestCompareChecksums(t *testing.T) {
+	committedChecksums := parseChecksums("aaa  _output/bin/ctr/linux-amd64/ctr\nbbb  _output/bin/ctr/linux-amd64/shim\nccc  _output/bin/ctr/linux-arm64/ctr\n")
+	rebuiltChecksums := []map[string]string{
+		parseChecksums("aaa  _output/bin/ctr/linux-amd64/ctr\nbbb  _output/bin/ctr/linux-amd64/shim\nddd  _output/bin/ctr/linux-arm64/ctr\neee  _output/bin/ctr/linux-arm64/new\n"),
+		parseChecksums("aaa  _output/bin/ctr/linux-amd64/ctr\nfff  _output/bin/ctr/linux-amd64/shim\nddd  _output/bin/ctr/linux-arm64/ctr\neee  _output/bin/ctr/linux-arm64/new\n"),
+	}
+
+	want := []types.ChecksumDriftResult{
+		{Project: "containerd/containerd", Binary: "_output/bin/ctr/linux-amd64/ctr", Status: constants.ReproducibleChecksumStatus, CommittedChecksum: "aaa", RebuiltChecksums: []string{"aaa", "aaa"}},
+		{Project: "containerd/containerd", Binary: "_output/bin/ctr/linux-amd64/shim", Status: constants.NondeterministicChecksumStatus, CommittedChecksum: "bbb", RebuiltChecksums: []string{"bbb", "fff"}},
+		{Project: "containerd/containerd", Binary: "_output/bin/ctr/linux-arm64/ctr", Status: constants.DriftedChecksumStatus, CommittedChecksum: "ccc", RebuiltChecksums: []string{"ddd", "ddd"}},
+		{Project: "containerd/containerd", Binary: "_output/bin/ctr/linux-arm64/new", Status: constants.DriftedChecksumStatus, RebuiltChecksums: []string{"eee", "eee"}},
+	}
+	got := compareChecksums("containerd/containerd", "", committedChecksums, rebuiltChecksums)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Unexpected checksum drift results. Want: %+v, got: %+v", want, got)
+	}
+}
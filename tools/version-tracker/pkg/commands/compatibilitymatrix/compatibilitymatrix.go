@@ -0,0 +1,165 @@
+package compatibilitymatrix
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/rodaine/table"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+var commitHashRegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// Run contains the business logic to execute the `compatibility-matrix` subcommand.
+func Run(compatibilityMatrixOptions *types.CompatibilityMatrixOptions) error {
+	projectName := compatibilityMatrixOptions.ProjectName
+	outputFormat := compatibilityMatrixOptions.OutputFormat
+
+	if outputFormat != constants.TableOutputFormat && outputFormat != constants.JSONOutputFormat && outputFormat != constants.MarkdownOutputFormat {
+		return fmt.Errorf("invalid output format %s, must be one of %s, %s or %s", outputFormat, constants.TableOutputFormat, constants.JSONOutputFormat, constants.MarkdownOutputFormat)
+	}
+
+	// Get base repository owner environment variable if set.
+	baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+	if baseRepoOwner == "" {
+		baseRepoOwner = constants.DefaultBaseRepoOwner
+	}
+
+	// Clone the eks-anywhere-build-tooling repository.
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+	if err != nil {
+		return fmt.Errorf("cloning build-tooling repo: %v", err)
+	}
+
+	compatibilityMatrix, err := getCompatibilityMatrix(buildToolingRepoPath, projectName)
+	if err != nil {
+		return fmt.Errorf("generating compatibility matrix: %v", err)
+	}
+	if projectName != "" && len(compatibilityMatrix.Projects) == 0 {
+		return fmt.Errorf("project %s not found in upstream projects tracker file", projectName)
+	}
+
+	switch outputFormat {
+	case constants.JSONOutputFormat:
+		compatibilityMatrixJSON, err := json.MarshalIndent(compatibilityMatrix, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshalling compatibility matrix: %v", err)
+		}
+		fmt.Println(string(compatibilityMatrixJSON))
+	case constants.MarkdownOutputFormat:
+		fmt.Print(renderMarkdown(compatibilityMatrix))
+	default:
+		headers := []interface{}{"Project"}
+		for _, releaseBranch := range compatibilityMatrix.ReleaseBranches {
+			headers = append(headers, releaseBranch)
+		}
+		tbl := table.New(headers...).WithHeaderFormatter(func(format string, vals ...interface{}) string {
+			return strings.ToUpper(fmt.Sprintf(format, vals...))
+		})
+		for _, project := range compatibilityMatrix.Projects {
+			row := []interface{}{project.Project}
+			for _, releaseBranch := range compatibilityMatrix.ReleaseBranches {
+				row = append(row, abbreviateRevision(project.Versions[releaseBranch]))
+			}
+			tbl.AddRow(row...)
+		}
+		tbl.Print()
+	}
+
+	return nil
+}
+
+// getCompatibilityMatrix builds the compatibility matrix for all projects, or a single project if the project name
+// is non-empty, from the upstream projects tracker file. Release-branched projects list their revisions in the
+// order of the supported release branches, while other projects ship the same revision in every release branch.
+func getCompatibilityMatrix(buildToolingRepoPath, projectName string) (*types.CompatibilityMatrix, error) {
+	supportedReleaseBranchesFileContents, err := os.ReadFile(filepath.Join(buildToolingRepoPath, constants.SupportedReleaseBranchesFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading supported release branches file: %v", err)
+	}
+	releaseBranches := strings.Fields(string(supportedReleaseBranchesFileContents))
+
+	contents, err := os.ReadFile(filepath.Join(buildToolingRepoPath, constants.UpstreamProjectsTrackerFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading upstream projects tracker file: %v", err)
+	}
+	var projectsList types.ProjectsList
+	err = yaml.Unmarshal(contents, &projectsList)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling upstream projects tracker file: %v", err)
+	}
+
+	compatibilityMatrix := &types.CompatibilityMatrix{
+		ReleaseBranches: releaseBranches,
+		Projects:        []types.CompatibilityMatrixEntry{},
+	}
+	for _, project := range projectsList.Projects {
+		for _, repo := range project.Repos {
+			name := fmt.Sprintf("%s/%s", project.Org, repo.Name)
+			if projectName != "" && name != projectName {
+				continue
+			}
+			if len(repo.Versions) > 1 && len(repo.Versions) != len(releaseBranches) {
+				return nil, fmt.Errorf("project %s has %d versions in upstream projects tracker file but there are %d supported release branches", name, len(repo.Versions), len(releaseBranches))
+			}
+
+			versions := map[string]string{}
+			for i, releaseBranch := range releaseBranches {
+				version := repo.Versions[0]
+				if len(repo.Versions) > 1 {
+					version = repo.Versions[i]
+				}
+				revision := version.Tag
+				if revision == "" {
+					revision = version.Commit
+				}
+				versions[releaseBranch] = revision
+			}
+			compatibilityMatrix.Projects = append(compatibilityMatrix.Projects, types.CompatibilityMatrixEntry{
+				Project:  name,
+				Versions: versions,
+			})
+		}
+	}
+
+	return compatibilityMatrix, nil
+}
+
+// renderMarkdown renders the compatibility matrix as a Markdown table, with a row per project and a column per
+// release branch.
+func renderMarkdown(compatibilityMatrix *types.CompatibilityMatrix) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("| Project | %s |\n", strings.Join(compatibilityMatrix.ReleaseBranches, " | ")))
+	b.WriteString(fmt.Sprintf("|---|%s\n", strings.Repeat("---|", len(compatibilityMatrix.ReleaseBranches))))
+	for _, project := range compatibilityMatrix.Projects {
+		versions := []string{}
+		for _, releaseBranch := range compatibilityMatrix.ReleaseBranches {
+			versions = append(versions, fmt.Sprintf("`%s`", abbreviateRevision(project.Versions[releaseBranch])))
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s |\n", project.Project, strings.Join(versions, " | ")))
+	}
+
+	return b.String()
+}
+
+// abbreviateRevision shortens commit hashes to their abbreviated form for display, leaving tags unchanged.
+func abbreviateRevision(revision string) string {
+	if commitHashRegex.MatchString(revision) {
+		return revision[:7]
+	}
+
+	return revision
+}
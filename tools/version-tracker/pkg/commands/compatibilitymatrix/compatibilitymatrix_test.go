@@ -0,0 +1,73 @@
+package compatibilitymatrix
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+)
+
+const upstreamProjectsTrackerFileContents = `projects:
+- org: kubernetes-sigs
+  repos:
+  - name: cluster-api
+    versions:
+    - tag: v1.9.4
+  - name: etcdadm
+    versions:
+    - commit: 0123456789abcdef0123456789abcdef01234567
+- org: kubernetes
+  repos:
+  - name: cloud-provider-vsphere
+    versions:
+    - tag: v1.30.2
+    - tag: v1.31.1
+    - tag: v1.32.0
+`
+
+const wantMarkdown = "| Project | 1-30 | 1-31 | 1-32 |\n" +
+	"|---|---|---|---|\n" +
+	"| kubernetes-sigs/cluster-api | `v1.9.4` | `v1.9.4` | `v1.9.4` |\n" +
+	"| kubernetes-sigs/etcdadm | `0123456` | `0123456` | `0123456` |\n" +
+	"| kubernetes/cloud-provider-vsphere | `v1.30.2` | `v1.31.1` | `v1.32.0` |\n"
+
+func TestCompatibilityMatrixIsReproducible(t *testing.T) {
+	buildToolingRepoPath := t.TempDir()
+	supportedReleaseBranchesFilepath := filepath.Join(buildToolingRepoPath, constants.SupportedReleaseBranchesFile)
+	if err := os.MkdirAll(filepath.Dir(supportedReleaseBranchesFilepath), 0o755); err != nil {
+		t.Fatalf("Unexpected error creating release directory. Got: %v", err)
+	}
+	if err := os.WriteFile(supportedReleaseBranchesFilepath, []byte("1-30\n1-31\n1-32\n"), 0o644); err != nil {
+		t.Fatalf("Unexpected error writing supported release branches file. Got: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(buildToolingRepoPath, constants.UpstreamProjectsTrackerFile), []byte(upstreamProjectsTrackerFileContents), 0o644); err != nil {
+		t.Fatalf("Unexpected error writing upstream projects tracker file. Got: %v", err)
+	}
+
+	// The versions of each project are kept in a map, whose iteration order is randomized, so the matrix is
+	// generated several times to catch any output that depends on it.
+	var firstJSON string
+	for i := 0; i < 20; i++ {
+		compatibilityMatrix, err := getCompatibilityMatrix(buildToolingRepoPath, "")
+		if err != nil {
+			t.Fatalf("Unexpected error generating compatibility matrix. Got: %v", err)
+		}
+
+		gotMarkdown := renderMarkdown(compatibilityMatrix)
+		if gotMarkdown != wantMarkdown {
+			t.Fatalf("Unexpected Markdown output. Expected:\n%s\nGot:\n%s", wantMarkdown, gotMarkdown)
+		}
+
+		compatibilityMatrixJSON, err := json.MarshalIndent(compatibilityMatrix, "", "  ")
+		if err != nil {
+			t.Fatalf("Unexpected error marshalling compatibility matrix. Got: %v", err)
+		}
+		if i == 0 {
+			firstJSON = string(compatibilityMatrixJSON)
+		} else if string(compatibilityMatrixJSON) != firstJSON {
+			t.Fatalf("JSON output differs between runs. First:\n%s\nGot:\n%s", firstJSON, compatibilityMatrixJSON)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package diffupstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rodaine/table"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/upstream"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/cleanup"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/makefile"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// fileStatuses maps the status letters reported by git diff --name-status to the statuses displayed for files.
+var fileStatuses = map[string]string{
+	"A": "Added",
+	"D": "Deleted",
+	"M": "Modified",
+	"T": "Type changed",
+}
+
+// Run contains the business logic to execute the `diff-upstream` subcommand.
+func Run(diffUpstreamOptions *types.DiffUpstreamOptions) error {
+	projectName := diffUpstreamOptions.ProjectName
+	releaseBranch := diffUpstreamOptions.ReleaseBranch
+
+	if diffUpstreamOptions.OutputFormat != constants.TableOutputFormat && diffUpstreamOptions.OutputFormat != constants.JSONOutputFormat {
+		return fmt.Errorf("invalid output format %s, must be one of %s or %s", diffUpstreamOptions.OutputFormat, constants.TableOutputFormat, constants.JSONOutputFormat)
+	}
+
+	// Get base repository owner environment variable if set.
+	baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+	if baseRepoOwner == "" {
+		baseRepoOwner = constants.DefaultBaseRepoOwner
+	}
+
+	// Clone the eks-anywhere-build-tooling repository.
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+	if err != nil {
+		return fmt.Errorf("cloning build-tooling repo: %v", err)
+	}
+
+	// Validate if the project name provided exists in the repository.
+	projectRootFilepath := projects.RootPath(buildToolingRepoPath, projectName)
+	if !projects.Exists(buildToolingRepoPath, projectName) {
+		return fmt.Errorf("invalid project name %s", projectName)
+	}
+
+	cloneURL, err := makefile.GetVariableValue(projectRootFilepath, "CLONE_URL", releaseBranch)
+	if err != nil {
+		return fmt.Errorf("getting project clone URL: %v", err)
+	}
+	gitTag, err := makefile.GetVariableValue(projectRootFilepath, "GIT_TAG", releaseBranch)
+	if err != nil {
+		return fmt.Errorf("getting project Git tag: %v", err)
+	}
+	patchesDirectory, err := makefile.GetVariableValue(projectRootFilepath, "PATCHES_DIR", releaseBranch)
+	if err != nil {
+		return fmt.Errorf("getting project patches directory: %v", err)
+	}
+
+	upstreamDiff := types.UpstreamDiff{
+		ProjectName:   projectName,
+		ReleaseBranch: releaseBranch,
+		GitTag:        gitTag,
+		Files:         []types.UpstreamDiffFile{},
+	}
+
+	patches := []string{}
+	if patchesDirectory != "" {
+		patches, err = filepath.Glob(filepath.Join(patchesDirectory, constants.PatchFileGlob))
+		if err != nil {
+			return fmt.Errorf("listing project patches: %v", err)
+		}
+	}
+	upstreamDiff.Patches = len(patches)
+
+	if len(patches) > 0 {
+		err = diffPatchedWorktree(cloneURL, gitTag, patches, diffUpstreamOptions.StatOnly, &upstreamDiff)
+		if err != nil {
+			return err
+		}
+	}
+
+	if diffUpstreamOptions.OutputFormat == constants.JSONOutputFormat {
+		upstreamDiffJSON, err := json.MarshalIndent(upstreamDiff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshalling upstream diff: %v", err)
+		}
+		fmt.Println(string(upstreamDiffJSON))
+		return nil
+	}
+
+	if len(patches) == 0 {
+		logger.Info("Project has no patches, so it does not differ from upstream", "Project", projectName, "Git tag", gitTag)
+		return nil
+	}
+
+	if !diffUpstreamOptions.StatOnly && upstreamDiff.Diff != "" {
+		fmt.Println(upstreamDiff.Diff)
+		fmt.Println()
+	}
+
+	tbl := table.New("File", "Status", "Additions", "Deletions").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	})
+	for _, file := range upstreamDiff.Files {
+		additions, deletions := strconv.Itoa(file.Additions), strconv.Itoa(file.Deletions)
+		if file.Binary {
+			additions, deletions = "Binary", "Binary"
+		}
+		tbl.AddRow(file.Path, file.Status, additions, deletions)
+	}
+	tbl.Print()
+
+	fmt.Printf("\n%d patches change %d files relative to %s: %d additions, %d deletions\n", upstreamDiff.Patches, len(upstreamDiff.Files), gitTag, upstreamDiff.Additions, upstreamDiff.Deletions)
+
+	return nil
+}
+
+// diffPatchedWorktree applies the patch series to the Git tag in a worktree of the upstream mirror and records
+// the changes between the Git tag and the patched worktree in the upstream diff.
+func diffPatchedWorktree(cloneURL, gitTag string, patches []string, statOnly bool, upstreamDiff *types.UpstreamDiff) error {
+	upstreamMirrorPath, err := upstream.SyncMirror(cloneURL, gitTag)
+	if err != nil {
+		return fmt.Errorf("syncing upstream repository mirror: %v", err)
+	}
+	defer func() {
+		if err := upstream.PruneWorktrees(upstreamMirrorPath); err != nil {
+			logger.Warn("Unable to prune upstream mirror worktrees", "Error", err)
+		}
+	}()
+
+	// The upstream worktree can be large, so it is created in the workspace directory rather than the
+	// system temporary directory.
+	workspaceDir, err := workspace.Dir()
+	if err != nil {
+		return fmt.Errorf("getting workspace directory: %v", err)
+	}
+	diffDirectory, err := os.MkdirTemp(workspaceDir, "diff-upstream")
+	if err != nil {
+		return fmt.Errorf("creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(diffDirectory)
+	defer cleanup.Register("Removing temporary diff directory", func() error {
+		return os.RemoveAll(diffDirectory)
+	})()
+
+	worktreePath := filepath.Join(diffDirectory, "worktree")
+	err = upstream.AddWorktree(upstreamMirrorPath, worktreePath, gitTag)
+	if err != nil {
+		return fmt.Errorf("checking out Git tag %s: %v", gitTag, err)
+	}
+
+	logger.Info("Applying patches", "Git tag", gitTag, "Patches", len(patches))
+	args := []string{"-C", worktreePath, "-c", fmt.Sprintf("user.name=%s", constants.PatchCommitterName), "-c", fmt.Sprintf("user.email=%s", constants.PatchCommitterEmail), "am", "--committer-date-is-author-date"}
+	_, err = command.ExecCommand(exec.Command("git", append(args, patches...)...))
+	if err != nil {
+		return fmt.Errorf("applying patches to Git tag %s: %v", gitTag, err)
+	}
+
+	diffArgs := []string{"-C", worktreePath, "-c", "core.quotePath=false", "diff", "--no-renames", "--no-color"}
+	nameStatusOutput, err := command.ExecCommandOutput(exec.Command("git", append(diffArgs, "--name-status", gitTag, "HEAD")...))
+	if err != nil {
+		return fmt.Errorf("listing files changed by patches: %v", err)
+	}
+	numstatOutput, err := command.ExecCommandOutput(exec.Command("git", append(diffArgs, "--numstat", gitTag, "HEAD")...))
+	if err != nil {
+		return fmt.Errorf("counting lines changed by patches: %v", err)
+	}
+	upstreamDiff.Files, err = parseDiffStats(nameStatusOutput, numstatOutput)
+	if err != nil {
+		return fmt.Errorf("parsing diff stats: %v", err)
+	}
+	for _, file := range upstreamDiff.Files {
+		upstreamDiff.Additions += file.Additions
+		upstreamDiff.Deletions += file.Deletions
+	}
+
+	if !statOnly {
+		upstreamDiff.Diff, err = command.ExecCommandOutput(exec.Command("git", append(diffArgs, gitTag, "HEAD")...))
+		if err != nil {
+			return fmt.Errorf("diffing patched repository against Git tag %s: %v", gitTag, err)
+		}
+	}
+
+	return nil
+}
+
+// parseDiffStats combines the output of git diff --name-status and git diff --numstat, run without rename
+// detection, into the list of changed files.
+func parseDiffStats(nameStatusOutput, numstatOutput string) ([]types.UpstreamDiffFile, error) {
+	files := []types.UpstreamDiffFile{}
+	fileIndices := map[string]int{}
+	for _, line := range strings.Split(nameStatusOutput, "\n") {
+		if line == "" {
+			continue
+		}
+		statusLetter, path, found := strings.Cut(line, "\t")
+		if !found {
+			return nil, fmt.Errorf("unexpected name-status line %q", line)
+		}
+		status, ok := fileStatuses[statusLetter]
+		if !ok {
+			status = statusLetter
+		}
+		fileIndices[path] = len(files)
+		files = append(files, types.UpstreamDiffFile{Path: path, Status: status})
+	}
+
+	for _, line := range strings.Split(numstatOutput, "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected numstat line %q", line)
+		}
+		i, ok := fileIndices[fields[2]]
+		if !ok {
+			return nil, fmt.Errorf("numstat line %q does not match a changed file", line)
+		}
+
+		// Binary files are reported with dashes instead of line counts.
+		if fields[0] == "-" && fields[1] == "-" {
+			files[i].Binary = true
+			continue
+		}
+		additions, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("parsing additions in numstat line %q: %v", line, err)
+		}
+		deletions, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("parsing deletions in numstat line %q: %v", line, err)
+		}
+		files[i].Additions, files[i].Deletions = additions, deletions
+	}
+
+	return files, nil
+}
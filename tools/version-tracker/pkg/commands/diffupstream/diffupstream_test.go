@@ -0,0 +1,32 @@
+package diffupstream
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+)
+
+func TestParseDiffStats(t *testing.T) {
+	nameStatusOutput := "M\tcmd/main.go\nA\tpkg/new.go\nD\tdocs/old.md\nA\tlogo.png"
+	numstatOutput := "3\t1\tcmd/main.go\n20\t0\tpkg/new.go\n0\t7\tdocs/old.md\n-\t-\tlogo.png"
+
+	want := []types.UpstreamDiffFile{
+		{Path: "cmd/main.go", Status: "Modified", Additions: 3, Deletions: 1},
+		{Path: "pkg/new.go", Status: "Added", Additions: 20},
+		{Path: "docs/old.md", Status: "Deleted", Deletions: 7},
+		{Path: "logo.png", Status: "Added", Binary: true},
+	}
+	got, err := parseDiffStats(nameStatusOutput, numstatOutput)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing diff stats. Got: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Unexpected diff stats. Want: %+v, got: %+v", want, got)
+	}
+
+	_, err = parseDiffStats(nameStatusOutput, "3\t1\tcmd/other.go")
+	if err == nil {
+		t.Fatalf("Expected an error for numstat lines of unknown files")
+	}
+}
@@ -1,20 +1,24 @@
 package display
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
-	gogithub "github.com/google/go-github/v53/github"
 	"github.com/rodaine/table"
 	"gopkg.in/yaml.v3"
 
-	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/github"
-	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/scanstate"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/failures"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
 )
 
 // Run contains the business logic to execute the `display` subcommand.
@@ -24,11 +28,11 @@ func Run(displayOptions *types.DisplayOptions) error {
 	if !ok {
 		return fmt.Errorf("GITHUB_TOKEN environment variable is not set")
 	}
-	client := gogithub.NewTokenClient(context.Background(), githubToken)
+	client, apiBudget := github.NewClientWithBudget(githubToken, displayOptions.MaxAPICalls)
 
-	cwd, err := os.Getwd()
+	workspaceDir, err := workspace.Dir()
 	if err != nil {
-		return fmt.Errorf("retrieving current working directory: %v", err)
+		return fmt.Errorf("getting workspace directory: %v", err)
 	}
 
 	// Get base repository owner environment variable if set.
@@ -38,7 +42,10 @@ func Run(displayOptions *types.DisplayOptions) error {
 	}
 
 	// Clone the eks-anywhere-build-tooling repository.
-	buildToolingRepoPath := filepath.Join(cwd, constants.BuildToolingRepoName)
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
 	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
 	if err != nil {
 		return fmt.Errorf("cloning build-tooling repo: %v", err)
@@ -46,7 +53,7 @@ func Run(displayOptions *types.DisplayOptions) error {
 
 	if displayOptions.ProjectName != "" {
 		// Validate if the project name provided exists in the repository.
-		if _, err := os.Stat(filepath.Join(buildToolingRepoPath, "projects", displayOptions.ProjectName)); os.IsNotExist(err) {
+		if !projects.Exists(buildToolingRepoPath, displayOptions.ProjectName) {
 			return fmt.Errorf("invalid project name %s", displayOptions.ProjectName)
 		}
 	}
@@ -65,7 +72,25 @@ func Run(displayOptions *types.DisplayOptions) error {
 		return fmt.Errorf("unmarshalling upstream projects tracker file: %v", err)
 	}
 
+	// Scans of all projects persist their progress, so that a scan interrupted by the GitHub API budget or rate
+	// limit resumes from where it left off when the command is rerun.
+	fullScan := displayOptions.ProjectName == ""
+	scanStateFilepath := filepath.Join(workspaceDir, constants.DisplayScanStateFile)
+	scanState := &types.ScanState{LatestRevisions: map[string]string{}}
+	if fullScan {
+		scanState, err = scanstate.Load(scanStateFilepath)
+		if err != nil {
+			return fmt.Errorf("loading scan state: %v", err)
+		}
+		if len(scanState.LatestRevisions) > 0 {
+			logger.Info(fmt.Sprintf("Resuming scan with %d projects already scanned", len(scanState.LatestRevisions)))
+		}
+	}
+
 	var projectVersionInfoList []types.ProjectVersionInfo
+	var totalProjects int
+	report := failures.NewReport("Project")
+	scanInterrupted := false
 	for _, project := range projectsList.Projects {
 		org := project.Org
 		for _, repo := range project.Repos {
@@ -82,10 +107,37 @@ func Run(displayOptions *types.DisplayOptions) error {
 				continue
 			}
 
-			// Get latest revision for the project from GitHub.
-			latestRevision, _, err := github.GetLatestRevision(client, org, repoName, currentRevision)
-			if err != nil {
-				return fmt.Errorf("getting latest revision from GitHub: %v", err)
+			totalProjects++
+			if scanInterrupted {
+				continue
+			}
+
+			// Get latest revision for the project from GitHub, unless it was already scanned by an interrupted scan.
+			latestRevision, ok := scanState.LatestRevisions[fullRepoName]
+			if !ok {
+				if apiBudget.Exhausted() {
+					scanInterrupted = true
+					continue
+				}
+				latestRevision, _, err = github.GetLatestRevision(client, org, repoName, currentRevision)
+				if err != nil {
+					if apiBudget.Exhausted() {
+						scanInterrupted = true
+						continue
+					}
+					if !displayOptions.ContinueOnError {
+						return fmt.Errorf("getting latest revision from GitHub: %v", err)
+					}
+					report.Add(fullRepoName, "get latest revision", err)
+					continue
+				}
+				if fullScan {
+					scanState.LatestRevisions[fullRepoName] = latestRevision
+					err = scanstate.Write(scanStateFilepath, scanState)
+					if err != nil {
+						return fmt.Errorf("persisting scan state: %v", err)
+					}
+				}
 			}
 
 			// Check if we should print only the latest version of the project.
@@ -110,6 +162,24 @@ func Run(displayOptions *types.DisplayOptions) error {
 
 	// Print the table contents to standard output.
 	tbl.Print()
+	report.Print()
+
+	if scanInterrupted {
+		message := fmt.Sprintf("GitHub API budget exhausted after %d API calls, scanned %d of %d projects", apiBudget.Calls(), len(projectVersionInfoList), totalProjects)
+		if reset := apiBudget.RateLimitReset(); !reset.IsZero() && time.Now().Before(reset) {
+			message = fmt.Sprintf("%s. The rate limit resets at %s", message, reset.Format(time.RFC3339))
+		}
+		logger.Warn(fmt.Sprintf("%s. Rerun the command to resume the scan", message))
+		return report.Err("get latest versions of projects")
+	}
+
+	// Keep the scan state if some projects failed, so that rerunning the command only retries those projects.
+	if fullScan && report.Len() == 0 {
+		err = scanstate.Remove(scanStateFilepath)
+		if err != nil {
+			return fmt.Errorf("removing scan state: %v", err)
+		}
+	}
 
-	return nil
+	return report.Err("get latest versions of projects")
 }
@@ -0,0 +1,107 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rodaine/table"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	upgradehistory "github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/history"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// Run contains the business logic to execute the `history` subcommand.
+func Run(historyOptions *types.HistoryOptions) error {
+	projectName := historyOptions.ProjectName
+
+	if historyOptions.OutputFormat != constants.TableOutputFormat && historyOptions.OutputFormat != constants.JSONOutputFormat {
+		return fmt.Errorf("invalid output format %s, must be one of %s or %s", historyOptions.OutputFormat, constants.TableOutputFormat, constants.JSONOutputFormat)
+	}
+
+	// Get base repository owner environment variable if set.
+	baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+	if baseRepoOwner == "" {
+		baseRepoOwner = constants.DefaultBaseRepoOwner
+	}
+
+	// Clone the eks-anywhere-build-tooling repository.
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+	if err != nil {
+		return fmt.Errorf("cloning build-tooling repo: %v", err)
+	}
+
+	upgradeHistory, err := upgradehistory.Load(filepath.Join(buildToolingRepoPath, constants.UpgradeHistoryDirectory))
+	if err != nil {
+		return fmt.Errorf("loading upgrade history: %v", err)
+	}
+
+	upgrades := []types.UpgradeHistoryEntry{}
+	for _, upgrade := range upgradeHistory.Upgrades {
+		if projectName == "" || upgrade.Project == projectName {
+			upgrades = append(upgrades, upgrade)
+		}
+	}
+
+	if historyOptions.OutputFormat == constants.JSONOutputFormat {
+		upgradesJSON, err := json.MarshalIndent(upgrades, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshalling upgrade history: %v", err)
+		}
+		fmt.Println(string(upgradesJSON))
+		return nil
+	}
+
+	if len(upgrades) == 0 {
+		logger.Info("No upgrades recorded in upgrade history")
+		return nil
+	}
+
+	tbl := table.New("Project", "Release Branch", "From", "To", "Date", "Pull Request").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	})
+	for _, upgrade := range upgrades {
+		releaseBranch := upgrade.ReleaseBranch
+		if releaseBranch == "" {
+			releaseBranch = "N/A"
+		}
+		tbl.AddRow(upgrade.Project, releaseBranch, upgrade.PreviousVersion, upgrade.Version, upgrade.Date, upgrade.PullRequest)
+	}
+	tbl.Print()
+
+	if projectName != "" && len(upgrades) > 1 {
+		cadence, err := getUpgradeCadence(upgrades)
+		if err != nil {
+			return fmt.Errorf("computing upgrade cadence: %v", err)
+		}
+		logger.Info(fmt.Sprintf("Project was upgraded %d times, on average every %.1f days", len(upgrades), cadence.Hours()/24))
+	}
+
+	return nil
+}
+
+// getUpgradeCadence returns the average time between consecutive upgrades in the given upgrade history entries,
+// which are recorded in chronological order.
+func getUpgradeCadence(upgrades []types.UpgradeHistoryEntry) (time.Duration, error) {
+	firstUpgradeTime, err := time.Parse(time.RFC3339, upgrades[0].Date)
+	if err != nil {
+		return 0, fmt.Errorf("parsing upgrade date %s: %v", upgrades[0].Date, err)
+	}
+	lastUpgradeTime, err := time.Parse(time.RFC3339, upgrades[len(upgrades)-1].Date)
+	if err != nil {
+		return 0, fmt.Errorf("parsing upgrade date %s: %v", upgrades[len(upgrades)-1].Date, err)
+	}
+
+	return lastUpgradeTime.Sub(firstUpgradeTime) / time.Duration(len(upgrades)-1), nil
+}
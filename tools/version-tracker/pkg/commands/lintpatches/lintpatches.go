@@ -0,0 +1,139 @@
+package lintpatches
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rodaine/table"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/patch"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/slices"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// Run contains the business logic to execute the `lint-patches` subcommand.
+func Run(lintPatchesOptions *types.LintPatchesOptions) error {
+	if lintPatchesOptions.OutputFormat != constants.TableOutputFormat && lintPatchesOptions.OutputFormat != constants.JSONOutputFormat {
+		return fmt.Errorf("invalid output format %s, must be one of %s or %s", lintPatchesOptions.OutputFormat, constants.TableOutputFormat, constants.JSONOutputFormat)
+	}
+
+	patchFiles := lintPatchesOptions.PatchFiles
+	baseDirectory := ""
+	if len(patchFiles) == 0 {
+		// Get base repository owner environment variable if set.
+		baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+		if baseRepoOwner == "" {
+			baseRepoOwner = constants.DefaultBaseRepoOwner
+		}
+
+		// Clone the eks-anywhere-build-tooling repository.
+		buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+		if err != nil {
+			return fmt.Errorf("getting build-tooling repository path: %v", err)
+		}
+		_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+		if err != nil {
+			return fmt.Errorf("cloning build-tooling repo: %v", err)
+		}
+
+		patchFiles, err = getProjectPatchFiles(buildToolingRepoPath, lintPatchesOptions.ProjectNames)
+		if err != nil {
+			return err
+		}
+		baseDirectory = buildToolingRepoPath
+	}
+
+	findings := []types.PatchLintFinding{}
+	failedPatches := []string{}
+	for _, patchFile := range patchFiles {
+		contents, err := os.ReadFile(patchFile)
+		if err != nil {
+			return fmt.Errorf("reading patch file %s: %v", patchFile, err)
+		}
+
+		patchPath := patchFile
+		if baseDirectory != "" {
+			patchPath, err = filepath.Rel(baseDirectory, patchFile)
+			if err != nil {
+				return fmt.Errorf("getting relative path of patch file %s: %v", patchFile, err)
+			}
+		}
+		for _, finding := range patch.Lint(string(contents)) {
+			findings = append(findings, types.PatchLintFinding{
+				Patch:   patchPath,
+				Line:    finding.Line,
+				Check:   finding.Check,
+				Message: finding.Message,
+			})
+			if !slices.Contains(failedPatches, patchPath) {
+				failedPatches = append(failedPatches, patchPath)
+			}
+		}
+	}
+
+	if lintPatchesOptions.OutputFormat == constants.JSONOutputFormat {
+		findingsJSON, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshalling patch lint findings: %v", err)
+		}
+		fmt.Println(string(findingsJSON))
+	} else {
+		logger.Info(fmt.Sprintf("Linted %d patches, %d of them with problems", len(patchFiles), len(failedPatches)))
+		if len(findings) > 0 {
+			tbl := table.New("Patch", "Line", "Check", "Problem").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+				return strings.ToUpper(fmt.Sprintf(format, vals...))
+			})
+			for _, finding := range findings {
+				tbl.AddRow(finding.Patch, finding.Line, finding.Check, finding.Message)
+			}
+			tbl.Print()
+		}
+	}
+
+	if len(failedPatches) > 0 {
+		return fmt.Errorf("found problems in patches: %s", strings.Join(failedPatches, ", "))
+	}
+
+	return nil
+}
+
+// getProjectPatchFiles returns the patch files of the given projects, or of all projects if none are given, from
+// the project's patches directory and the patches directories of its release branches.
+func getProjectPatchFiles(buildToolingRepoPath string, projectNames []string) ([]string, error) {
+	var err error
+	if len(projectNames) == 0 {
+		projectNames, err = projects.List(buildToolingRepoPath)
+		if err != nil {
+			return nil, fmt.Errorf("listing projects: %v", err)
+		}
+	}
+
+	patchFiles := []string{}
+	for _, projectName := range projectNames {
+		if !projects.Exists(buildToolingRepoPath, projectName) {
+			return nil, fmt.Errorf("invalid project name %s", projectName)
+		}
+
+		patchesDirectories, err := projects.PatchesDirectories(buildToolingRepoPath, projectName)
+		if err != nil {
+			return nil, fmt.Errorf("listing patches directories of project %s: %v", projectName, err)
+		}
+		for _, patchesDirectory := range patchesDirectories {
+			projectPatchFiles, err := filepath.Glob(filepath.Join(patchesDirectory, constants.PatchFileGlob))
+			if err != nil {
+				return nil, fmt.Errorf("listing patches of project %s: %v", projectName, err)
+			}
+			patchFiles = append(patchFiles, projectPatchFiles...)
+		}
+	}
+
+	return patchFiles, nil
+}
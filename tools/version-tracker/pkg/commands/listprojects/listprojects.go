@@ -9,18 +9,14 @@ import (
 	"github.com/rodaine/table"
 	"gopkg.in/yaml.v3"
 
-	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
-	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
 )
 
 // Run contains the business logic to execute the `list-projects“ subcommand.
 func Run() error {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("retrieving current working directory: %v", err)
-	}
-
 	// Get base repository owner environment variable if set.
 	baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
 	if baseRepoOwner == "" {
@@ -28,7 +24,10 @@ func Run() error {
 	}
 
 	// Clone the eks-anywhere-build-tooling repository.
-	buildToolingRepoPath := filepath.Join(cwd, constants.BuildToolingRepoName)
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
 	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
 	if err != nil {
 		return fmt.Errorf("cloning build-tooling repo: %v", err)
@@ -0,0 +1,168 @@
+package patchedfiles
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rodaine/table"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// Run contains the business logic to execute the `patched-files` subcommand.
+func Run(patchedFilesOptions *types.PatchedFilesOptions) error {
+	filePattern, err := regexp.Compile(patchedFilesOptions.FilePattern)
+	if err != nil {
+		return fmt.Errorf("compiling file pattern: %v", err)
+	}
+
+	// Get base repository owner environment variable if set.
+	baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+	if baseRepoOwner == "" {
+		baseRepoOwner = constants.DefaultBaseRepoOwner
+	}
+
+	// Clone the eks-anywhere-build-tooling repository.
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+	if err != nil {
+		return fmt.Errorf("cloning build-tooling repo: %v", err)
+	}
+
+	projectsDirectory := filepath.Join(buildToolingRepoPath, "projects")
+	if patchedFilesOptions.ProjectName != "" {
+		// Validate if the project name provided exists in the repository.
+		if !projects.Exists(buildToolingRepoPath, patchedFilesOptions.ProjectName) {
+			return fmt.Errorf("invalid project name %s", patchedFilesOptions.ProjectName)
+		}
+	}
+
+	patchedFiles, err := indexPatchedFiles(projectsDirectory, patchedFilesOptions.ProjectName)
+	if err != nil {
+		return fmt.Errorf("indexing patched files: %v", err)
+	}
+
+	tbl := table.New("File", "Project", "Patch").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	})
+
+	matchingProjects := map[string]bool{}
+	for _, patchedFile := range patchedFiles {
+		if !filePattern.MatchString(patchedFile.File) {
+			continue
+		}
+		matchingProjects[patchedFile.Project] = true
+		tbl.AddRow(patchedFile.File, patchedFile.Project, patchedFile.Patch)
+	}
+
+	logger.Info(fmt.Sprintf("Found %d projects with patches modifying matching files", len(matchingProjects)))
+	if len(matchingProjects) > 0 {
+		tbl.Print()
+	}
+
+	return nil
+}
+
+// indexPatchedFiles walks the patches directories of all projects, or a single project if the project name
+// is non-empty, and returns the upstream files modified by each patch, sorted by file, project and patch.
+func indexPatchedFiles(projectsDirectory, projectName string) ([]types.PatchedFile, error) {
+	diffHeaderRegex := regexp.MustCompile(constants.PatchDiffHeaderRegex)
+	patchedFiles := []types.PatchedFile{}
+
+	searchDirectory := projectsDirectory
+	if projectName != "" {
+		searchDirectory = filepath.Join(projectsDirectory, projectName)
+	}
+
+	err := filepath.WalkDir(searchDirectory, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".patch" || filepath.Base(filepath.Dir(path)) != constants.PatchesDirectory {
+			return nil
+		}
+
+		// Patches live under projects/<org>/<repo>, optionally nested in release branch or Helm chart directories.
+		relativePath, err := filepath.Rel(projectsDirectory, path)
+		if err != nil {
+			return err
+		}
+		pathComponents := strings.SplitN(filepath.ToSlash(relativePath), "/", 3)
+		if len(pathComponents) < 3 {
+			return nil
+		}
+		project := fmt.Sprintf("%s/%s", pathComponents[0], pathComponents[1])
+
+		files, err := getFilesModifiedByPatch(path, diffHeaderRegex)
+		if err != nil {
+			return fmt.Errorf("reading patch %s: %v", relativePath, err)
+		}
+		for _, file := range files {
+			patchedFiles = append(patchedFiles, types.PatchedFile{
+				Project: project,
+				Patch:   pathComponents[2],
+				File:    file,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(patchedFiles, func(i, j int) bool {
+		if patchedFiles[i].File != patchedFiles[j].File {
+			return patchedFiles[i].File < patchedFiles[j].File
+		}
+		if patchedFiles[i].Project != patchedFiles[j].Project {
+			return patchedFiles[i].Project < patchedFiles[j].Project
+		}
+		return patchedFiles[i].Patch < patchedFiles[j].Patch
+	})
+
+	return patchedFiles, nil
+}
+
+// getFilesModifiedByPatch returns the files modified by a patch, based on its diff headers. For renamed
+// files, both the source and destination paths are returned.
+func getFilesModifiedByPatch(patchFilepath string, diffHeaderRegex *regexp.Regexp) ([]string, error) {
+	patchFile, err := os.Open(patchFilepath)
+	if err != nil {
+		return nil, err
+	}
+	defer patchFile.Close()
+
+	files := []string{}
+	scanner := bufio.NewScanner(patchFile)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for scanner.Scan() {
+		matches := diffHeaderRegex.FindStringSubmatch(scanner.Text())
+		if matches == nil {
+			continue
+		}
+		files = append(files, matches[2])
+		if matches[1] != matches[2] {
+			files = append(files, matches[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
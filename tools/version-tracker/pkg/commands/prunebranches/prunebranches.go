@@ -0,0 +1,113 @@
+package prunebranches
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	gogithub "github.com/google/go-github/v53/github"
+	"github.com/rodaine/table"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/github"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/failures"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+)
+
+// Run contains the business logic to execute the `prune-branches` subcommand.
+func Run(pruneBranchesOptions *types.PruneBranchesOptions) error {
+	// Check if base repository owner environment variable has been set.
+	baseRepoOwner, ok := os.LookupEnv(constants.BaseRepoOwnerEnvvar)
+	if !ok {
+		return fmt.Errorf("BASE_REPO_OWNER environment variable is not set")
+	}
+
+	// Check if head repository owner environment variable has been set.
+	headRepoOwner, ok := os.LookupEnv(constants.HeadRepoOwnerEnvvar)
+	if !ok {
+		return fmt.Errorf("HEAD_REPO_OWNER environment variable is not set")
+	}
+
+	// Check if GitHub token environment variable has been set.
+	githubToken, ok := os.LookupEnv(constants.GitHubTokenEnvvar)
+	if !ok {
+		return fmt.Errorf("GITHUB_TOKEN environment variable is not set")
+	}
+	client := gogithub.NewTokenClient(context.Background(), githubToken)
+
+	branches, err := github.GetBranchesWithPrefix(client, headRepoOwner, constants.BuildToolingRepoName, constants.AutomationBranchPrefix)
+	if err != nil {
+		return fmt.Errorf("getting automation branches: %v", err)
+	}
+
+	tbl := table.New("Branch", "Pull Request", "Closed", "Action").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	})
+
+	report := failures.NewReport("Branch")
+	staleBranchCount := 0
+	cutoff := time.Now().Add(-pruneBranchesOptions.MaxAge)
+	for _, branch := range branches {
+		pullRequests, err := github.GetPullRequestsForBranch(client, baseRepoOwner, headRepoOwner, branch)
+		if err != nil {
+			if !pruneBranchesOptions.ContinueOnError {
+				return fmt.Errorf("getting pull requests for branch %s: %v", branch, err)
+			}
+			report.Add(branch, "get pull requests", err)
+			continue
+		}
+
+		latestPullRequest, stale := isStale(pullRequests, cutoff)
+		if !stale {
+			logger.V(6).Info("Skipping branch with open or recently closed pull request", "Branch", branch)
+			continue
+		}
+		staleBranchCount++
+
+		action := "Deleted"
+		if pruneBranchesOptions.DryRun {
+			action = "Would delete"
+		} else {
+			err = github.DeleteBranch(client, headRepoOwner, constants.BuildToolingRepoName, branch)
+			if err != nil {
+				if !pruneBranchesOptions.ContinueOnError {
+					return err
+				}
+				report.Add(branch, "delete branch", err)
+				action = "Failed to delete"
+			}
+		}
+		tbl.AddRow(branch, latestPullRequest.GetHTMLURL(), latestPullRequest.GetClosedAt().Format(time.DateOnly), action)
+	}
+
+	logger.Info(fmt.Sprintf("Found %d stale branches in %s/%s", staleBranchCount, headRepoOwner, constants.BuildToolingRepoName))
+	if staleBranchCount > 0 {
+		tbl.Print()
+	}
+	report.Print()
+
+	return report.Err("prune branches")
+}
+
+// isStale determines whether a branch is stale based on its pull requests. A branch is stale if it has at least one
+// pull request, none of them are open and the most recent one was merged or closed before the cutoff time. Branches
+// without pull requests are left alone since they may be in use by a run that has not created its pull request yet.
+func isStale(pullRequests []*gogithub.PullRequest, cutoff time.Time) (*gogithub.PullRequest, bool) {
+	var latestPullRequest *gogithub.PullRequest
+	for _, pullRequest := range pullRequests {
+		if pullRequest.GetState() == "open" {
+			return nil, false
+		}
+		if latestPullRequest == nil || pullRequest.GetClosedAt().After(latestPullRequest.GetClosedAt().Time) {
+			latestPullRequest = pullRequest
+		}
+	}
+	if latestPullRequest == nil {
+		return nil, false
+	}
+
+	return latestPullRequest, latestPullRequest.GetClosedAt().Before(cutoff)
+}
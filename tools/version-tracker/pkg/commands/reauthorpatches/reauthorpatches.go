@@ -0,0 +1,214 @@
+package reauthorpatches
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/checkout"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/upstream"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/cleanup"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/patch"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// Run contains the business logic to execute the `reauthor-patches` subcommand.
+func Run(reauthorPatchesOptions *types.ReauthorPatchesOptions) error {
+	projectName := reauthorPatchesOptions.ProjectName
+	releaseBranch := reauthorPatchesOptions.ReleaseBranch
+
+	// Get base repository owner environment variable if set.
+	baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+	if baseRepoOwner == "" {
+		baseRepoOwner = constants.DefaultBaseRepoOwner
+	}
+
+	// Clone the eks-anywhere-build-tooling repository.
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+	if err != nil {
+		return fmt.Errorf("cloning build-tooling repo: %v", err)
+	}
+
+	// Validate if the project name provided exists in the repository.
+	projectRootFilepath := projects.RootPath(buildToolingRepoPath, projectName)
+	if !projects.Exists(buildToolingRepoPath, projectName) {
+		return fmt.Errorf("invalid project name %s", projectName)
+	}
+
+	projectRepoPath, gitTag, patchesDirectory, err := checkout.GetRepoInfo(projectRootFilepath, releaseBranch)
+	if err != nil {
+		return err
+	}
+	if patchesDirectory == "" {
+		return fmt.Errorf("project %s does not have any patches", projectName)
+	}
+
+	// A patch that fails to apply leaves git am in progress in the checkout, which is the state the
+	// human needs to resolve, so only other checkout failures are fatal.
+	logger.Info("Checking out project repository and applying patches", "Project", projectName)
+	checkoutRepoOutput, err := checkout.CheckoutRepo(projectRootFilepath, releaseBranch)
+	if err != nil {
+		if !strings.Contains(checkoutRepoOutput, constants.FailedPatchApplyMarker) {
+			if logger.Verbosity < 6 {
+				fmt.Println(checkoutRepoOutput)
+			}
+			return err
+		}
+		logger.Warn("Patches failed to apply. Resolve the conflicts and run `git am --continue` in the shell below")
+
+		// Resolving conflicts often needs the upstream history, which shallow checkouts do not have.
+		err = upstream.Deepen(projectRepoPath)
+		if err != nil {
+			logger.Warn("Unable to fetch upstream repository history", "Error", err)
+		}
+	}
+
+	logger.Info(fmt.Sprintf("Opening a shell in %s. Use Git to resolve conflicts or edit the patch commits on top of %s, then exit the shell to export the patches", projectRepoPath, gitTag))
+	err = cleanup.RunInteractive(func() error {
+		return openShell(projectRepoPath)
+	})
+	if err != nil {
+		return fmt.Errorf("running interactive shell: %v", err)
+	}
+
+	inProgress, err := isGitOperationInProgress(projectRepoPath)
+	if err != nil {
+		return fmt.Errorf("checking for in-progress Git operations: %v", err)
+	}
+	if inProgress {
+		return fmt.Errorf("a git am or rebase operation is still in progress in %s, complete or abort it and rerun the command", projectRepoPath)
+	}
+
+	patchCount, err := exportPatches(projectRepoPath, gitTag, patchesDirectory)
+	if err != nil {
+		return fmt.Errorf("exporting patches: %v", err)
+	}
+	logger.Info(fmt.Sprintf("Exported %d patches to %s", patchCount, patchesDirectory))
+
+	return nil
+}
+
+// openShell starts the user's shell in the given directory, attached to the current terminal, and waits
+// for it to exit. Commits made in the shell use the identity from the commit author environment variables,
+// if set.
+func openShell(dir string) error {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = constants.DefaultShell
+	}
+
+	shellCmd := exec.Command(shell)
+	shellCmd.Dir = dir
+	shellCmd.Env = append(os.Environ(), checkout.GitIdentityEnv()...)
+	shellCmd.Stdin = os.Stdin
+	shellCmd.Stdout = os.Stdout
+	shellCmd.Stderr = os.Stderr
+
+	return shellCmd.Run()
+}
+
+// isGitOperationInProgress checks whether a git am or rebase operation has been left unfinished in the
+// given repository.
+func isGitOperationInProgress(repoPath string) (bool, error) {
+	for _, stateDirectory := range []string{"rebase-apply", "rebase-merge"} {
+		gitPathCmd := exec.Command("git", "-C", repoPath, "rev-parse", "--git-path", stateDirectory)
+		statePath, err := command.ExecCommand(gitPathCmd)
+		if err != nil {
+			return false, err
+		}
+		if !filepath.IsAbs(statePath) {
+			statePath = filepath.Join(repoPath, statePath)
+		}
+		if _, err := os.Stat(statePath); err == nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// exportPatches replaces the patches in the patches directory with the commits on top of the given Git tag,
+// normalizing each patch so that unchanged commits produce identical files, and returns the number of
+// patches written.
+func exportPatches(repoPath, gitTag, patchesDirectory string) (int, error) {
+	existingPatches, err := filepath.Glob(filepath.Join(patchesDirectory, constants.PatchFileGlob))
+	if err != nil {
+		return 0, fmt.Errorf("listing existing patches: %v", err)
+	}
+
+	// Keep the original patches in memory so they can be restored if the command is interrupted while the
+	// patches directory is half-written.
+	originalPatches := map[string][]byte{}
+	for _, existingPatch := range existingPatches {
+		contents, err := os.ReadFile(existingPatch)
+		if err != nil {
+			return 0, fmt.Errorf("reading existing patch %s: %v", existingPatch, err)
+		}
+		originalPatches[existingPatch] = contents
+	}
+	defer cleanup.Register("Restoring original patches", func() error {
+		return restorePatches(patchesDirectory, originalPatches)
+	})()
+
+	for _, existingPatch := range existingPatches {
+		if err := os.Remove(existingPatch); err != nil {
+			return 0, fmt.Errorf("removing existing patch %s: %v", existingPatch, err)
+		}
+	}
+
+	formatPatchCmd := exec.Command("git", "-C", repoPath, "format-patch", "--output-directory", patchesDirectory, gitTag)
+	formatPatchOutput, err := command.ExecCommand(formatPatchCmd)
+	if err != nil {
+		return 0, fmt.Errorf("running git format-patch: %v", err)
+	}
+	if formatPatchOutput == "" {
+		return 0, nil
+	}
+
+	// git format-patch prints the path of each patch it writes.
+	patchFiles := strings.Split(formatPatchOutput, "\n")
+	for _, patchFile := range patchFiles {
+		if !filepath.IsAbs(patchFile) {
+			patchFile = filepath.Join(repoPath, patchFile)
+		}
+		err = patch.NormalizeFile(patchFile)
+		if err != nil {
+			return 0, fmt.Errorf("normalizing patch: %v", err)
+		}
+	}
+
+	return len(patchFiles), nil
+}
+
+// restorePatches replaces the patches in the patches directory with the given original patches.
+func restorePatches(patchesDirectory string, originalPatches map[string][]byte) error {
+	patches, err := filepath.Glob(filepath.Join(patchesDirectory, constants.PatchFileGlob))
+	if err != nil {
+		return fmt.Errorf("listing patches: %v", err)
+	}
+	for _, patchFile := range patches {
+		if err := os.Remove(patchFile); err != nil {
+			return fmt.Errorf("removing patch %s: %v", patchFile, err)
+		}
+	}
+
+	for patchFile, contents := range originalPatches {
+		if err := os.WriteFile(patchFile, contents, 0o644); err != nil {
+			return fmt.Errorf("restoring patch %s: %v", patchFile, err)
+		}
+	}
+
+	return nil
+}
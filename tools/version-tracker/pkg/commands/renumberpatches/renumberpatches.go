@@ -0,0 +1,202 @@
+package renumberpatches
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rodaine/table"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/provenance"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/patch"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// renumbering represents a patch whose filename or subject numbering does not match its position in its series.
+type renumbering struct {
+	number             int
+	total              int
+	patchFilepath      string
+	contents           string
+	renumberedFilepath string
+	renumberedContents string
+	patchProvenance    *types.PatchProvenance
+}
+
+// Run contains the business logic to execute the `renumber-patches` subcommand.
+func Run(renumberPatchesOptions *types.RenumberPatchesOptions) error {
+	// Get base repository owner environment variable if set.
+	baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+	if baseRepoOwner == "" {
+		baseRepoOwner = constants.DefaultBaseRepoOwner
+	}
+
+	// Clone the eks-anywhere-build-tooling repository.
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+	if err != nil {
+		return fmt.Errorf("cloning build-tooling repo: %v", err)
+	}
+
+	projectNames := renumberPatchesOptions.ProjectNames
+	if len(projectNames) == 0 {
+		projectNames, err = projects.List(buildToolingRepoPath)
+		if err != nil {
+			return fmt.Errorf("listing projects: %v", err)
+		}
+	}
+	for _, projectName := range projectNames {
+		if !projects.Exists(buildToolingRepoPath, projectName) {
+			return fmt.Errorf("invalid project name %s", projectName)
+		}
+	}
+
+	tbl := table.New("Patch", "Renumbered Patch", "Number").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	})
+	renumberedPatches := 0
+	outdatedSeries := []string{}
+	for _, projectName := range projectNames {
+		patchesDirectories, err := projects.PatchesDirectories(buildToolingRepoPath, projectName)
+		if err != nil {
+			return fmt.Errorf("listing patches directories of project %s: %v", projectName, err)
+		}
+
+		for _, patchesDirectory := range patchesDirectories {
+			renumberings, err := getRenumberings(patchesDirectory)
+			if err != nil {
+				return fmt.Errorf("renumbering patches in %s: %v", patchesDirectory, err)
+			}
+			if len(renumberings) == 0 {
+				continue
+			}
+
+			relativePatchesDirectory, err := filepath.Rel(buildToolingRepoPath, patchesDirectory)
+			if err != nil {
+				return fmt.Errorf("getting relative path of patches directory %s: %v", patchesDirectory, err)
+			}
+			outdatedSeries = append(outdatedSeries, relativePatchesDirectory)
+			renumberedPatches += len(renumberings)
+			for _, r := range renumberings {
+				tbl.AddRow(filepath.Join(relativePatchesDirectory, filepath.Base(r.patchFilepath)), filepath.Base(r.renumberedFilepath), fmt.Sprintf("%d/%d", r.number, r.total))
+			}
+
+			if !renumberPatchesOptions.Check {
+				err = renumberPatches(renumberings)
+				if err != nil {
+					return fmt.Errorf("renumbering patches in %s: %v", relativePatchesDirectory, err)
+				}
+			}
+		}
+	}
+
+	if renumberPatchesOptions.Check {
+		logger.Info(fmt.Sprintf("Found %d patches to renumber in %d patch series", renumberedPatches, len(outdatedSeries)))
+	} else {
+		logger.Info(fmt.Sprintf("Renumbered %d patches in %d patch series", renumberedPatches, len(outdatedSeries)))
+	}
+	if renumberedPatches > 0 {
+		tbl.Print()
+	}
+
+	if renumberPatchesOptions.Check && len(outdatedSeries) > 0 {
+		return fmt.Errorf("patches are not numbered consistently in: %s. Run the `renumber-patches` subcommand to renumber them", strings.Join(outdatedSeries, ", "))
+	}
+
+	return nil
+}
+
+// getRenumberings returns the patches in the patches directory whose filename or subject numbering does not match
+// their position in the order git am applies them in, along with their renumbered filenames and contents. Subjects
+// are only renumbered if at least one patch in the series is numbered.
+func getRenumberings(patchesDirectory string) ([]renumbering, error) {
+	patchFiles, err := filepath.Glob(filepath.Join(patchesDirectory, constants.PatchFileGlob))
+	if err != nil {
+		return nil, fmt.Errorf("listing patches: %v", err)
+	}
+
+	patchContents := []string{}
+	numbered := false
+	for _, patchFile := range patchFiles {
+		contents, err := os.ReadFile(patchFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading patch file %s: %v", patchFile, err)
+		}
+		patchContents = append(patchContents, string(contents))
+		numbered = numbered || patch.Numbered(string(contents))
+	}
+
+	renumberings := []renumbering{}
+	for i, patchFile := range patchFiles {
+		r := renumbering{
+			number:             i + 1,
+			total:              len(patchFiles),
+			patchFilepath:      patchFile,
+			contents:           patchContents[i],
+			renumberedFilepath: filepath.Join(patchesDirectory, patch.RenumberFilename(filepath.Base(patchFile), i+1)),
+			renumberedContents: patchContents[i],
+		}
+		// Series exported without numbering, where every subject is prefixed with [PATCH] only, are consistent
+		// as they are.
+		if numbered {
+			r.renumberedContents = patch.Renumber(patchContents[i], i+1, len(patchFiles))
+		}
+		if r.renumberedFilepath == r.patchFilepath && r.renumberedContents == r.contents {
+			continue
+		}
+
+		// The provenance file of a renamed patch is renamed along with it.
+		if r.renumberedFilepath != r.patchFilepath {
+			if _, err := os.Stat(provenance.Path(patchFile)); err == nil {
+				r.patchProvenance, err = provenance.Load(patchFile)
+				if err != nil {
+					return nil, fmt.Errorf("loading provenance of patch %s: %v", patchFile, err)
+				}
+			}
+		}
+		renumberings = append(renumberings, r)
+	}
+
+	return renumberings, nil
+}
+
+// renumberPatches writes the renumbered patches and the provenance files of renamed patches. All renamed files are
+// removed before any is written, since the new name of a patch can be the old name of another patch in the series.
+func renumberPatches(renumberings []renumbering) error {
+	for _, r := range renumberings {
+		if r.renumberedFilepath == r.patchFilepath {
+			continue
+		}
+		if err := os.Remove(r.patchFilepath); err != nil {
+			return fmt.Errorf("removing patch file %s: %v", r.patchFilepath, err)
+		}
+		if r.patchProvenance != nil {
+			if err := os.Remove(provenance.Path(r.patchFilepath)); err != nil {
+				return fmt.Errorf("removing provenance file of patch %s: %v", r.patchFilepath, err)
+			}
+		}
+	}
+
+	for _, r := range renumberings {
+		if err := os.WriteFile(r.renumberedFilepath, []byte(r.renumberedContents), 0o644); err != nil {
+			return fmt.Errorf("writing patch file %s: %v", r.renumberedFilepath, err)
+		}
+		if r.patchProvenance != nil {
+			r.patchProvenance.Patch = filepath.Base(r.renumberedFilepath)
+			if err := provenance.Write(r.renumberedFilepath, r.patchProvenance); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package renumberpatches
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/provenance"
+)
+
+func TestRenumberPatches(t *testing.T) {
+	patchesDirectory := filepath.Join(t.TempDir(), "patches")
+	if err := os.MkdirAll(patchesDirectory, 0o755); err != nil {
+		t.Fatalf("Unexpected error creating patches directory. Got: %v", err)
+	}
+	patches := map[string]string{
+		"0001-First.patch":  "From: Jane Doe <jane@example.com>\nSubject: [PATCH 1/4] First\n\n",
+		"0003-Second.patch": "From: Jane Doe <jane@example.com>\nSubject: [PATCH 3/4] Second\n\n",
+		"0004-Third.patch":  "From: Jane Doe <jane@example.com>\nSubject: [PATCH 4/4] Third\n\n",
+	}
+	for name, contents := range patches {
+		if err := os.WriteFile(filepath.Join(patchesDirectory, name), []byte(contents), 0o644); err != nil {
+			t.Fatalf("Unexpected error writing patch %s. Got: %v", name, err)
+		}
+	}
+	if err := provenance.Write(filepath.Join(patchesDirectory, "0003-Second.patch"), &types.PatchProvenance{Patch: "0003-Second.patch", Reason: "Second"}); err != nil {
+		t.Fatalf("Unexpected error writing provenance. Got: %v", err)
+	}
+
+	renumberings, err := getRenumberings(patchesDirectory)
+	if err != nil {
+		t.Fatalf("Unexpected error getting renumberings. Got: %v", err)
+	}
+	if len(renumberings) != 3 {
+		t.Fatalf("Unexpected number of patches to renumber. Want: 3, got: %d", len(renumberings))
+	}
+	if err = renumberPatches(renumberings); err != nil {
+		t.Fatalf("Unexpected error renumbering patches. Got: %v", err)
+	}
+
+	want := map[string]string{
+		"0001-First.patch":  "From: Jane Doe <jane@example.com>\nSubject: [PATCH 1/3] First\n\n",
+		"0002-Second.patch": "From: Jane Doe <jane@example.com>\nSubject: [PATCH 2/3] Second\n\n",
+		"0003-Third.patch":  "From: Jane Doe <jane@example.com>\nSubject: [PATCH 3/3] Third\n\n",
+	}
+	got := map[string]string{}
+	patchFiles, _ := filepath.Glob(filepath.Join(patchesDirectory, "*.patch"))
+	for _, patchFile := range patchFiles {
+		contents, err := os.ReadFile(patchFile)
+		if err != nil {
+			t.Fatalf("Unexpected error reading patch %s. Got: %v", patchFile, err)
+		}
+		got[filepath.Base(patchFile)] = string(contents)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Unexpected renumbered patches. Want: %v, got: %v", want, got)
+	}
+
+	if _, err := os.Stat(provenance.Path(filepath.Join(patchesDirectory, "0003-Second.patch"))); !os.IsNotExist(err) {
+		t.Fatalf("Expected provenance file of renamed patch to be removed. Got: %v", err)
+	}
+	patchProvenance, err := provenance.Load(filepath.Join(patchesDirectory, "0002-Second.patch"))
+	if err != nil {
+		t.Fatalf("Unexpected error loading provenance of renamed patch. Got: %v", err)
+	}
+	if patchProvenance.Patch != "0002-Second.patch" || patchProvenance.Reason != "Second" {
+		t.Fatalf("Unexpected provenance of renamed patch. Got: %+v", patchProvenance)
+	}
+
+	if renumberings, err = getRenumberings(patchesDirectory); err != nil || len(renumberings) != 0 {
+		t.Fatalf("Unexpected renumberings after renumbering. Got: %d, error: %v", len(renumberings), err)
+	}
+}
@@ -0,0 +1,271 @@
+package selfupdate
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/buildinfo"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/cleanup"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+)
+
+// Run contains the business logic to execute the `self-update` subcommand.
+func Run(selfUpdateOptions *types.SelfUpdateOptions) error {
+	artifactsURL := strings.TrimSuffix(selfUpdateOptions.ArtifactsURL, "/")
+	if artifactsURL == "" {
+		artifactsURL = strings.TrimSuffix(os.Getenv(constants.SelfUpdateArtifactsURLEnvvar), "/")
+	}
+	if artifactsURL == "" {
+		return fmt.Errorf("no artifacts URL given, set the --artifacts-url flag or the %s environment variable", constants.SelfUpdateArtifactsURLEnvvar)
+	}
+
+	publicKeyFile := selfUpdateOptions.PublicKeyFile
+	if publicKeyFile == "" {
+		publicKeyFile = os.Getenv(constants.SelfUpdatePublicKeyEnvvar)
+	}
+	var publicKey ed25519.PublicKey
+	if publicKeyFile != "" {
+		var err error
+		publicKey, err = readPublicKey(publicKeyFile)
+		if err != nil {
+			return fmt.Errorf("reading public key: %v", err)
+		}
+	} else if !selfUpdateOptions.InsecureSkipSignature && !selfUpdateOptions.CheckOnly {
+		return fmt.Errorf("no public key given to verify the signature of the latest build with, set the --public-key flag or the %s environment variable, or pass --insecure-skip-signature to install it unverified", constants.SelfUpdatePublicKeyEnvvar)
+	}
+
+	currentBuildInfo := buildinfo.Get()
+	buildInfoURL := fmt.Sprintf("%s/%s", artifactsURL, constants.SelfUpdateBuildInfoFile)
+	buildInfoContents, err := download(buildInfoURL)
+	if err != nil {
+		return fmt.Errorf("downloading build information of latest build: %v", err)
+	}
+	var latestBuildInfo types.BuildInfo
+	if err := json.Unmarshal(buildInfoContents, &latestBuildInfo); err != nil {
+		return fmt.Errorf("unmarshalling build information of latest build: %v", err)
+	}
+	if latestBuildInfo.GitCommit == "" {
+		return fmt.Errorf("build information at %s does not contain a Git commit", buildInfoURL)
+	}
+
+	newer, err := isNewerBuild(currentBuildInfo, latestBuildInfo)
+	if err != nil {
+		return fmt.Errorf("comparing build information at %s with running binary: %v", buildInfoURL, err)
+	}
+	if !newer {
+		logger.Info("version-tracker is up to date", "Version", currentBuildInfo.Version, "Git commit", currentBuildInfo.GitCommit, "Build date", currentBuildInfo.BuildDate, "Latest build date", latestBuildInfo.BuildDate)
+		return nil
+	}
+	logger.Info("Newer version-tracker build available", "Current version", currentBuildInfo.Version, "Latest version", latestBuildInfo.Version, "Latest build date", latestBuildInfo.BuildDate)
+	if selfUpdateOptions.CheckOnly {
+		return nil
+	}
+
+	executablePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("getting path of running binary: %v", err)
+	}
+	executablePath, err = filepath.EvalSymlinks(executablePath)
+	if err != nil {
+		return fmt.Errorf("resolving path of running binary: %v", err)
+	}
+	executableInfo, err := os.Stat(executablePath)
+	if err != nil {
+		return fmt.Errorf("getting file info of running binary: %v", err)
+	}
+
+	binaryURL := fmt.Sprintf("%s/%s", artifactsURL, fmt.Sprintf(constants.SelfUpdateBinaryPathFormat, runtime.GOOS, runtime.GOARCH))
+	logger.Info("Downloading latest version-tracker build", "URL", binaryURL)
+	binaryContents, err := download(binaryURL)
+	if err != nil {
+		return fmt.Errorf("downloading latest build: %v", err)
+	}
+
+	checksumContents, err := download(binaryURL + constants.SelfUpdateChecksumFileSuffix)
+	if err != nil {
+		return fmt.Errorf("downloading checksum of latest build: %v", err)
+	}
+	if err := verifyChecksum(binaryContents, checksumContents); err != nil {
+		return err
+	}
+
+	if publicKey != nil {
+		signatureContents, err := download(binaryURL + constants.SelfUpdateSignatureFileSuffix)
+		if err != nil {
+			return fmt.Errorf("downloading signature of latest build: %v", err)
+		}
+		if err := verifySignature(binaryContents, signatureContents, publicKey); err != nil {
+			return err
+		}
+	} else {
+		logger.Warn("Installing latest build without verifying its signature", "URL", binaryURL)
+	}
+
+	// The new binary is written next to the running binary, so that it is on the same filesystem and can be moved
+	// over it atomically. Processes already running the old binary keep running it until they exit.
+	tempBinaryFile, err := os.CreateTemp(filepath.Dir(executablePath), fmt.Sprintf(".%s-*", filepath.Base(executablePath)))
+	if err != nil {
+		return fmt.Errorf("creating temporary file next to running binary: %v", err)
+	}
+	tempBinaryPath := tempBinaryFile.Name()
+	defer cleanup.Register("Removing downloaded version-tracker binary", func() error {
+		return os.RemoveAll(tempBinaryPath)
+	})()
+	defer os.RemoveAll(tempBinaryPath)
+
+	if _, err := tempBinaryFile.Write(binaryContents); err != nil {
+		tempBinaryFile.Close()
+		return fmt.Errorf("writing downloaded binary: %v", err)
+	}
+	if err := tempBinaryFile.Close(); err != nil {
+		return fmt.Errorf("closing downloaded binary: %v", err)
+	}
+	if err := os.Chmod(tempBinaryPath, executableInfo.Mode().Perm()|0o111); err != nil {
+		return fmt.Errorf("setting permissions of downloaded binary: %v", err)
+	}
+
+	// Run the downloaded binary before replacing the running one, so that a build that cannot run on this host
+	// does not leave the tool unusable. A binary whose signature was not verified is never run.
+	if publicKey != nil {
+		if err := checkDownloadedBinary(tempBinaryPath, latestBuildInfo); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Rename(tempBinaryPath, executablePath); err != nil {
+		return fmt.Errorf("replacing running binary %s: %v", executablePath, err)
+	}
+	logger.Info("Updated version-tracker", "Path", executablePath, "Version", latestBuildInfo.Version, "Git commit", latestBuildInfo.GitCommit)
+
+	return nil
+}
+
+// isNewerBuild returns whether the published build is newer than the running one, based on their build dates, so
+// that publishing an older build, for example when a release is rolled back, does not downgrade installed binaries.
+// Binaries built without a build date, such as with `go install`, are considered older than any published build.
+func isNewerBuild(currentBuildInfo, latestBuildInfo types.BuildInfo) (bool, error) {
+	if latestBuildInfo.GitCommit == currentBuildInfo.GitCommit {
+		return false, nil
+	}
+
+	latestBuildDate, err := time.Parse(time.RFC3339, latestBuildInfo.BuildDate)
+	if err != nil {
+		return false, fmt.Errorf("parsing build date of latest build: %v", err)
+	}
+	currentBuildDate, err := time.Parse(time.RFC3339, currentBuildInfo.BuildDate)
+	if err != nil {
+		return true, nil
+	}
+
+	return latestBuildDate.After(currentBuildDate), nil
+}
+
+// download returns the contents of the artifact at the given URL.
+func download(url string) ([]byte, error) {
+	logger.V(6).Info(fmt.Sprintf("Downloading %s", url))
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s returned status %d", url, resp.StatusCode)
+	}
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %v", url, err)
+	}
+
+	return contents, nil
+}
+
+// verifyChecksum checks the SHA256 checksum of the downloaded binary against the checksum file published with it,
+// which is in the format written by sha256sum.
+func verifyChecksum(binaryContents, checksumContents []byte) error {
+	checksumFields := strings.Fields(string(checksumContents))
+	if len(checksumFields) == 0 {
+		return fmt.Errorf("checksum file of latest build is empty")
+	}
+
+	binaryChecksum := sha256.Sum256(binaryContents)
+	if !strings.EqualFold(checksumFields[0], hex.EncodeToString(binaryChecksum[:])) {
+		return fmt.Errorf("checksum of downloaded binary %x does not match published checksum %s", binaryChecksum, checksumFields[0])
+	}
+
+	return nil
+}
+
+// verifySignature checks the base64-encoded Ed25519 signature of the downloaded binary published with it against
+// the given public key.
+func verifySignature(binaryContents, signatureContents []byte, publicKey ed25519.PublicKey) error {
+	signature, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signatureContents)))
+	if err != nil {
+		return fmt.Errorf("decoding signature of latest build: %v", err)
+	}
+	if !ed25519.Verify(publicKey, binaryContents, signature) {
+		return fmt.Errorf("signature of downloaded binary does not match public key")
+	}
+
+	return nil
+}
+
+// readPublicKey reads the PEM-encoded Ed25519 public key used to verify the signature of downloaded builds.
+func readPublicKey(publicKeyFilepath string) (ed25519.PublicKey, error) {
+	publicKeyContents, err := os.ReadFile(publicKeyFilepath)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKeyBlock, _ := pem.Decode(publicKeyContents)
+	if publicKeyBlock == nil {
+		return nil, fmt.Errorf("no PEM-encoded public key found in %s", publicKeyFilepath)
+	}
+	parsedPublicKey, err := x509.ParsePKIXPublicKey(publicKeyBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key: %v", err)
+	}
+	publicKey, ok := parsedPublicKey.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key in %s is not an Ed25519 key", publicKeyFilepath)
+	}
+
+	return publicKey, nil
+}
+
+// checkDownloadedBinary runs the downloaded binary to print its build information and checks that it is the
+// build described by the published build information.
+func checkDownloadedBinary(binaryPath string, latestBuildInfo types.BuildInfo) error {
+	buildInfoOutput, err := exec.Command(binaryPath, "version", "--output", constants.JSONOutputFormat).Output()
+	if err != nil {
+		return fmt.Errorf("running downloaded binary: %v", err)
+	}
+
+	var downloadedBuildInfo types.BuildInfo
+	if err := json.Unmarshal(buildInfoOutput, &downloadedBuildInfo); err != nil {
+		return fmt.Errorf("unmarshalling build information of downloaded binary: %v", err)
+	}
+	if downloadedBuildInfo.GitCommit != latestBuildInfo.GitCommit {
+		return fmt.Errorf("downloaded binary was built from commit %s, but the published build information lists commit %s", downloadedBuildInfo.GitCommit, latestBuildInfo.GitCommit)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package selfupdate
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+)
+
+func TestVerifyDownloadedBinary(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error generating key. Got: %v", err)
+	}
+	otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error generating key. Got: %v", err)
+	}
+
+	binaryContents := []byte("version-tracker binary")
+	binaryChecksum := sha256.Sum256(binaryContents)
+	checksumContents := []byte(hex.EncodeToString(binaryChecksum[:]) + "  version-tracker\n")
+	signatureContents := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, binaryContents)) + "\n")
+
+	testcases := []struct {
+		name              string
+		binaryContents    []byte
+		checksumContents  []byte
+		signatureContents []byte
+		publicKey         ed25519.PublicKey
+		wantErr           bool
+	}{
+		{
+			name:              "Matching checksum and signature",
+			binaryContents:    binaryContents,
+			checksumContents:  checksumContents,
+			signatureContents: signatureContents,
+			publicKey:         publicKey,
+			wantErr:           false,
+		},
+		{
+			name:              "Modified binary",
+			binaryContents:    []byte("modified version-tracker binary"),
+			checksumContents:  checksumContents,
+			signatureContents: signatureContents,
+			publicKey:         publicKey,
+			wantErr:           true,
+		},
+		{
+			name:              "Empty checksum file",
+			binaryContents:    binaryContents,
+			checksumContents:  []byte{},
+			signatureContents: signatureContents,
+			publicKey:         publicKey,
+			wantErr:           true,
+		},
+		{
+			name:              "Signature from another key",
+			binaryContents:    binaryContents,
+			checksumContents:  checksumContents,
+			signatureContents: signatureContents,
+			publicKey:         otherPublicKey,
+			wantErr:           true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyChecksum(tc.binaryContents, tc.checksumContents)
+			if err == nil {
+				err = verifySignature(tc.binaryContents, tc.signatureContents, tc.publicKey)
+			}
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error. Got: %v", err)
+			}
+		})
+	}
+}
+
+func TestIsNewerBuild(t *testing.T) {
+	currentBuildInfo := types.BuildInfo{GitCommit: "abc123", BuildDate: "2024-05-01T10:00:00Z"}
+
+	testcases := []struct {
+		name             string
+		currentBuildInfo types.BuildInfo
+		latestBuildInfo  types.BuildInfo
+		wantNewer        bool
+		wantErr          bool
+	}{
+		{
+			name:             "Same commit",
+			currentBuildInfo: currentBuildInfo,
+			latestBuildInfo:  types.BuildInfo{GitCommit: "abc123", BuildDate: "2024-06-01T10:00:00Z"},
+			wantNewer:        false,
+		},
+		{
+			name:             "Later build",
+			currentBuildInfo: currentBuildInfo,
+			latestBuildInfo:  types.BuildInfo{GitCommit: "def456", BuildDate: "2024-06-01T10:00:00Z"},
+			wantNewer:        true,
+		},
+		{
+			name:             "Earlier build",
+			currentBuildInfo: currentBuildInfo,
+			latestBuildInfo:  types.BuildInfo{GitCommit: "def456", BuildDate: "2024-04-01T10:00:00Z"},
+			wantNewer:        false,
+		},
+		{
+			name:             "Running binary without build date",
+			currentBuildInfo: types.BuildInfo{GitCommit: "abc123", BuildDate: "unknown"},
+			latestBuildInfo:  types.BuildInfo{GitCommit: "def456", BuildDate: "2024-04-01T10:00:00Z"},
+			wantNewer:        true,
+		},
+		{
+			name:             "Latest build without build date",
+			currentBuildInfo: currentBuildInfo,
+			latestBuildInfo:  types.BuildInfo{GitCommit: "def456"},
+			wantErr:          true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			newer, err := isNewerBuild(tc.currentBuildInfo, tc.latestBuildInfo)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error. Got: %v", err)
+			}
+			if newer != tc.wantNewer {
+				t.Errorf("Unexpected result. Want: %t, got: %t", tc.wantNewer, newer)
+			}
+		})
+	}
+}
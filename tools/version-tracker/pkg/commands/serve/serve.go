@@ -0,0 +1,276 @@
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// server serves the metadata of the projects in the build-tooling repository checkout, which is regenerated
+// every time the checkout is refreshed.
+type server struct {
+	mutex    sync.RWMutex
+	metadata *types.ProjectsMetadata
+}
+
+// Run contains the business logic to execute the `serve` subcommand.
+func Run(serveOptions *types.ServeOptions) error {
+	// Get base repository owner environment variable if set.
+	baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+	if baseRepoOwner == "" {
+		baseRepoOwner = constants.DefaultBaseRepoOwner
+	}
+
+	// Clone the eks-anywhere-build-tooling repository.
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+	if err != nil {
+		return fmt.Errorf("cloning build-tooling repo: %v", err)
+	}
+
+	metadata, err := getProjectsMetadata(buildToolingRepoPath, time.Now())
+	if err != nil {
+		return fmt.Errorf("generating project metadata: %v", err)
+	}
+	s := &server{metadata: metadata}
+
+	if serveOptions.RefreshInterval > 0 {
+		go s.refresh(buildToolingRepoPath, serveOptions.RefreshInterval)
+	}
+
+	httpServer := &http.Server{
+		Addr:              serveOptions.ListenAddress,
+		Handler:           s.handler(),
+		ReadHeaderTimeout: constants.ServeReadHeaderTimeout,
+	}
+	logger.Info("Serving project metadata", "Address", serveOptions.ListenAddress, "Projects", len(metadata.Projects), "Commit", metadata.Commit)
+	err = httpServer.ListenAndServe()
+	if err != nil {
+		return fmt.Errorf("serving HTTP requests: %v", err)
+	}
+
+	return nil
+}
+
+// refresh regenerates the metadata every interval. The clone in the workspace directory is pulled first, while an
+// existing checkout given as the repository root is served as it is on disk, so that its owner controls what it
+// contains. The previous metadata keeps being served if the refresh fails.
+func (s *server) refresh(buildToolingRepoPath string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !workspace.ExistingCheckout() {
+			_, err := command.ExecCommand(exec.Command("git", "-C", buildToolingRepoPath, "pull", "--ff-only"))
+			if err != nil {
+				logger.Warn("Unable to pull build-tooling repository, serving the previous metadata", "Error", err)
+				continue
+			}
+		}
+
+		metadata, err := getProjectsMetadata(buildToolingRepoPath, time.Now())
+		if err != nil {
+			logger.Warn("Unable to regenerate project metadata, serving the previous metadata", "Error", err)
+			continue
+		}
+
+		s.mutex.Lock()
+		s.metadata = metadata
+		s.mutex.Unlock()
+		logger.V(6).Info("Regenerated project metadata", "Projects", len(metadata.Projects), "Commit", metadata.Commit)
+	}
+}
+
+// handler returns the HTTP handler serving the metadata of all projects at /projects, the metadata of a single
+// project at /projects/<org>/<repo> and a health check at /healthz.
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("GET /projects", func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.RLock()
+		defer s.mutex.RUnlock()
+		writeJSON(w, http.StatusOK, s.metadata)
+	})
+	mux.HandleFunc("GET /projects/{org}/{repo}", func(w http.ResponseWriter, r *http.Request) {
+		projectName := fmt.Sprintf("%s/%s", r.PathValue("org"), r.PathValue("repo"))
+
+		s.mutex.RLock()
+		defer s.mutex.RUnlock()
+		for _, project := range s.metadata.Projects {
+			if project.Project == projectName {
+				writeJSON(w, http.StatusOK, project)
+				return
+			}
+		}
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": fmt.Sprintf("project %s not found", projectName)})
+	})
+
+	return mux
+}
+
+// writeJSON writes the value as the JSON body of the response with the given status code.
+func writeJSON(w http.ResponseWriter, statusCode int, value interface{}) {
+	body, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("marshalling response: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(append(body, '\n'))
+}
+
+// getProjectsMetadata generates the metadata of all projects in the build-tooling repository checkout. Release
+// branch-specific files are read from the release branch directories of release-branched projects and fall back
+// to the project's directory, the same way the project Makefiles resolve them.
+func getProjectsMetadata(buildToolingRepoPath string, now time.Time) (*types.ProjectsMetadata, error) {
+	supportedReleaseBranchesFileContents, err := os.ReadFile(filepath.Join(buildToolingRepoPath, constants.SupportedReleaseBranchesFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading supported release branches file: %v", err)
+	}
+	supportedReleaseBranches := strings.Fields(string(supportedReleaseBranchesFileContents))
+
+	projectNames, err := projects.List(buildToolingRepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("listing projects: %v", err)
+	}
+
+	headCommit, err := command.ExecCommandOutput(exec.Command("git", "-C", buildToolingRepoPath, "rev-parse", "HEAD"))
+	if err != nil {
+		return nil, fmt.Errorf("getting build-tooling repository HEAD commit: %v", err)
+	}
+
+	// A single log of the commits changing the GIT_TAG and CHECKSUMS files of all projects is much faster than one
+	// log per project.
+	logOutput, err := command.ExecCommandOutput(exec.Command("git", "-C", buildToolingRepoPath, "log", "--format=%x00%H%x09%cI", "--name-only", "--",
+		fmt.Sprintf("%s/*%s", projects.Directory, constants.GitTagFile), fmt.Sprintf("%s/*%s", projects.Directory, constants.ChecksumsFile)))
+	if err != nil {
+		return nil, fmt.Errorf("getting history of project files: %v", err)
+	}
+	lastChanges := parseLastChanges(logOutput)
+
+	metadata := &types.ProjectsMetadata{
+		Commit:      headCommit,
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		Projects:    []types.ProjectMetadata{},
+	}
+	for _, projectName := range projectNames {
+		projectRootFilepath := projects.RootPath(buildToolingRepoPath, projectName)
+
+		releaseBranches := []string{}
+		for _, releaseBranch := range supportedReleaseBranches {
+			if info, err := os.Stat(filepath.Join(projectRootFilepath, releaseBranch)); err == nil && info.IsDir() {
+				releaseBranches = append(releaseBranches, releaseBranch)
+			}
+		}
+		if len(releaseBranches) == 0 {
+			releaseBranches = []string{""}
+		}
+
+		projectMetadata := types.ProjectMetadata{
+			Project:         projectName,
+			ReleaseBranches: []types.ReleaseBranchMetadata{},
+		}
+		for _, releaseBranch := range releaseBranches {
+			releaseBranchMetadata := types.ReleaseBranchMetadata{
+				ReleaseBranch: releaseBranch,
+				GitTag:        readReleaseBranchFile(projectRootFilepath, releaseBranch, constants.GitTagFile),
+				GoVersion:     readReleaseBranchFile(projectRootFilepath, releaseBranch, constants.GoVersionFile),
+			}
+			patchesDirectory := filepath.Join(projectRootFilepath, releaseBranch, constants.PatchesDirectory)
+			if _, err := os.Stat(patchesDirectory); err != nil {
+				patchesDirectory = filepath.Join(projectRootFilepath, constants.PatchesDirectory)
+			}
+			patches, err := filepath.Glob(filepath.Join(patchesDirectory, constants.PatchFileGlob))
+			if err != nil {
+				return nil, fmt.Errorf("listing patches of project %s: %v", projectName, err)
+			}
+			releaseBranchMetadata.Patches = len(patches)
+			projectMetadata.Patches += len(patches)
+			projectMetadata.ReleaseBranches = append(projectMetadata.ReleaseBranches, releaseBranchMetadata)
+		}
+
+		if versionUpdate, ok := lastChanges[projectName][constants.GitTagFile]; ok {
+			projectMetadata.LastVersionUpdate = &versionUpdate
+			if date, err := time.Parse(time.RFC3339, versionUpdate.Date); err == nil {
+				days := int(now.Sub(date).Hours() / 24)
+				projectMetadata.DaysSinceVersionUpdate = &days
+			}
+		}
+		if build, ok := lastChanges[projectName][constants.ChecksumsFile]; ok {
+			projectMetadata.LastBuild = &build
+		}
+
+		metadata.Projects = append(metadata.Projects, projectMetadata)
+	}
+
+	return metadata, nil
+}
+
+// readReleaseBranchFile returns the trimmed contents of a release branch-specific file of the project, or of the
+// project-wide file if the release branch has none. Missing files are returned as empty.
+func readReleaseBranchFile(projectRootFilepath, releaseBranch, fileName string) string {
+	contents, err := os.ReadFile(filepath.Join(projectRootFilepath, releaseBranch, fileName))
+	if err != nil {
+		contents, err = os.ReadFile(filepath.Join(projectRootFilepath, fileName))
+		if err != nil {
+			return ""
+		}
+	}
+
+	return strings.TrimSpace(string(contents))
+}
+
+// parseLastChanges parses the output of git log with the --name-only flag and a NUL-prefixed "<commit>\t<date>"
+// format, newest commits first, into the last commit changing each kind of file of each project, keyed by project
+// name and file name. Files outside project directories are ignored.
+func parseLastChanges(logOutput string) map[string]map[string]types.CommitReference {
+	lastChanges := map[string]map[string]types.CommitReference{}
+	var currentCommit types.CommitReference
+	for _, line := range strings.Split(logOutput, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "\x00") {
+			commit, date, _ := strings.Cut(strings.TrimPrefix(line, "\x00"), "\t")
+			currentCommit = types.CommitReference{Commit: commit, Date: date}
+			continue
+		}
+
+		pathParts := strings.Split(line, "/")
+		if len(pathParts) < 4 || pathParts[0] != projects.Directory {
+			continue
+		}
+		projectName := fmt.Sprintf("%s/%s", pathParts[1], pathParts[2])
+		fileName := pathParts[len(pathParts)-1]
+		if lastChanges[projectName] == nil {
+			lastChanges[projectName] = map[string]types.CommitReference{}
+		}
+		if _, ok := lastChanges[projectName][fileName]; !ok {
+			lastChanges[projectName][fileName] = currentCommit
+		}
+	}
+
+	return lastChanges
+}
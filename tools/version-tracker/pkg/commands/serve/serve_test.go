@@ -0,0 +1,59 @@
+package serve
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+)
+
+func TestParseLastChanges(t *testing.T) {
+	logOutput := "\x00bbb\t2024-05-02T10:00:00Z\n\nprojects/kubernetes/cloud-provider-aws/1-29/GIT_TAG\nprojects/kubernetes/cloud-provider-aws/1-29/CHECKSUMS\n" +
+		"\x00aaa\t2024-04-01T10:00:00Z\n\nprojects/kubernetes/cloud-provider-aws/1-28/GIT_TAG\nprojects/containerd/containerd/CHECKSUMS\nrelease/GIT_TAG"
+
+	want := map[string]map[string]types.CommitReference{
+		"kubernetes/cloud-provider-aws": {
+			"GIT_TAG":   {Commit: "bbb", Date: "2024-05-02T10:00:00Z"},
+			"CHECKSUMS": {Commit: "bbb", Date: "2024-05-02T10:00:00Z"},
+		},
+		"containerd/containerd": {
+			"CHECKSUMS": {Commit: "aaa", Date: "2024-04-01T10:00:00Z"},
+		},
+	}
+	got := parseLastChanges(logOutput)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Unexpected last changes. Want: %+v, got: %+v", want, got)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	s := &server{metadata: &types.ProjectsMetadata{
+		Commit: "abc",
+		Projects: []types.ProjectMetadata{
+			{Project: "containerd/containerd", ReleaseBranches: []types.ReleaseBranchMetadata{{GitTag: "v1.7.13", Patches: 2}}, Patches: 2},
+		},
+	}}
+	handler := s.handler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/projects/containerd/containerd", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Unexpected status code for existing project. Want: %d, got: %d", http.StatusOK, recorder.Code)
+	}
+	var project types.ProjectMetadata
+	if err := json.Unmarshal(recorder.Body.Bytes(), &project); err != nil {
+		t.Fatalf("Unexpected error unmarshalling project metadata. Got: %v", err)
+	}
+	if !reflect.DeepEqual(project, s.metadata.Projects[0]) {
+		t.Fatalf("Unexpected project metadata. Want: %+v, got: %+v", s.metadata.Projects[0], project)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/projects/containerd/unknown", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("Unexpected status code for unknown project. Want: %d, got: %d", http.StatusNotFound, recorder.Code)
+	}
+}
@@ -0,0 +1,154 @@
+package triage
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/rodaine/table"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/upstream"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/disk"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/environment"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/progress"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// Run contains the business logic to execute the `triage` subcommand.
+func Run(triageOptions *types.TriageOptions) error {
+	projectName := triageOptions.ProjectName
+	projectPath := projects.Path(projectName)
+
+	var buildLog string
+	if triageOptions.LogFile != "" {
+		logContents, err := os.ReadFile(triageOptions.LogFile)
+		if err != nil {
+			return fmt.Errorf("reading build log file: %v", err)
+		}
+		buildLog = string(logContents)
+	} else {
+		// Get base repository owner environment variable if set.
+		baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+		if baseRepoOwner == "" {
+			baseRepoOwner = constants.DefaultBaseRepoOwner
+		}
+
+		// Clone the eks-anywhere-build-tooling repository.
+		buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+		if err != nil {
+			return fmt.Errorf("getting build-tooling repository path: %v", err)
+		}
+		_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+		if err != nil {
+			return fmt.Errorf("cloning build-tooling repo: %v", err)
+		}
+
+		// Validate if the project name provided exists in the repository.
+		projectRootFilepath := filepath.Join(buildToolingRepoPath, projectPath)
+		if !projects.Exists(buildToolingRepoPath, projectName) {
+			return fmt.Errorf("invalid project name %s", projectName)
+		}
+
+		if err := disk.CheckFreeSpace(projectRootFilepath); err != nil {
+			return err
+		}
+
+		var buildSucceeded bool
+		buildLog, buildSucceeded = BuildProject(projectRootFilepath, triageOptions.ReleaseBranch)
+		if buildSucceeded {
+			logger.Info("Project built successfully. Nothing to triage", "Project", projectName)
+			return nil
+		}
+	}
+
+	buildFailures := ClassifyBuildFailures(buildLog, projectPath)
+	if len(buildFailures) == 0 {
+		logger.Warn("Unable to classify build failure. Inspect the build log for details", "Project", projectName)
+		return nil
+	}
+
+	tbl := table.New("Category", "Evidence", "Suggested Fix").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	})
+	for _, buildFailure := range buildFailures {
+		tbl.AddRow(buildFailure.Category, buildFailure.Evidence, buildFailure.Suggestion)
+	}
+	tbl.Print()
+
+	return nil
+}
+
+// BuildProject runs the project's default Make target, which checks out the upstream repository, applies
+// patches and builds the project, and returns the combined output along with whether the build succeeded.
+func BuildProject(projectRootFilepath, releaseBranch string) (string, bool) {
+	buildCommandSequence := fmt.Sprintf("%s -C %s build", environment.Make(), projectRootFilepath)
+	if releaseBranch != "" {
+		buildCommandSequence = fmt.Sprintf("%s RELEASE_BRANCH=%s", buildCommandSequence, releaseBranch)
+	}
+	logger.Info("Reproducing project build", "Command", buildCommandSequence)
+
+	if err := upstream.PrepareCheckout(projectRootFilepath, releaseBranch); err != nil {
+		logger.Warn("Unable to prepare upstream worktree checkout, leaving the checkout to the Make target", "Error", err)
+	}
+
+	buildCmd := exec.Command("bash", "-c", buildCommandSequence)
+	stopProgress := progress.Start("building project", "Command", buildCommandSequence)
+	buildOutput, err := command.ExecCommand(buildCmd)
+	stopProgress()
+	if err != nil {
+		logger.V(6).Info(fmt.Sprintf("Project build failed: %v", err))
+		return buildOutput, false
+	}
+
+	return buildOutput, true
+}
+
+// ClassifyBuildFailures matches each line of the build log against the build failure rules and returns
+// one failure per matched category, in rule order, with the first matching line as evidence.
+func ClassifyBuildFailures(buildLog, projectPath string) []types.BuildFailure {
+	buildFailures := []types.BuildFailure{}
+	for _, rule := range constants.BuildFailureRules {
+		patterns := make([]*regexp.Regexp, 0, len(rule.Patterns))
+		for _, pattern := range rule.Patterns {
+			patterns = append(patterns, regexp.MustCompile(pattern))
+		}
+
+		evidence := findFirstMatchingLine(buildLog, patterns)
+		if evidence == "" {
+			continue
+		}
+
+		buildFailures = append(buildFailures, types.BuildFailure{
+			Category:   rule.Category,
+			Evidence:   evidence,
+			Suggestion: fmt.Sprintf(rule.Suggestion, projectPath),
+		})
+	}
+
+	return buildFailures
+}
+
+func findFirstMatchingLine(buildLog string, patterns []*regexp.Regexp) string {
+	scanner := bufio.NewScanner(strings.NewReader(buildLog))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		for _, pattern := range patterns {
+			if pattern.MatchString(line) {
+				return line
+			}
+		}
+	}
+
+	return ""
+}
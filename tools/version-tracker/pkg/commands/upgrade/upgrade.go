@@ -6,42 +6,61 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ghodss/yaml"
+	gogit "github.com/go-git/go-git/v5"
 	gogithub "github.com/google/go-github/v53/github"
 	"github.com/pelletier/go-toml/v2"
 	goyamlv3 "gopkg.in/yaml.v3"
 
-	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/commands/triage"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/ecrpublic"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/eksdistro"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/github"
-	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/history"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/upstream"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/cleanup"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/disk"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/environment"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/file"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/gomod"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/makefile"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/progress"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/prtemplate"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/slices"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/tracing"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
 )
 
 // Run contains the business logic to execute the `upgrade` subcommand.
 func Run(upgradeOptions *types.UpgradeOptions) error {
 	var currentRevision, latestRevision string
-	var patchApplySucceeded, addPatchWarningComment bool
-	var totalPatchCount int
-	var updatedFiles []string
+	var addPatchWarningComment bool
+	var updatedFiles, pullRequestLabels []string
+	var breakingChanges []types.MarkedChange
+	var supersededBranches []string
+	var simulationSection string
 	patchesWarningComment := constants.PatchesCommentBody
 
 	projectName := upgradeOptions.ProjectName
 
 	// Get org and repository name from project name.
-	projectOrg := strings.Split(projectName, "/")[0]
-	projectRepo := strings.Split(projectName, "/")[1]
+	projectOrg, projectRepo := projects.SplitName(projectName)
 
 	// Check if base repository owner environment variable has been set.
 	baseRepoOwner, ok := os.LookupEnv(constants.BaseRepoOwnerEnvvar)
@@ -79,8 +98,13 @@ func Run(upgradeOptions *types.UpgradeOptions) error {
 	}
 
 	// Clone the eks-anywhere-build-tooling repository.
-	buildToolingRepoPath := filepath.Join(cwd, constants.BuildToolingRepoName)
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	cloneSpan := tracing.Start("clone-build-tooling-repo")
 	repo, headCommit, err := git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, headRepoOwner)
+	cloneSpan.End(err)
 	if err != nil {
 		return fmt.Errorf("cloning build-tooling repo: %v", err)
 	}
@@ -91,6 +115,11 @@ func Run(upgradeOptions *types.UpgradeOptions) error {
 		return fmt.Errorf("getting repo's current worktree: %v", err)
 	}
 
+	// Discard the partially applied upgrade if the command is interrupted.
+	defer cleanup.Register("Discarding uncommitted changes in build-tooling repository", func() error {
+		return git.DiscardChanges(worktree, nil)
+	})()
+
 	var headBranchName, baseBranchName, commitMessage, pullRequestBody string
 	if isEKSDistroUpgrade(projectName) {
 		headBranchName = "update-eks-d-latest-releases"
@@ -119,23 +148,12 @@ func Run(upgradeOptions *types.UpgradeOptions) error {
 		}
 	} else {
 		// Validate if the project name provided exists in the repository.
-		projectPath := filepath.Join("projects", projectName)
+		projectPath := projects.Path(projectName)
 		projectRootFilepath := filepath.Join(buildToolingRepoPath, projectPath)
-		if _, err := os.Stat(projectRootFilepath); os.IsNotExist(err) {
+		if !projects.Exists(buildToolingRepoPath, projectName) {
 			return fmt.Errorf("invalid project name %s", projectName)
 		}
 
-		// Check if project to be upgraded has patches
-		projectHasPatches := false
-		if _, err := os.Stat(filepath.Join(projectRootFilepath, constants.PatchesDirectory)); err == nil {
-			projectHasPatches = true
-			patchFiles, err := os.ReadDir(filepath.Join(projectRootFilepath, constants.PatchesDirectory))
-			if err != nil {
-				return fmt.Errorf("reading patch directory", err)
-			}
-			totalPatchCount = len(patchFiles)
-		}
-
 		headBranchName = fmt.Sprintf("update-%s-%s", projectOrg, projectRepo)
 		baseBranchName = constants.MainBranchName
 		commitMessage = fmt.Sprintf("Bump %s to latest release", projectName)
@@ -147,21 +165,35 @@ func Run(upgradeOptions *types.UpgradeOptions) error {
 			return fmt.Errorf("loading upstream projects tracker file: %v", err)
 		}
 
-		// Validate whether the given project is release-branched.
+		// Release-branched projects track a revision per release branch, so upgrade each of them to the latest
+		// patch release in its minor version line.
 		if len(targetRepo.Versions) > 1 {
-			return fmt.Errorf("release-branched projects not supported at this time")
+			return upgradeReleaseBranchedProject(client, repo, worktree, headCommit, upgradeOptions, buildToolingRepoPath, baseRepoOwner, headRepoOwner, githubToken)
 		}
 
 		currentVersion := targetRepo.Versions[0]
-		// Validate whether the project builds off a commit hash instead of a tag.
-		if currentVersion.Tag == "" {
-			return fmt.Errorf("projects tracked with commit hashes not supported at this time")
+		currentRevision = currentVersion.Tag
+
+		// Projects that build off a commit hash instead of a tag follow the head of an upstream branch, if they have
+		// a commit tracking policy configured.
+		commitPinned := currentVersion.Tag == ""
+		commitTrackingPolicy, hasCommitTrackingPolicy := constants.ProjectCommitTrackingPolicies[projectName]
+		if commitPinned {
+			if !hasCommitTrackingPolicy {
+				return fmt.Errorf("projects tracked with commit hashes not supported without a commit tracking policy")
+			}
+			currentRevision = currentVersion.Commit
 		}
-		currentRevision := currentVersion.Tag
 
-		var latestRevision string
 		var needsUpgrade bool
-		if projectName == "cilium/cilium" {
+		var trackedBranch string
+		if commitPinned {
+			trackedBranch, latestRevision, needsUpgrade, err = getLatestBranchRevision(client, buildToolingRepoPath, projectName, projectOrg, projectRepo, currentRevision, commitTrackingPolicy)
+			if err != nil {
+				return fmt.Errorf("getting latest commit from GitHub: %v", err)
+			}
+			commitMessage = fmt.Sprintf("Bump %s to latest commit", projectName)
+		} else if projectName == "cilium/cilium" {
 			latestRevision, needsUpgrade, err = ecrpublic.GetLatestRevision(constants.CiliumImageRepository, currentRevision)
 			if err != nil {
 				return fmt.Errorf("getting latest revision from ECR Public: %v", err)
@@ -175,6 +207,9 @@ func Run(upgradeOptions *types.UpgradeOptions) error {
 		}
 
 		pullRequestBody = fmt.Sprintf(constants.DefaultUpgradePullRequestBody, projectOrg, projectRepo, currentRevision, latestRevision)
+		if commitPinned {
+			pullRequestBody = fmt.Sprintf(constants.CommitPinnedUpgradePullRequestBody, projectOrg, projectRepo, trackedBranch, currentRevision, latestRevision)
+		}
 
 		// Upgrade project if latest commit was made after current commit and the semver of the latest revision is
 		// greater than the semver of the current version.
@@ -194,118 +229,40 @@ func Run(upgradeOptions *types.UpgradeOptions) error {
 			if needsUpgrade {
 				logger.Info("Project is out of date.", "Current version", currentRevision, "Latest version", latestRevision)
 
-				// Reload upstream projects tracker file to get its original value instead of
-				// the updated one from another project's previous upgrade
-				projectsList, targetRepo, err := loadUpstreamProjectsTrackerFile(upstreamProjectsTrackerFilePath, projectOrg, projectRepo)
-				if err != nil {
-					return fmt.Errorf("reloading upstream projects tracker file: %v", err)
-				}
-				targetRepo.Versions[0].Tag = latestRevision
-
-				// Update the Git tag file corresponding to the project
-				logger.Info("Updating Git tag file corresponding to the project")
-				projectGitTagRelativePath, err := updateProjectVersionFile(buildToolingRepoPath, constants.GitTagFile, projectName, latestRevision)
-				if err != nil {
-					return fmt.Errorf("updating project GIT_TAG file: %v", err)
-				}
-				updatedFiles = append(updatedFiles, projectGitTagRelativePath)
-
-				var latestGoVersion string
-				if currentVersion.GoVersion != "N/A" {
-					currentGoVersion := currentVersion.GoVersion
-					// Get Go version corresponding to the latest revision of the project.
-					latestGoVersion, err := github.GetGoVersionForLatestRevision(client, projectOrg, projectRepo, latestRevision)
-					if err != nil {
-						return fmt.Errorf("getting latest Go version for release %s: %v", latestRevision, err)
-					}
-
-					// Get the minor version for the current revision's Go version.
-					currentGoMinorVersion, err := strconv.Atoi(strings.Split(currentGoVersion, ".")[1])
+				// Summarize the upstream commits for commit-pinned projects, since there are no release notes to link to.
+				if commitPinned {
+					commitLog, err := github.GetCommitLog(client, projectOrg, projectRepo, currentRevision, latestRevision)
 					if err != nil {
-						return fmt.Errorf("getting current Go minor version: %v", err)
-					}
-
-					// Get the major version for the latest revision's Go version.
-					latestGoMinorVersion, err := strconv.Atoi(strings.Split(latestGoVersion, ".")[1])
-					if err != nil {
-						return fmt.Errorf("getting latest Go minor version: %v", err)
-					}
-
-					// If the Go version has been updated in the latest revision, then update the Go version file corresponding to the project.
-					if latestGoMinorVersion > currentGoMinorVersion {
-						logger.Info("Project Go version needs to be updated.", "Current Go version", currentGoVersion, "Latest Go version", latestGoVersion)
-						targetRepo.Versions[0].GoVersion = latestGoVersion
-
-						logger.Info("Updating Go version file corresponding to the project")
-						projectGoVersionRelativePath, err := updateProjectVersionFile(buildToolingRepoPath, constants.GoVersionFile, projectName, latestGoVersion)
-						if err != nil {
-							return fmt.Errorf("updating project GOLANG_VERSION file: %v", err)
-						}
-						updatedFiles = append(updatedFiles, projectGoVersionRelativePath)
+						logger.Warn("Unable to get commit log", "Project", projectName, "Error", err)
+					} else {
+						pullRequestBody += getCommitLogSection(commitLog, currentRevision, latestRevision)
 					}
-				} else {
-					latestGoVersion = "N/A"
-					targetRepo.Versions[0].GoVersion = latestGoVersion
 				}
 
-				// Update the tag and Go version in the section of the upstream projects tracker file corresponding to the given project.
-				logger.Info("Updating Git tag and Go version in upstream projects tracker file")
-				err = updateUpstreamProjectsTrackerFile(&projectsList, targetRepo, buildToolingRepoPath, upstreamProjectsTrackerFilePath, latestRevision, latestGoVersion)
+				checksSections, checksLabels, checksBreakingChanges, err := checkUpgrade(client, upgradeOptions, buildToolingRepoPath, currentVersion.GoVersion, currentRevision, latestRevision)
 				if err != nil {
-					return fmt.Errorf("updating upstream projects tracker file: %v", err)
+					return err
 				}
-				updatedFiles = append(updatedFiles, constants.UpstreamProjectsTrackerFile)
+				pullRequestBody += checksSections
+				pullRequestLabels = append(pullRequestLabels, checksLabels...)
+				breakingChanges = checksBreakingChanges
 
-				// Update the version in the project's README file.
-				logger.Info("Updating project README file")
-				projectReadmePath := filepath.Join(projectPath, constants.ReadmeFile)
-				err = updateProjectReadmeVersion(buildToolingRepoPath, projectOrg, projectRepo)
+				projectUpdatedFiles, projectPatchesWarningComment, err := UpdateProjectVersionFiles(client, buildToolingRepoPath, projectName, currentVersion, latestRevision)
 				if err != nil {
-					return fmt.Errorf("updating version in project README: %v", err)
+					return fmt.Errorf("updating project version files: %v", err)
 				}
-				updatedFiles = append(updatedFiles, projectReadmePath)
+				updatedFiles = append(updatedFiles, projectUpdatedFiles...)
 
-				// If project has patches, attempt to apply them. Track failed patches and files that failed to apply, if any.
-				if projectHasPatches {
-					appliedPatchesCount, failedPatch, applyFailedFiles, err := applyPatchesToRepo(projectRootFilepath, projectRepo, latestRevision, totalPatchCount)
-					if appliedPatchesCount == totalPatchCount {
-						patchApplySucceeded = true
-					}
+				if !upgradeOptions.DryRun {
+					historyEntryRelativePath, err := RecordUpgradeInHistory(buildToolingRepoPath, projectName, "", currentRevision, latestRevision)
 					if err != nil {
-						return fmt.Errorf("applying patches to repository: %v", err)
-					}
-					if !patchApplySucceeded {
-						addPatchWarningComment = true
-						patchesWarningComment = fmt.Sprintf(constants.PatchesCommentBody, appliedPatchesCount, totalPatchCount, failedPatch, applyFailedFiles)
+						return err
 					}
+					updatedFiles = append(updatedFiles, historyEntryRelativePath)
 				}
-
-				// If project doesn't have patches, or it does and they were applied successfully, then update the checksums file
-				// and attribution file(s) corresponding to the project.
-				if !projectHasPatches || patchApplySucceeded {
-					if _, err := os.Stat(filepath.Join(projectRootFilepath, constants.ChecksumsFile)); err == nil {
-						logger.Info("Updating project checksums and attribution files")
-						projectChecksumsFileRelativePath := filepath.Join(projectPath, constants.ChecksumsFile)
-						err = updateChecksumsAttributionFiles(projectRootFilepath)
-						if err != nil {
-							return fmt.Errorf("updating project checksums and attribution files: %v", err)
-						}
-						updatedFiles = append(updatedFiles, projectChecksumsFileRelativePath)
-
-						// Attribution files can have a binary name prefix so we use a common prefix regular expression
-						// and glob them to cover all possibilities.
-						projectAttributionFileGlob, err := filepath.Glob(filepath.Join(projectRootFilepath, constants.AttributionsFilePattern))
-						if err != nil {
-							return fmt.Errorf("finding filenames matching attribution file pattern [%s]: %v", constants.AttributionsFilePattern, err)
-						}
-						for _, attributionFile := range projectAttributionFileGlob {
-							attributionFileRelativePath, err := filepath.Rel(buildToolingRepoPath, attributionFile)
-							if err != nil {
-								return fmt.Errorf("getting relative path for attribution file: %v", err)
-							}
-							updatedFiles = append(updatedFiles, attributionFileRelativePath)
-						}
-					}
+				if projectPatchesWarningComment != "" {
+					addPatchWarningComment = true
+					patchesWarningComment = projectPatchesWarningComment
 				}
 
 				if projectName == "cilium/cilium" {
@@ -315,6 +272,11 @@ func Run(upgradeOptions *types.UpgradeOptions) error {
 					}
 					updatedFiles = append(updatedFiles, updatedCiliumImageDigestFiles...)
 				}
+
+				// Build the project at the latest revision to let reviewers know up front whether the upgrade is green.
+				if upgradeOptions.Simulate {
+					simulationSection = simulateBuild(projectRootFilepath, projectPath, "")
+				}
 			}
 
 			if projectName == "kubernetes-sigs/image-builder" {
@@ -325,11 +287,13 @@ func Run(upgradeOptions *types.UpgradeOptions) error {
 				if len(updatedBRFiles) > 0 {
 					updatedFiles = append(updatedFiles, updatedBRFiles...)
 					if len(updatedFiles) == len(updatedBRFiles) {
-						headBranchName = "update-bottlerocket-releases"
+						headBranchName = constants.BottlerocketUpgradeBranchName
 						commitMessage = "Bump Bottlerocket versions to latest release"
 						pullRequestBody = fmt.Sprintf(constants.BottlerocketUpgradePullRequestBody, currentBottlerocketVersion, latestBottlerocketVersion)
 					} else {
 						headBranchName = fmt.Sprintf("update-%s-%s-and-bottlerocket", projectOrg, projectRepo)
+						// The combined pull request replaces any open pull requests upgrading either of them individually.
+						supersededBranches = []string{fmt.Sprintf("update-%s-%s", projectOrg, projectRepo), constants.BottlerocketUpgradeBranchName}
 						commitMessage = fmt.Sprintf("Bump %s and Bottlerocket versions to latest release", projectName)
 						pullRequestBody = fmt.Sprintf(constants.CombinedImageBuilderBottlerocketUpgradePullRequestBody, currentRevision, latestRevision, currentBottlerocketVersion, latestBottlerocketVersion)
 					}
@@ -363,23 +327,839 @@ func Run(upgradeOptions *types.UpgradeOptions) error {
 			return nil
 		}
 
+		pullRequestBody += simulationSection
+
+		// Render the pull request title and body from the configured templates, if any.
+		var pullRequestTitle string
+		pullRequestTitle, pullRequestBody, err = renderPullRequest(upgradeOptions, buildToolingRepoPath, types.PullRequestTemplateData{
+			Project:         projectName,
+			Org:             projectOrg,
+			Repo:            projectRepo,
+			CurrentVersion:  currentRevision,
+			LatestVersion:   latestRevision,
+			BreakingChanges: breakingChanges,
+			DefaultTitle:    commitMessage,
+			DefaultBody:     pullRequestBody,
+		})
+		if err != nil {
+			return fmt.Errorf("rendering pull request templates: %v", err)
+		}
+
 		// Push the changes to the target branch in the head repository.
+		pushSpan := tracing.Start("push-branch", "Branch", headBranchName)
 		err = git.Push(repo, headRepoOwner, headBranchName, githubToken)
+		pushSpan.End(err)
 		if err != nil {
 			return fmt.Errorf("pushing updated project version files for [%s] project: %v", projectName, err)
 		}
 
 		// Create a pull request from the bramch in the head repository to the target branch in the aws/eks-anywhere-build-tooling repository.
 		logger.Info("Creating pull request with updated files")
-		err = github.CreatePullRequest(client, projectOrg, projectRepo, commitMessage, pullRequestBody, baseRepoOwner, baseBranchName, headRepoOwner, headBranchName, currentRevision, latestRevision, addPatchWarningComment, patchesWarningComment)
+		pullRequestSpan := tracing.Start("create-pull-request", "Branch", headBranchName)
+		err = github.CreatePullRequest(client, projectOrg, projectRepo, pullRequestTitle, pullRequestBody, baseRepoOwner, baseBranchName, headRepoOwner, headBranchName, currentRevision, latestRevision, addPatchWarningComment, patchesWarningComment, pullRequestLabels)
+		pullRequestSpan.End(err)
 		if err != nil {
+			RollBackPullRequestBranch(client, repo, worktree, headCommit, baseRepoOwner, baseBranchName, headRepoOwner, headBranchName)
 			return fmt.Errorf("creating pull request to %s repository: %v", constants.BuildToolingRepoName, err)
 		}
+
+		if len(supersededBranches) > 0 {
+			err = github.CloseSupersededPullRequests(client, baseRepoOwner, baseBranchName, headRepoOwner, headBranchName, supersededBranches)
+			if err != nil {
+				return fmt.Errorf("closing superseded pull requests: %v", err)
+			}
+		}
+
+		err = RecordPullRequestInHistory(client, repo, worktree, buildToolingRepoPath, []string{projectName}, baseRepoOwner, baseBranchName, headRepoOwner, headBranchName, githubToken)
+		if err != nil {
+			return fmt.Errorf("recording pull request in upgrade history: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// upgradeReleaseBranchedProject upgrades the revision tracked for each release branch of a release-branched project
+// to the latest patch revision in the same minor version line. Depending on the pull request policy, the upgrades
+// are either proposed in a separate pull request per release branch or combined into a single pull request.
+func upgradeReleaseBranchedProject(client *gogithub.Client, repo *gogit.Repository, worktree *gogit.Worktree, headCommit string, upgradeOptions *types.UpgradeOptions, buildToolingRepoPath, baseRepoOwner, headRepoOwner, githubToken string) error {
+	projectName := upgradeOptions.ProjectName
+	projectOrg, projectRepo := projects.SplitName(projectName)
+	projectRootFilepath := projects.RootPath(buildToolingRepoPath, projectName)
+	upstreamProjectsTrackerFilePath := filepath.Join(buildToolingRepoPath, constants.UpstreamProjectsTrackerFile)
+
+	pullRequestPolicy := upgradeOptions.PullRequestPolicy
+	if pullRequestPolicy == "" {
+		pullRequestPolicy = constants.PerReleaseBranchPullRequestPolicy
+	}
+	if pullRequestPolicy != constants.PerReleaseBranchPullRequestPolicy && pullRequestPolicy != constants.CombinedPullRequestPolicy {
+		return fmt.Errorf("invalid pull request policy %s, must be one of %s or %s", pullRequestPolicy, constants.PerReleaseBranchPullRequestPolicy, constants.CombinedPullRequestPolicy)
+	}
+
+	// The revisions in the upstream projects tracker file are listed in the order of the supported release branches.
+	releaseBranches, err := getSupportedReleaseBranches(buildToolingRepoPath)
+	if err != nil {
+		return fmt.Errorf("getting supported release branches: %v", err)
+	}
+	_, targetRepo, err := loadUpstreamProjectsTrackerFile(upstreamProjectsTrackerFilePath, projectOrg, projectRepo)
+	if err != nil {
+		return fmt.Errorf("loading upstream projects tracker file: %v", err)
+	}
+	if len(targetRepo.Versions) != len(releaseBranches) {
+		return fmt.Errorf("project has %d versions in upstream projects tracker file but there are %d supported release branches", len(targetRepo.Versions), len(releaseBranches))
+	}
+
+	upgrades := []types.ReleaseBranchUpgrade{}
+	for i, releaseBranch := range releaseBranches {
+		currentRevision := targetRepo.Versions[i].Tag
+		if currentRevision == "" {
+			return fmt.Errorf("projects tracked with commit hashes not supported at this time")
+		}
+
+		latestRevision, needsUpgrade, err := github.GetLatestPatchRevision(client, projectOrg, projectRepo, currentRevision)
+		if err != nil {
+			return fmt.Errorf("getting latest patch revision for %s release branch from GitHub: %v", releaseBranch, err)
+		}
+		if !needsUpgrade {
+			logger.Info("Release branch is at the latest available version.", "Release branch", releaseBranch, "Current version", currentRevision)
+			continue
+		}
+		logger.Info("Release branch is out of date.", "Release branch", releaseBranch, "Current version", currentRevision, "Latest version", latestRevision)
+		upgrades = append(upgrades, types.ReleaseBranchUpgrade{
+			Index:           i,
+			ReleaseBranch:   releaseBranch,
+			CurrentRevision: currentRevision,
+			LatestRevision:  latestRevision,
+		})
+	}
+	if len(upgrades) == 0 {
+		return nil
+	}
+
+	// Group the release branch upgrades into the pull requests to create according to the policy.
+	upgradeGroups := [][]types.ReleaseBranchUpgrade{upgrades}
+	if pullRequestPolicy == constants.PerReleaseBranchPullRequestPolicy {
+		upgradeGroups = [][]types.ReleaseBranchUpgrade{}
+		for _, upgrade := range upgrades {
+			upgradeGroups = append(upgradeGroups, []types.ReleaseBranchUpgrade{upgrade})
+		}
+	}
+
+	for _, upgradeGroup := range upgradeGroups {
+		headBranchName := fmt.Sprintf("update-%s-%s", projectOrg, projectRepo)
+		commitMessage := fmt.Sprintf("Bump %s to latest patch releases", projectName)
+		pullRequestBody := ""
+		if len(upgradeGroup) == 1 {
+			headBranchName = fmt.Sprintf("%s-%s", headBranchName, upgradeGroup[0].ReleaseBranch)
+			commitMessage = fmt.Sprintf("Bump %s to latest release for %s release branch", projectName, upgradeGroup[0].ReleaseBranch)
+		}
+
+		// Checkout a new branch to keep track of version upgrade changes.
+		err = git.Checkout(worktree, headBranchName)
+		if err != nil {
+			return fmt.Errorf("checking out worktree at branch %s: %v", headBranchName, err)
+		}
+
+		// Reset current worktree to get a clean index.
+		err = git.ResetToMain(worktree, headCommit)
+		if err != nil {
+			return fmt.Errorf("resetting new branch to [origin/main] HEAD: %v", err)
+		}
+
+		// Reload upstream projects tracker file to get its original value instead of the updated one from
+		// a previous release branch's upgrade.
+		projectsList, targetRepo, err := loadUpstreamProjectsTrackerFile(upstreamProjectsTrackerFilePath, projectOrg, projectRepo)
+		if err != nil {
+			return fmt.Errorf("reloading upstream projects tracker file: %v", err)
+		}
+
+		updatedFiles := []string{}
+		upgradeLines := []string{}
+		patchesNotes := ""
+		checksSections := ""
+		simulationSections := ""
+		pullRequestLabels := []string{}
+		breakingChanges := []types.MarkedChange{}
+		for _, upgrade := range upgradeGroup {
+			releaseBranch := upgrade.ReleaseBranch
+			upgradeLines = append(upgradeLines, fmt.Sprintf(constants.ReleaseBranchUpgradeLineFormat, projectOrg, projectRepo, releaseBranch, upgrade.CurrentRevision, upgrade.LatestRevision))
+
+			releaseBranchChecksSections, releaseBranchChecksLabels, releaseBranchBreakingChanges, err := checkUpgrade(client, upgradeOptions, buildToolingRepoPath, targetRepo.Versions[upgrade.Index].GoVersion, upgrade.CurrentRevision, upgrade.LatestRevision)
+			if err != nil {
+				return err
+			}
+			checksSections += releaseBranchChecksSections
+			for _, label := range releaseBranchChecksLabels {
+				if !slices.Contains(pullRequestLabels, label) {
+					pullRequestLabels = append(pullRequestLabels, label)
+				}
+			}
+			breakingChanges = append(breakingChanges, releaseBranchBreakingChanges...)
+			targetRepo.Versions[upgrade.Index].Tag = upgrade.LatestRevision
+
+			if !upgradeOptions.DryRun {
+				historyEntryRelativePath, err := RecordUpgradeInHistory(buildToolingRepoPath, projectName, releaseBranch, upgrade.CurrentRevision, upgrade.LatestRevision)
+				if err != nil {
+					return err
+				}
+				updatedFiles = append(updatedFiles, historyEntryRelativePath)
+			}
+
+			logger.Info("Updating Git tag file corresponding to the release branch", "Release branch", releaseBranch)
+			projectGitTagRelativePath, err := updateProjectVersionFile(buildToolingRepoPath, filepath.Join(releaseBranch, constants.GitTagFile), projectName, upgrade.LatestRevision)
+			if err != nil {
+				return fmt.Errorf("updating project GIT_TAG file for %s release branch: %v", releaseBranch, err)
+			}
+			updatedFiles = append(updatedFiles, projectGitTagRelativePath)
+
+			patchesDirectory, err := makefile.GetVariableValue(projectRootFilepath, "PATCHES_DIR", releaseBranch)
+			if err != nil {
+				return fmt.Errorf("getting project patches directory for %s release branch: %v", releaseBranch, err)
+			}
+			if patchesDirectory != "" {
+				patchesNotes += fmt.Sprintf(constants.ReleaseBranchPatchesPullRequestNote, releaseBranch)
+			} else if _, err := os.Stat(filepath.Join(projectRootFilepath, releaseBranch, constants.ChecksumsFile)); err == nil {
+				// The checksums and attribution files are tracked per release branch.
+				logger.Info("Updating project checksums and attribution files", "Release branch", releaseBranch)
+				err = updateReleaseBranchChecksumsAttributionFiles(projectRootFilepath, releaseBranch)
+				if err != nil {
+					return fmt.Errorf("updating project checksums and attribution files for %s release branch: %v", releaseBranch, err)
+				}
+				updatedFiles = append(updatedFiles, filepath.Join("projects", projectName, releaseBranch, constants.ChecksumsFile))
+
+				projectAttributionFileGlob, err := filepath.Glob(filepath.Join(projectRootFilepath, releaseBranch, constants.AttributionsFilePattern))
+				if err != nil {
+					return fmt.Errorf("finding filenames matching attribution file pattern [%s]: %v", constants.AttributionsFilePattern, err)
+				}
+				for _, attributionFile := range projectAttributionFileGlob {
+					attributionFileRelativePath, err := filepath.Rel(buildToolingRepoPath, attributionFile)
+					if err != nil {
+						return fmt.Errorf("getting relative path for attribution file: %v", err)
+					}
+					updatedFiles = append(updatedFiles, attributionFileRelativePath)
+				}
+			}
+
+			// Build the release branch at the latest revision to let reviewers know up front whether the upgrade is green.
+			if upgradeOptions.Simulate {
+				simulationSections += simulateBuild(projectRootFilepath, projects.Path(projectName), releaseBranch)
+			}
+		}
+
+		logger.Info("Updating Git tags in upstream projects tracker file")
+		err = updateUpstreamProjectsTrackerFile(&projectsList, targetRepo, buildToolingRepoPath, upstreamProjectsTrackerFilePath, "", "")
+		if err != nil {
+			return fmt.Errorf("updating upstream projects tracker file: %v", err)
+		}
+		updatedFiles = append(updatedFiles, constants.UpstreamProjectsTrackerFile)
+
+		logger.Info("Updating project README file")
+		err = updateProjectReadmeVersion(buildToolingRepoPath, projectOrg, projectRepo)
+		if err != nil {
+			return fmt.Errorf("updating version in project README: %v", err)
+		}
+		updatedFiles = append(updatedFiles, filepath.Join("projects", projectName, constants.ReadmeFile))
+
+		if len(upgradeGroup) == 1 {
+			pullRequestBody = fmt.Sprintf(constants.DefaultUpgradePullRequestBody, projectOrg, projectRepo, upgradeGroup[0].CurrentRevision, upgradeGroup[0].LatestRevision)
+		} else {
+			pullRequestBody = fmt.Sprintf(constants.ReleaseBranchedUpgradePullRequestBody, projectOrg, projectRepo, strings.Join(upgradeLines, "\n"))
+		}
+		pullRequestBody += checksSections + patchesNotes
+
+		err = git.Add(worktree, updatedFiles)
+		if err != nil {
+			return fmt.Errorf("adding updated files to index: %v", err)
+		}
+
+		err = git.Commit(worktree, commitMessage)
+		if err != nil {
+			return fmt.Errorf("committing updated project version files for [%s] project: %v", projectName, err)
+		}
+
+		if upgradeOptions.DryRun {
+			logger.Info(fmt.Sprintf("Completed dry run of upgrade for project %s", projectName), "Branch", headBranchName)
+			continue
+		}
+
+		pullRequestBody += simulationSections
+
+		pullRequestTitle, pullRequestBody, err := renderPullRequest(upgradeOptions, buildToolingRepoPath, types.PullRequestTemplateData{
+			Project:         projectName,
+			Org:             projectOrg,
+			Repo:            projectRepo,
+			ReleaseBranch:   upgradeGroup[0].ReleaseBranch,
+			CurrentVersion:  upgradeGroup[0].CurrentRevision,
+			LatestVersion:   upgradeGroup[0].LatestRevision,
+			BreakingChanges: breakingChanges,
+			DefaultTitle:    commitMessage,
+			DefaultBody:     pullRequestBody,
+		})
+		if err != nil {
+			return fmt.Errorf("rendering pull request templates: %v", err)
+		}
+
+		pushSpan := tracing.Start("push-branch", "Branch", headBranchName)
+		err = git.Push(repo, headRepoOwner, headBranchName, githubToken)
+		pushSpan.End(err)
+		if err != nil {
+			return fmt.Errorf("pushing updated project version files for [%s] project: %v", projectName, err)
+		}
+
+		logger.Info("Creating pull request with updated files", "Branch", headBranchName)
+		pullRequestSpan := tracing.Start("create-pull-request", "Branch", headBranchName)
+		err = github.CreatePullRequest(client, projectOrg, projectRepo, pullRequestTitle, pullRequestBody, baseRepoOwner, constants.MainBranchName, headRepoOwner, headBranchName, upgradeGroup[0].CurrentRevision, upgradeGroup[0].LatestRevision, false, "", pullRequestLabels)
+		pullRequestSpan.End(err)
+		if err != nil {
+			RollBackPullRequestBranch(client, repo, worktree, headCommit, baseRepoOwner, constants.MainBranchName, headRepoOwner, headBranchName)
+			return fmt.Errorf("creating pull request to %s repository: %v", constants.BuildToolingRepoName, err)
+		}
+
+		// A combined pull request replaces any open pull requests upgrading its release branches individually.
+		if len(upgradeGroup) > 1 {
+			supersededBranches := []string{}
+			for _, upgrade := range upgradeGroup {
+				supersededBranches = append(supersededBranches, fmt.Sprintf("update-%s-%s-%s", projectOrg, projectRepo, upgrade.ReleaseBranch))
+			}
+			err = github.CloseSupersededPullRequests(client, baseRepoOwner, constants.MainBranchName, headRepoOwner, headBranchName, supersededBranches)
+			if err != nil {
+				return fmt.Errorf("closing superseded pull requests: %v", err)
+			}
+		}
+
+		err = RecordPullRequestInHistory(client, repo, worktree, buildToolingRepoPath, []string{projectName}, baseRepoOwner, constants.MainBranchName, headRepoOwner, headBranchName, githubToken)
+		if err != nil {
+			return fmt.Errorf("recording pull request in upgrade history: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// checkUpgrade runs the checks shared by the upgrades of all projects and release branches before they are proposed.
+// It scans the upstream release notes and commit messages between the current and latest revisions for breaking
+// changes and checks that the Go version required by the latest revision is available in this repository, unless
+// the project's Go version is not tracked. It returns the pull request body sections and labels for the findings,
+// along with the breaking changes found. Cilium is skipped, since its revisions come from ECR Public.
+func checkUpgrade(client *gogithub.Client, upgradeOptions *types.UpgradeOptions, buildToolingRepoPath, goVersion, currentRevision, latestRevision string) (string, []string, []types.MarkedChange, error) {
+	projectName := upgradeOptions.ProjectName
+	if projectName == "cilium/cilium" {
+		return "", nil, nil, nil
+	}
+	projectOrg, projectRepo := projects.SplitName(projectName)
+
+	pullRequestSections := ""
+	pullRequestLabels := []string{}
+
+	// Scan the upstream release notes and commit messages for breaking changes and call them out in the pull
+	// request, so that reviewers can assess the risk of the upgrade.
+	breakingChanges, err := github.GetBreakingChanges(client, projectOrg, projectRepo, currentRevision, latestRevision)
+	if err != nil {
+		logger.Warn("Unable to detect breaking changes", "Project", projectName, "Error", err)
+	} else if len(breakingChanges) > 0 {
+		logger.Warn("Found potential breaking changes in upstream release", "Count", len(breakingChanges))
+		pullRequestSections += getBreakingChangesSection(breakingChanges, currentRevision, latestRevision)
+		if upgradeOptions.BreakingChangeLabel != "" {
+			pullRequestLabels = append(pullRequestLabels, upgradeOptions.BreakingChangeLabel)
+		}
+	}
+
+	// Check that the Go version required by the latest revision is available in this repository before proposing
+	// the upgrade.
+	if goVersion != "N/A" {
+		goToolchainSection, err := CheckGoToolchainAvailability(client, buildToolingRepoPath, projectOrg, projectRepo, latestRevision)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("checking Go toolchain availability: %v", err)
+		}
+		if goToolchainSection != "" {
+			if upgradeOptions.RequireGoToolchain {
+				return "", nil, nil, fmt.Errorf("Go version required by %s revision %s is not available", projectName, latestRevision)
+			}
+			pullRequestSections += goToolchainSection
+		}
+	}
+
+	return pullRequestSections, pullRequestLabels, breakingChanges, nil
+}
+
+// RecordUpgradeInHistory records the upgrade of a project, or of one of its release branches, in the upgrade history
+// and returns the path of the entry file relative to the build-tooling repository. It is not called on dry runs, so
+// that the history only holds upgrades that were proposed.
+func RecordUpgradeInHistory(buildToolingRepoPath, projectName, releaseBranch, currentRevision, latestRevision string) (string, error) {
+	historyEntryFilepath, err := history.RecordUpgrade(filepath.Join(buildToolingRepoPath, constants.UpgradeHistoryDirectory), projectName, releaseBranch, currentRevision, latestRevision)
+	if err != nil {
+		return "", fmt.Errorf("recording upgrade in upgrade history: %v", err)
+	}
+
+	historyEntryRelativePath, err := filepath.Rel(buildToolingRepoPath, historyEntryFilepath)
+	if err != nil {
+		return "", fmt.Errorf("getting relative path for upgrade history entry file: %v", err)
+	}
+
+	return historyEntryRelativePath, nil
+}
+
+// updateReleaseBranchChecksumsAttributionFiles runs the attribution-checksums Make target for the given release
+// branch of a release-branched project.
+func updateReleaseBranchChecksumsAttributionFiles(projectRootFilepath, releaseBranch string) error {
+	span := tracing.Start("update-checksums-attribution", "Release branch", releaseBranch)
+	updateChecksumsAttributionCommandSequence := fmt.Sprintf("%s -C %s attribution-checksums RELEASE_BRANCH=%s", environment.Make(), projectRootFilepath, releaseBranch)
+	updateChecksumsAttributionCmd := exec.Command("bash", "-c", updateChecksumsAttributionCommandSequence)
+	_, err := command.ExecCommand(updateChecksumsAttributionCmd)
+	span.End(err)
+	if err != nil {
+		return fmt.Errorf("running checksums-attribution Make command: %v", err)
+	}
+
+	return nil
+}
+
+// CheckGoToolchainAvailability compares the Go version required by the go directive in the project's go.mod file
+// at the latest revision against the Go versions used to build projects in this repository. If the required Go
+// version is newer than all of them, it returns a pull request body section calling this out.
+func CheckGoToolchainAvailability(client *gogithub.Client, buildToolingRepoPath, projectOrg, projectRepo, latestRevision string) (string, error) {
+	goModContents, err := github.GetFileContents(client, projectOrg, projectRepo, constants.GoModFile, latestRevision)
+	if err != nil {
+		// Not all projects have a go.mod file at the root of the repository, so there is nothing to check.
+		logger.V(6).Info(fmt.Sprintf("Unable to get go.mod file for [%s/%s] repository at revision %s: %v", projectOrg, projectRepo, latestRevision, err))
+		return "", nil
+	}
+
+	goModFile, err := gomod.ParseModFile(goModContents)
+	if err != nil {
+		return "", fmt.Errorf("parsing go.mod file: %v", err)
+	}
+	if goModFile.Go == "" {
+		return "", nil
+	}
+	requiredMajorVersion, requiredMinorVersion, err := gomod.ParseGoMinorVersion(goModFile.Go)
+	if err != nil {
+		return "", fmt.Errorf("parsing go directive: %v", err)
+	}
+
+	latestAvailableGoVersion, err := getLatestAvailableGoVersion(buildToolingRepoPath)
+	if err != nil {
+		return "", fmt.Errorf("getting latest available Go version: %v", err)
+	}
+	availableMajorVersion, availableMinorVersion, err := gomod.ParseGoMinorVersion(latestAvailableGoVersion)
+	if err != nil {
+		return "", fmt.Errorf("parsing latest available Go version: %v", err)
+	}
+
+	if requiredMajorVersion < availableMajorVersion || (requiredMajorVersion == availableMajorVersion && requiredMinorVersion <= availableMinorVersion) {
+		return "", nil
+	}
+
+	toolchain := goModFile.Toolchain
+	if toolchain == "" {
+		toolchain = "N/A"
+	}
+	requiredGoVersion := fmt.Sprintf("%d.%d", requiredMajorVersion, requiredMinorVersion)
+	logger.Warn("Go version required by latest revision is not available.", "Required Go version", requiredGoVersion, "Toolchain", toolchain, "Latest available Go version", latestAvailableGoVersion)
+
+	return fmt.Sprintf(constants.GoToolchainUnavailablePullRequestSection, latestRevision, requiredGoVersion, toolchain, latestAvailableGoVersion), nil
+}
+
+// getLatestAvailableGoVersion returns the newest Go minor version configured in the GOLANG_VERSION files of the
+// projects in this repository, which are built with the Go versions installed in the builder-base image.
+func getLatestAvailableGoVersion(buildToolingRepoPath string) (string, error) {
+	goVersionFiles := []string{}
+	for _, goVersionFilesGlob := range []string{constants.ProjectGoVersionFilesGlob, constants.ReleaseBranchGoVersionFilesGlob} {
+		matchingFiles, err := filepath.Glob(filepath.Join(buildToolingRepoPath, goVersionFilesGlob))
+		if err != nil {
+			return "", fmt.Errorf("finding Go version files: %v", err)
+		}
+		goVersionFiles = append(goVersionFiles, matchingFiles...)
+	}
+
+	var latestGoVersion string
+	var latestMajorVersion, latestMinorVersion int
+	for _, goVersionFile := range goVersionFiles {
+		contents, err := os.ReadFile(goVersionFile)
+		if err != nil {
+			return "", fmt.Errorf("reading Go version file [%s]: %v", goVersionFile, err)
+		}
+		goVersion := strings.TrimSpace(string(contents))
+		majorVersion, minorVersion, err := gomod.ParseGoMinorVersion(goVersion)
+		if err != nil {
+			continue
+		}
+		if majorVersion > latestMajorVersion || (majorVersion == latestMajorVersion && minorVersion > latestMinorVersion) {
+			latestGoVersion = goVersion
+			latestMajorVersion, latestMinorVersion = majorVersion, minorVersion
+		}
+	}
+	if latestGoVersion == "" {
+		return "", fmt.Errorf("no Go versions found in project Go version files")
+	}
+
+	return latestGoVersion, nil
+}
+
+// simulateBuild runs the project build with the updated version files, which checks out the upstream repository
+// at the latest revision, applies patches and builds the project, and returns a pull request body section with
+// the result. Release-branched projects are built for the given release branch. Failures are classified using the
+// same rules as the `triage` subcommand.
+func simulateBuild(projectRootFilepath, projectPath, releaseBranch string) string {
+	logger.Info("Simulating project build at the latest revision", "Release branch", releaseBranch)
+	if err := disk.CheckFreeSpace(projectRootFilepath); err != nil {
+		logger.Warn("Skipping project build simulation", "Error", err)
+		return getBuildSimulationSection(releaseBranch, "Skipped", fmt.Sprintf("The build was not simulated: %v.", err))
+	}
+	span := tracing.Start("simulate-build", "Release branch", releaseBranch)
+	buildLog, buildSucceeded := triage.BuildProject(projectRootFilepath, releaseBranch)
+	span.SetAttributes("Succeeded", buildSucceeded)
+	span.End(nil)
+	if buildSucceeded {
+		logger.Info("Project build simulation succeeded")
+		return getBuildSimulationSection(releaseBranch, "Succeeded", "The project builds successfully at the latest revision.")
+	}
+
+	logger.Warn("Project build simulation failed")
+	buildFailures := triage.ClassifyBuildFailures(buildLog, projectPath)
+	if len(buildFailures) == 0 {
+		return getBuildSimulationSection(releaseBranch, "Failed", "The build failure could not be classified. Run `make build` for the project locally to inspect the build log.")
+	}
+	buildFailureLines := []string{}
+	for _, buildFailure := range buildFailures {
+		buildFailureLines = append(buildFailureLines, fmt.Sprintf("* **%s**: `%s`\n  %s", buildFailure.Category, buildFailure.Evidence, buildFailure.Suggestion))
+	}
+
+	return getBuildSimulationSection(releaseBranch, "Failed", strings.Join(buildFailureLines, "\n"))
+}
+
+// getBuildSimulationSection returns the pull request body section with the build simulation result, titled with
+// the release branch for release-branched projects.
+func getBuildSimulationSection(releaseBranch, result, details string) string {
+	if releaseBranch != "" {
+		return fmt.Sprintf(constants.ReleaseBranchBuildSimulationPullRequestSection, releaseBranch, result, details)
+	}
+	return fmt.Sprintf(constants.BuildSimulationPullRequestSection, result, details)
+}
+
+// RollBackPullRequestBranch cleans up after a failure to create the pull request for a pushed branch, so that reruns
+// start clean instead of finding an orphaned branch. The branch is deleted from the head repository unless it has an
+// open pull request, which would be closed by deleting it, and the local branch is deleted. Failures are logged
+// rather than returned, since the pull request creation error is the one to surface.
+func RollBackPullRequestBranch(client *gogithub.Client, repo *gogit.Repository, worktree *gogit.Worktree, headCommit, baseRepoOwner, baseBranch, headRepoOwner, headBranch string) {
+	logger.Warn("Rolling back branch after failing to create pull request", "Branch", headBranch)
+
+	hasOpenPullRequest, err := github.HasOpenPullRequest(client, baseRepoOwner, baseBranch, headRepoOwner, headBranch)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Unable to check for open pull requests, leaving branch in %s/%s repository", headRepoOwner, constants.BuildToolingRepoName), "Branch", headBranch, "Error", err)
+	} else if !hasOpenPullRequest {
+		err = github.DeleteBranch(client, headRepoOwner, constants.BuildToolingRepoName, headBranch)
+		if err != nil {
+			logger.Warn("Unable to delete pushed branch", "Branch", headBranch, "Error", err)
+		}
+	}
+
+	err = git.DeleteBranch(repo, worktree, headBranch, headCommit)
+	if err != nil {
+		logger.Warn("Unable to delete local branch", "Branch", headBranch, "Error", err)
+	}
+}
+
+// RecordPullRequestInHistory records the pull request created for the upgrade of the given projects in the upgrade
+// history entries added by the upgrade. Since the pull request only exists once the upgrade commit has been pushed,
+// the entry files are updated in a follow-up commit on the same branch.
+func RecordPullRequestInHistory(client *gogithub.Client, repo *gogit.Repository, worktree *gogit.Worktree, buildToolingRepoPath string, projectNames []string, baseRepoOwner, baseBranch, headRepoOwner, headBranch, githubToken string) error {
+	pullRequestURL, err := github.GetPullRequestURL(client, baseRepoOwner, baseBranch, headRepoOwner, headBranch)
+	if err != nil {
+		return err
+	}
+
+	updatedFiles := []string{}
+	for _, projectName := range projectNames {
+		updatedFilepaths, err := history.RecordPullRequest(filepath.Join(buildToolingRepoPath, constants.UpgradeHistoryDirectory), projectName, pullRequestURL)
+		if err != nil {
+			return err
+		}
+		for _, updatedFilepath := range updatedFilepaths {
+			updatedRelativePath, err := filepath.Rel(buildToolingRepoPath, updatedFilepath)
+			if err != nil {
+				return fmt.Errorf("getting relative path for upgrade history entry file: %v", err)
+			}
+			updatedFiles = append(updatedFiles, updatedRelativePath)
+		}
+	}
+	if len(updatedFiles) == 0 {
+		return nil
+	}
+
+	err = git.Add(worktree, updatedFiles)
+	if err != nil {
+		return fmt.Errorf("adding upgrade history entry files to index: %v", err)
+	}
+
+	err = git.Commit(worktree, fmt.Sprintf("Record %s upgrade pull request in history", strings.Join(projectNames, ", ")))
+	if err != nil {
+		return fmt.Errorf("committing upgrade history entry files: %v", err)
+	}
+
+	err = git.Push(repo, headRepoOwner, headBranch, githubToken)
+	if err != nil {
+		return fmt.Errorf("pushing upgrade history entry files: %v", err)
 	}
 
 	return nil
 }
 
+// renderPullRequest returns the pull request title and body for the given project upgrade, rendered from the
+// pull request templates file if one is configured, or the default title and body otherwise.
+func renderPullRequest(upgradeOptions *types.UpgradeOptions, buildToolingRepoPath string, data types.PullRequestTemplateData) (string, string, error) {
+	templatesFilepath := upgradeOptions.PullRequestTemplatesFile
+	if templatesFilepath == "" {
+		templatesFilepath = filepath.Join(buildToolingRepoPath, constants.PullRequestTemplatesFile)
+		if _, err := os.Stat(templatesFilepath); os.IsNotExist(err) {
+			return data.DefaultTitle, data.DefaultBody, nil
+		}
+	}
+
+	pullRequestTemplates, err := prtemplate.Load(templatesFilepath)
+	if err != nil {
+		return "", "", err
+	}
+
+	if data.Org != "" && data.Repo != "" {
+		data.CompareURL = fmt.Sprintf(constants.GithubCompareURLFormat, data.Org, data.Repo, data.CurrentVersion, data.LatestVersion)
+		data.ReleaseNotesURL = fmt.Sprintf(constants.GithubReleaseURLFormat, data.Org, data.Repo, data.LatestVersion)
+	}
+
+	return prtemplate.Render(pullRequestTemplates, data)
+}
+
+// UpdateProjectVersionFiles updates the version files of a project that does not have release branches to the
+// latest revision, including the Git tag and Go version files, the upstream projects tracker file, the project
+// README, the Helm charts deploying the project's images and, if the project's patches apply cleanly, the checksums
+// and attribution files. It returns the updated files, relative to the build-tooling repository, and the comment to
+// add to the pull request if the patches failed to apply. The upgrade is recorded in the upgrade history separately
+// with RecordUpgradeInHistory, since dry runs don't record it.
+func UpdateProjectVersionFiles(client *gogithub.Client, buildToolingRepoPath, projectName string, currentVersion types.Version, latestRevision string) ([]string, string, error) {
+	projectOrg, projectRepo := projects.SplitName(projectName)
+	projectPath := projects.Path(projectName)
+	projectRootFilepath := filepath.Join(buildToolingRepoPath, projectPath)
+	upstreamProjectsTrackerFilePath := filepath.Join(buildToolingRepoPath, constants.UpstreamProjectsTrackerFile)
+
+	commitPinned := currentVersion.Tag == ""
+	currentRevision := currentVersion.Tag
+	if commitPinned {
+		currentRevision = currentVersion.Commit
+	}
+
+	// Check if project to be upgraded has patches, using the patches directory evaluated by the
+	// project Makefile since projects can override where their patches live.
+	var patchApplySucceeded bool
+	var totalPatchCount int
+	var updatedFiles []string
+	patchesWarningComment := ""
+	projectHasPatches := false
+	patchesDirectory, err := makefile.GetVariableValue(projectRootFilepath, "PATCHES_DIR", "")
+	if err != nil {
+		return nil, "", fmt.Errorf("getting project patches directory: %v", err)
+	}
+	if patchesDirectory != "" {
+		projectHasPatches = true
+		patchFiles, err := filepath.Glob(filepath.Join(patchesDirectory, constants.PatchFileGlob))
+		if err != nil {
+			return nil, "", fmt.Errorf("reading patch directory: %v", err)
+		}
+		totalPatchCount = len(patchFiles)
+	}
+
+	// Reload upstream projects tracker file to get its original value instead of
+	// the updated one from another project's previous upgrade
+	projectsList, targetRepo, err := loadUpstreamProjectsTrackerFile(upstreamProjectsTrackerFilePath, projectOrg, projectRepo)
+	if err != nil {
+		return nil, "", fmt.Errorf("reloading upstream projects tracker file: %v", err)
+	}
+	if commitPinned {
+		targetRepo.Versions[0].Commit = latestRevision
+	} else {
+		targetRepo.Versions[0].Tag = latestRevision
+	}
+
+	// Update the Git tag file corresponding to the project
+	logger.Info("Updating Git tag file corresponding to the project")
+	projectGitTagRelativePath, err := updateProjectVersionFile(buildToolingRepoPath, constants.GitTagFile, projectName, latestRevision)
+	if err != nil {
+		return nil, "", fmt.Errorf("updating project GIT_TAG file: %v", err)
+	}
+	updatedFiles = append(updatedFiles, projectGitTagRelativePath)
+
+	var latestGoVersion string
+	if currentVersion.GoVersion != "N/A" {
+		currentGoVersion := currentVersion.GoVersion
+		// Get Go version corresponding to the latest revision of the project.
+		latestGoVersion, err := github.GetGoVersionForLatestRevision(client, projectOrg, projectRepo, latestRevision)
+		if err != nil {
+			return nil, "", fmt.Errorf("getting latest Go version for release %s: %v", latestRevision, err)
+		}
+
+		// Get the minor version for the current revision's Go version.
+		currentGoMinorVersion, err := strconv.Atoi(strings.Split(currentGoVersion, ".")[1])
+		if err != nil {
+			return nil, "", fmt.Errorf("getting current Go minor version: %v", err)
+		}
+
+		// Get the major version for the latest revision's Go version.
+		latestGoMinorVersion, err := strconv.Atoi(strings.Split(latestGoVersion, ".")[1])
+		if err != nil {
+			return nil, "", fmt.Errorf("getting latest Go minor version: %v", err)
+		}
+
+		// If the Go version has been updated in the latest revision, then update the Go version file corresponding to the project.
+		if latestGoMinorVersion > currentGoMinorVersion {
+			logger.Info("Project Go version needs to be updated.", "Current Go version", currentGoVersion, "Latest Go version", latestGoVersion)
+			targetRepo.Versions[0].GoVersion = latestGoVersion
+
+			logger.Info("Updating Go version file corresponding to the project")
+			projectGoVersionRelativePath, err := updateProjectVersionFile(buildToolingRepoPath, constants.GoVersionFile, projectName, latestGoVersion)
+			if err != nil {
+				return nil, "", fmt.Errorf("updating project GOLANG_VERSION file: %v", err)
+			}
+			updatedFiles = append(updatedFiles, projectGoVersionRelativePath)
+		}
+	} else {
+		latestGoVersion = "N/A"
+		targetRepo.Versions[0].GoVersion = latestGoVersion
+	}
+
+	// Update the tag and Go version in the section of the upstream projects tracker file corresponding to the given project.
+	logger.Info("Updating Git tag and Go version in upstream projects tracker file")
+	err = updateUpstreamProjectsTrackerFile(&projectsList, targetRepo, buildToolingRepoPath, upstreamProjectsTrackerFilePath, latestRevision, latestGoVersion)
+	if err != nil {
+		return nil, "", fmt.Errorf("updating upstream projects tracker file: %v", err)
+	}
+	updatedFiles = append(updatedFiles, constants.UpstreamProjectsTrackerFile)
+
+	// Update the version in the project's README file.
+	logger.Info("Updating project README file")
+	projectReadmePath := filepath.Join(projectPath, constants.ReadmeFile)
+	err = updateProjectReadmeVersion(buildToolingRepoPath, projectOrg, projectRepo)
+	if err != nil {
+		return nil, "", fmt.Errorf("updating version in project README: %v", err)
+	}
+	updatedFiles = append(updatedFiles, projectReadmePath)
+
+	// Update the image tags in the Helm charts maintained in this repository that deploy the project's images.
+	logger.Info("Updating image tags in Helm charts referencing the project")
+	updatedChartFiles, err := updateChartImageTags(buildToolingRepoPath, projectRootFilepath, projectOrg, projectRepo, currentRevision, latestRevision)
+	if err != nil {
+		return nil, "", fmt.Errorf("updating image tags in Helm charts: %v", err)
+	}
+	updatedFiles = append(updatedFiles, updatedChartFiles...)
+
+	// If project has patches, attempt to apply them. Track failed patches and files that failed to apply, if any.
+	if projectHasPatches {
+		appliedPatchesCount, failedPatch, applyFailedFiles, err := applyPatchesToRepo(projectRootFilepath, projectRepo, latestRevision, totalPatchCount)
+		if appliedPatchesCount == totalPatchCount {
+			patchApplySucceeded = true
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("applying patches to repository: %v", err)
+		}
+		if !patchApplySucceeded {
+			patchesWarningComment = fmt.Sprintf(constants.PatchesCommentBody, appliedPatchesCount, totalPatchCount, failedPatch, applyFailedFiles)
+		}
+	}
+
+	// If project doesn't have patches, or it does and they were applied successfully, then update the checksums file
+	// and attribution file(s) corresponding to the project.
+	if !projectHasPatches || patchApplySucceeded {
+		if _, err := os.Stat(filepath.Join(projectRootFilepath, constants.ChecksumsFile)); err == nil {
+			logger.Info("Updating project checksums and attribution files")
+			projectChecksumsFileRelativePath := filepath.Join(projectPath, constants.ChecksumsFile)
+			err = updateChecksumsAttributionFiles(projectRootFilepath)
+			if err != nil {
+				return nil, "", fmt.Errorf("updating project checksums and attribution files: %v", err)
+			}
+			updatedFiles = append(updatedFiles, projectChecksumsFileRelativePath)
+
+			// Attribution files can have a binary name prefix so we use a common prefix regular expression
+			// and glob them to cover all possibilities.
+			projectAttributionFileGlob, err := filepath.Glob(filepath.Join(projectRootFilepath, constants.AttributionsFilePattern))
+			if err != nil {
+				return nil, "", fmt.Errorf("finding filenames matching attribution file pattern [%s]: %v", constants.AttributionsFilePattern, err)
+			}
+			for _, attributionFile := range projectAttributionFileGlob {
+				attributionFileRelativePath, err := filepath.Rel(buildToolingRepoPath, attributionFile)
+				if err != nil {
+					return nil, "", fmt.Errorf("getting relative path for attribution file: %v", err)
+				}
+				updatedFiles = append(updatedFiles, attributionFileRelativePath)
+			}
+		}
+	}
+
+	return updatedFiles, patchesWarningComment, nil
+}
+
+// getLatestBranchRevision returns the branch followed by a commit-pinned project and the commit hash at its head,
+// along with whether the project needs to be upgraded to it. If the policy has a cadence, the project is not
+// upgraded until the cadence has elapsed since its last recorded upgrade.
+func getLatestBranchRevision(client *gogithub.Client, buildToolingRepoPath, projectName, projectOrg, projectRepo, currentRevision string, commitTrackingPolicy types.CommitTrackingPolicy) (string, string, bool, error) {
+	branch := commitTrackingPolicy.Branch
+	if branch == "" {
+		defaultBranch, err := github.GetDefaultBranch(client, projectOrg, projectRepo)
+		if err != nil {
+			return "", "", false, fmt.Errorf("getting default branch: %v", err)
+		}
+		branch = defaultBranch
+	}
+
+	latestRevision, err := github.GetBranchHeadCommit(client, projectOrg, projectRepo, branch)
+	if err != nil {
+		return "", "", false, fmt.Errorf("getting head commit of %s branch: %v", branch, err)
+	}
+	if latestRevision == currentRevision {
+		return branch, latestRevision, false, nil
+	}
+
+	if commitTrackingPolicy.Cadence > 0 {
+		upgradeDate, upgraded, err := history.LastUpgradeDate(filepath.Join(buildToolingRepoPath, constants.UpgradeHistoryDirectory), projectName)
+		if err != nil {
+			return "", "", false, fmt.Errorf("getting last upgrade date from upgrade history: %v", err)
+		}
+		if upgraded && time.Since(upgradeDate) < commitTrackingPolicy.Cadence {
+			logger.Info("Project was upgraded within its upgrade cadence. Skipping upgrade", "Last upgrade", upgradeDate.Format(time.RFC3339), "Cadence", commitTrackingPolicy.Cadence.String())
+			return branch, latestRevision, false, nil
+		}
+	}
+
+	return branch, latestRevision, true, nil
+}
+
+// getCommitLogSection returns the pull request description section listing the upstream commits between the
+// current and latest revisions of a commit-pinned project.
+func getCommitLogSection(commitLog []string, currentRevision, latestRevision string) string {
+	commitLines := []string{}
+	for i, commit := range commitLog {
+		if i == constants.MaxCommitsInPullRequest {
+			commitLines = append(commitLines, fmt.Sprintf("* ...and %d more", len(commitLog)-i))
+			break
+		}
+		commitLines = append(commitLines, fmt.Sprintf("* %s", commit))
+	}
+
+	return fmt.Sprintf(constants.CommitLogPullRequestSection, currentRevision, latestRevision, strings.Join(commitLines, "\n"))
+}
+
+// getBreakingChangesSection returns the pull request body section listing the potential breaking changes
+// found upstream, truncated to a reasonable number of entries.
+func getBreakingChangesSection(breakingChanges []types.MarkedChange, currentRevision, latestRevision string) string {
+	breakingChangeLines := []string{}
+	for i, breakingChange := range breakingChanges {
+		if i == constants.MaxBreakingChangesInPullRequest {
+			breakingChangeLines = append(breakingChangeLines, fmt.Sprintf("* ...and %d more", len(breakingChanges)-i))
+			break
+		}
+		breakingChangeLines = append(breakingChangeLines, fmt.Sprintf("* `%s`: %s", breakingChange.Source, breakingChange.Line))
+	}
+
+	return fmt.Sprintf(constants.BreakingChangesPullRequestSection, currentRevision, latestRevision, strings.Join(breakingChangeLines, "\n"))
+}
+
 func updateEKSDistroReleasesFile(client *gogithub.Client, buildToolingRepoPath string) (bool, error) {
 	var isUpdated bool
 	eksDistroReleasesFilepath := filepath.Join(buildToolingRepoPath, constants.EKSDistroLatestReleasesFile)
@@ -402,7 +1182,7 @@ func updateEKSDistroReleasesFile(client *gogithub.Client, buildToolingRepoPath s
 
 	for i := range eksDistroLatestReleases.Releases {
 		if slices.Contains(supportedReleaseBranches, eksDistroLatestReleases.Releases[i].Branch) {
-			number, kubeVersion, err := getLatestEKSDistroRelease(client, eksDistroLatestReleases.Releases[i].Branch)
+			number, kubeVersion, err := eksdistro.GetLatestRelease(client, eksDistroLatestReleases.Releases[i].Branch)
 			if err != nil {
 				return false, fmt.Errorf("getting latest EKS Distro release for %s branch: %v", eksDistroLatestReleases.Releases[i].Branch, err)
 			}
@@ -441,42 +1221,170 @@ func getSupportedReleaseBranches(buildToolingRepoPath string) ([]string, error)
 	return supportedK8sVersions, nil
 }
 
-func getLatestEKSDistroRelease(client *gogithub.Client, branch string) (int, string, error) {
-	eksDistroProdReleaseNumberFile := fmt.Sprintf(constants.EKSDistroProdReleaseNumberFileFormat, branch)
-	releaseNumber, err := github.GetFileContents(client, "aws", "eks-distro", eksDistroProdReleaseNumberFile, constants.MainBranchName)
+// updateProjectVersionFile updates the version information stored in a specific file.
+func updateProjectVersionFile(buildToolingRepoPath, filename, projectName, value string) (string, error) {
+	fileRelativepath := filepath.Join("projects", projectName, filename)
+	fileAbsolutepath := filepath.Join(buildToolingRepoPath, fileRelativepath)
+	fileAbsolutePathStat, err := os.Stat(fileAbsolutepath)
 	if err != nil {
-		return 0, "", fmt.Errorf("getting contents of EKS Distro prod release number file: %v", eksDistroProdReleaseNumberFile, err)
+		return "", fmt.Errorf("unable to stat project %s file [%s]: %v", filename, fileAbsolutepath, err)
 	}
+	if err := os.WriteFile(fileAbsolutepath, []byte(fmt.Sprintf("%s\n", value)), fileAbsolutePathStat.Mode()); err != nil {
+		return "", fmt.Errorf("writing project %s file [%s]: %v", filename, fileAbsolutepath, err)
+	}
+	// The project Makefile reads the version files, so previously evaluated variables may be stale.
+	makefile.InvalidateCache(projects.RootPath(buildToolingRepoPath, projectName))
 
-	kubernetesGitTagFile := fmt.Sprintf(constants.KubernetesGitTagFileFormat, branch)
-	kubeVersion, err := github.GetFileContents(client, "aws", "eks-distro", kubernetesGitTagFile, constants.MainBranchName)
+	return fileRelativepath, nil
+}
+
+// updateChartImageTags updates the tags of the project's images in the Helm charts maintained in this repository
+// from the current revision to the latest revision. The version of each updated chart is bumped to the next patch
+// version in the chart's Chart.yaml, GIT_TAG and sedfile template, so that the changed chart is released.
+func updateChartImageTags(buildToolingRepoPath, projectRootFilepath, projectOrg, projectRepo, currentRevision, latestRevision string) ([]string, error) {
+	updatedFiles := []string{}
+
+	// The project's images may be named differently from the repository, so match on all of them.
+	imageNames := []string{projectRepo}
+	projectImageNames, err := makefile.GetVariableValue(projectRootFilepath, "IMAGE_NAMES", "")
 	if err != nil {
-		return 0, "", fmt.Errorf("getting contents of Kubernetes Git tag file: %v", eksDistroProdReleaseNumberFile, err)
+		return nil, fmt.Errorf("getting project image names: %v", err)
+	}
+	for _, imageName := range strings.Fields(projectImageNames) {
+		if !slices.Contains(imageNames, imageName) {
+			imageNames = append(imageNames, imageName)
+		}
+	}
+	imageReferenceRegexes := []*regexp.Regexp{}
+	for _, imageName := range imageNames {
+		imageReferenceRegexes = append(imageReferenceRegexes, regexp.MustCompile(fmt.Sprintf(constants.ChartImageReferenceRegexFormat, regexp.QuoteMeta(projectOrg), regexp.QuoteMeta(imageName), regexp.QuoteMeta(currentRevision))))
 	}
 
-	releaseNumberInt, err := strconv.Atoi(strings.TrimRight(string(releaseNumber), "\n"))
+	chartFiles, err := filepath.Glob(filepath.Join(buildToolingRepoPath, constants.HelmChartsGlob))
 	if err != nil {
-		return 0, "", fmt.Errorf("converting release number to integer: %v", err)
+		return nil, fmt.Errorf("finding Helm charts: %v", err)
 	}
+	for _, chartFile := range chartFiles {
+		chartDirectory := filepath.Dir(chartFile)
+		chartUpdated := false
+		err = filepath.WalkDir(chartDirectory, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || path == chartFile || (filepath.Ext(path) != ".yaml" && filepath.Ext(path) != ".yml") {
+				return nil
+			}
 
-	kubeVersionTrimmed := strings.TrimRight(string(kubeVersion), "\n")
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("reading chart file [%s]: %v", path, err)
+			}
+			updatedContents := contents
+			for _, imageReferenceRegex := range imageReferenceRegexes {
+				updatedContents = imageReferenceRegex.ReplaceAll(updatedContents, []byte(fmt.Sprintf("${1}:%s${2}", latestRevision)))
+			}
+			if bytes.Equal(contents, updatedContents) {
+				return nil
+			}
+
+			relativePath, err := writeBuildToolingRepoFile(buildToolingRepoPath, path, updatedContents)
+			if err != nil {
+				return err
+			}
+			updatedFiles = append(updatedFiles, relativePath)
+			chartUpdated = true
 
-	return releaseNumberInt, kubeVersionTrimmed, nil
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("updating image tags in Helm chart [%s]: %v", chartDirectory, err)
+		}
+
+		if chartUpdated {
+			updatedVersionFiles, err := bumpChartVersion(buildToolingRepoPath, chartFile)
+			if err != nil {
+				return nil, fmt.Errorf("bumping version of Helm chart [%s]: %v", chartDirectory, err)
+			}
+			updatedFiles = append(updatedFiles, updatedVersionFiles...)
+		}
+	}
+
+	return updatedFiles, nil
 }
 
-// updateProjectVersionFile updates the version information stored in a specific file.
-func updateProjectVersionFile(buildToolingRepoPath, filename, projectName, value string) (string, error) {
-	fileRelativepath := filepath.Join("projects", projectName, filename)
-	fileAbsolutepath := filepath.Join(buildToolingRepoPath, fileRelativepath)
-	fileAbsolutePathStat, err := os.Stat(fileAbsolutepath)
+// bumpChartVersion increments the patch version of the given Helm chart and updates the chart project's GIT_TAG
+// and sedfile template, which are expected to match the chart version.
+func bumpChartVersion(buildToolingRepoPath, chartFile string) ([]string, error) {
+	chartContents, err := os.ReadFile(chartFile)
 	if err != nil {
-		return "", fmt.Errorf("unable to stat project %s file [%s]: %v", filename, fileAbsolutepath, err)
+		return nil, fmt.Errorf("reading Chart.yaml: %v", err)
 	}
-	if err := os.WriteFile(fileAbsolutepath, []byte(fmt.Sprintf("%s\n", value)), fileAbsolutePathStat.Mode()); err != nil {
-		return "", fmt.Errorf("writing project %s file [%s]: %v", filename, fileAbsolutepath, err)
+
+	chartVersionRegex := regexp.MustCompile(constants.ChartVersionRegex)
+	matches := chartVersionRegex.FindSubmatch(chartContents)
+	if matches == nil {
+		return nil, fmt.Errorf("chart version not found in Chart.yaml")
 	}
+	currentChartVersion := string(matches[1])
+	versionComponents := strings.Split(currentChartVersion, ".")
+	patchVersion, err := strconv.Atoi(versionComponents[2])
+	if err != nil {
+		return nil, fmt.Errorf("parsing chart patch version: %v", err)
+	}
+	latestChartVersion := fmt.Sprintf("%s.%s.%d", versionComponents[0], versionComponents[1], patchVersion+1)
+	logger.Info("Bumping Helm chart version.", "Chart", filepath.Base(filepath.Dir(filepath.Dir(chartFile))), "Current version", currentChartVersion, "Latest version", latestChartVersion)
 
-	return fileRelativepath, nil
+	updatedFiles := []string{}
+	chartContents = chartVersionRegex.ReplaceAll(chartContents, []byte(fmt.Sprintf("version: %s", latestChartVersion)))
+	relativePath, err := writeBuildToolingRepoFile(buildToolingRepoPath, chartFile, chartContents)
+	if err != nil {
+		return nil, err
+	}
+	updatedFiles = append(updatedFiles, relativePath)
+
+	// The chart project's GIT_TAG and sedfile template are optional, and only updated if they track the chart version.
+	chartProjectPath := filepath.Dir(filepath.Dir(chartFile))
+	for _, versionFile := range []string{constants.GitTagFile, constants.HelmSedfileTemplate} {
+		versionFilepath := filepath.Join(chartProjectPath, versionFile)
+		contents, err := os.ReadFile(versionFilepath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading chart project %s file: %v", versionFile, err)
+		}
+		updatedContents := bytes.ReplaceAll(contents, []byte(currentChartVersion), []byte(latestChartVersion))
+		if bytes.Equal(contents, updatedContents) {
+			continue
+		}
+		relativePath, err := writeBuildToolingRepoFile(buildToolingRepoPath, versionFilepath, updatedContents)
+		if err != nil {
+			return nil, err
+		}
+		updatedFiles = append(updatedFiles, relativePath)
+	}
+	makefile.InvalidateCache(chartProjectPath)
+
+	return updatedFiles, nil
+}
+
+// writeBuildToolingRepoFile writes the given contents to a file in the build-tooling repository, preserving its
+// permissions, and returns the file path relative to the repository root.
+func writeBuildToolingRepoFile(buildToolingRepoPath, path string, contents []byte) (string, error) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to stat file [%s]: %v", path, err)
+	}
+	if err := os.WriteFile(path, contents, fileInfo.Mode()); err != nil {
+		return "", fmt.Errorf("writing file [%s]: %v", path, err)
+	}
+
+	relativePath, err := filepath.Rel(buildToolingRepoPath, path)
+	if err != nil {
+		return "", fmt.Errorf("getting relative path of file [%s]: %v", path, err)
+	}
+
+	return relativePath, nil
 }
 
 // loadUpstreamProjectsTrackerFile reads and unmarshals the contents of the upstream projects tracker file and
@@ -553,16 +1461,32 @@ func applyPatchesToRepo(projectRootFilepath, projectRepo, latestVersion string,
 	var failedPatch, failedFilesInPatch string
 	patchApplySucceeded := true
 
-	applyPatchesCommandSequence := fmt.Sprintf("make -C %s patch-repo", projectRootFilepath)
+	err := disk.CheckFreeSpace(projectRootFilepath)
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	err = upstream.PrepareCheckout(projectRootFilepath, "")
+	if err != nil {
+		return 0, "", "", fmt.Errorf("preparing upstream worktree checkout: %v", err)
+	}
+
+	span := tracing.Start("apply-patches", "Patches", totalPatchCount)
+	applyPatchesCommandSequence := fmt.Sprintf("%s -C %s patch-repo", environment.Make(), projectRootFilepath)
 	applyPatchesCmd := exec.Command("bash", "-c", applyPatchesCommandSequence)
+	stopProgress := progress.Start("applying patches", "Patches", totalPatchCount)
 	applyPatchesOutput, err := command.ExecCommand(applyPatchesCmd)
+	stopProgress()
 	if err != nil {
 		if strings.Contains(applyPatchesOutput, constants.FailedPatchApplyMarker) {
 			patchApplySucceeded = false
 		} else {
+			span.End(err)
 			return 0, "", "", fmt.Errorf("running patch-repo Make command: %v", err)
 		}
 	}
+	span.SetAttributes("Succeeded", patchApplySucceeded)
+	span.End(nil)
 
 	if patchApplySucceeded {
 		patchesApplied = totalPatchCount
@@ -600,9 +1524,11 @@ func applyPatchesToRepo(projectRootFilepath, projectRepo, latestVersion string,
 // updateChecksumsAttributionFiles runs a Make command to update the checksums and attribution files
 // corresponding to the project being upgraded.
 func updateChecksumsAttributionFiles(projectRootFilepath string) error {
-	updateChecksumsAttributionCommandSequence := fmt.Sprintf("make -C %s attribution-checksums", projectRootFilepath)
+	span := tracing.Start("update-checksums-attribution")
+	updateChecksumsAttributionCommandSequence := fmt.Sprintf("%s -C %s attribution-checksums", environment.Make(), projectRootFilepath)
 	updateChecksumsAttributionCmd := exec.Command("bash", "-c", updateChecksumsAttributionCommandSequence)
 	_, err := command.ExecCommand(updateChecksumsAttributionCmd)
+	span.End(err)
 	if err != nil {
 		return fmt.Errorf("running checksums-attribution Make command: %v", err)
 	}
@@ -626,7 +1552,7 @@ func updateProjectReadmeVersion(buildToolingRepoPath, projectOrg, projectRepo st
 
 func updateCiliumImageDigestFiles(projectRootFilepath, projectPath string) ([]string, error) {
 	updateCiliumFiles := []string{}
-	updateDigestsCommandSequence := fmt.Sprintf("make -C %s update-digests", projectRootFilepath)
+	updateDigestsCommandSequence := fmt.Sprintf("%s -C %s update-digests", environment.Make(), projectRootFilepath)
 	updateDigestsCmd := exec.Command("bash", "-c", updateDigestsCommandSequence)
 	_, err := command.ExecCommand(updateDigestsCmd)
 	if err != nil {
@@ -654,19 +1580,7 @@ func updateBottlerocketVersionFiles(client *gogithub.Client, projectRootFilepath
 		return "", "", nil, fmt.Errorf("unmarshalling Bottlerocket releases file: %v", err)
 	}
 
-	var currentBottlerocketVersion string
-	for channel := range bottlerocketReleaseMap {
-		for _, format := range constants.BottlerocketImageFormats {
-			releaseVersionByFormat := bottlerocketReleaseMap[channel].(map[string]interface{})[fmt.Sprintf("%s-release-version", format)]
-			if releaseVersionByFormat != nil {
-				currentBottlerocketVersion = releaseVersionByFormat.(string)
-				break
-			}
-		}
-		if currentBottlerocketVersion != "" {
-			break
-		}
-	}
+	currentBottlerocketVersion := getCurrentBottlerocketVersion(bottlerocketReleaseMap)
 
 	latestBottlerocketVersion, needsUpgrade, err := github.GetLatestRevision(client, "bottlerocket-os", "bottlerocket", currentBottlerocketVersion)
 	if err != nil {
@@ -692,8 +1606,34 @@ func updateBottlerocketVersionFiles(client *gogithub.Client, projectRootFilepath
 	return currentBottlerocketVersion, latestBottlerocketVersion, updatedBRFiles, nil
 }
 
-func updateBottlerocketReleasesFile(bottlerocketReleaseMap map[string]interface{}, bottlerocketReleasesFilePath, latestBottlerocketVersion string) error {
+// getCurrentBottlerocketVersion returns the first Bottlerocket release version in the releases file, checking the
+// release channels in sorted order so that the same version is picked on every run when the channels differ.
+func getCurrentBottlerocketVersion(bottlerocketReleaseMap map[string]interface{}) string {
+	for _, channel := range sortedBottlerocketChannels(bottlerocketReleaseMap) {
+		for _, format := range constants.BottlerocketImageFormats {
+			releaseVersionByFormat := bottlerocketReleaseMap[channel].(map[string]interface{})[fmt.Sprintf("%s-release-version", format)]
+			if releaseVersionByFormat != nil {
+				return releaseVersionByFormat.(string)
+			}
+		}
+	}
+
+	return ""
+}
+
+// sortedBottlerocketChannels returns the release channels in the Bottlerocket releases file in sorted order.
+func sortedBottlerocketChannels(bottlerocketReleaseMap map[string]interface{}) []string {
+	channels := make([]string, 0, len(bottlerocketReleaseMap))
 	for channel := range bottlerocketReleaseMap {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+
+	return channels
+}
+
+func updateBottlerocketReleasesFile(bottlerocketReleaseMap map[string]interface{}, bottlerocketReleasesFilePath, latestBottlerocketVersion string) error {
+	for _, channel := range sortedBottlerocketChannels(bottlerocketReleaseMap) {
 		for _, format := range constants.BottlerocketImageFormats {
 			releaseVersionByFormat := bottlerocketReleaseMap[channel].(map[string]interface{})[fmt.Sprintf("%s-release-version", format)]
 			if releaseVersionByFormat != nil {
@@ -777,7 +1717,7 @@ func updateBottlerocketHostContainerMetadata(client *gogithub.Client, projectRoo
 	updatedHostContainerFiles := []string{}
 	hostContainersTOMLContents, err := github.GetFileContents(client, "bottlerocket-os", "bottlerocket", constants.BottlerocketHostContainersTOMLFile, latestBottlerocketVersion)
 	if err != nil {
-		return nil, fmt.Errorf("getting contents of Bottlerocket host containers file: %v", constants.BottlerocketHostContainersTOMLFile, err)
+		return nil, fmt.Errorf("getting contents of Bottlerocket host containers file %s: %v", constants.BottlerocketHostContainersTOMLFile, err)
 	}
 
 	var hostContainersTOMLMap interface{}
@@ -0,0 +1,182 @@
+package upgrade
+
+import (
+	"errors"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command/mocks"
+)
+
+const failedPatchApplyOutput = `Applying: Add foo flag
+Applying: Bump bar dependency
+Applying: Fix baz handling
+error: patch failed: pkg/baz/baz.go:42
+error: pkg/baz/baz.go: patch does not apply
+error: cmd/baz/main.go: patch does not apply
+Patch failed at 0003 Fix baz handling`
+
+func TestApplyPatchesToRepo(t *testing.T) {
+	testcases := []struct {
+		name                   string
+		patchRepoOutput        string
+		patchRepoErr           error
+		gitDescribeOutput      string
+		wantPatchesApplied     int
+		wantFailedPatch        string
+		wantFailedFilesInPatch string
+		wantErr                bool
+	}{
+		{
+			name:               "All patches applied",
+			patchRepoOutput:    "Applying: Add foo flag\nApplying: Bump bar dependency\nApplying: Fix baz handling",
+			wantPatchesApplied: 3,
+		},
+		{
+			name:                   "Patch failed to apply",
+			patchRepoOutput:        failedPatchApplyOutput,
+			patchRepoErr:           errors.New("exit status 2"),
+			gitDescribeOutput:      "v1.2.3-2-g0123abc",
+			wantPatchesApplied:     2,
+			wantFailedPatch:        "Patch failed at 0003 Fix baz handling",
+			wantFailedFilesInPatch: "`pkg/baz/baz.go`,`cmd/baz/main.go`",
+		},
+		{
+			name:                   "First patch failed to apply",
+			patchRepoOutput:        "Applying: Add foo flag\nerror: pkg/foo/foo.go: patch does not apply\nPatch failed at 0001 Add foo flag",
+			patchRepoErr:           errors.New("exit status 2"),
+			gitDescribeOutput:      "v1.2.3",
+			wantPatchesApplied:     0,
+			wantFailedPatch:        "Patch failed at 0001 Add foo flag",
+			wantFailedFilesInPatch: "`pkg/foo/foo.go`",
+		},
+		{
+			name:            "Checkout failure",
+			patchRepoOutput: "fatal: unable to access repository",
+			patchRepoErr:    errors.New("exit status 2"),
+			wantErr:         true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := mocks.NewMockCommandRunner(gomock.NewController(t))
+			defer command.SetRunner(runner)()
+
+			patchRepoCall := runner.EXPECT().Run(gomock.Any()).DoAndReturn(func(cmd *exec.Cmd) (string, error) {
+				wantArgs := []string{"bash", "-c", "make -C projects/foo/bar patch-repo"}
+				if !reflect.DeepEqual(cmd.Args, wantArgs) {
+					t.Fatalf("Unexpected command arguments. Expected: %v, Got: %v", wantArgs, cmd.Args)
+				}
+				return tc.patchRepoOutput, tc.patchRepoErr
+			})
+			if tc.gitDescribeOutput != "" {
+				runner.EXPECT().Run(gomock.Any()).DoAndReturn(func(cmd *exec.Cmd) (string, error) {
+					wantArgs := []string{"git", "-C", "projects/foo/bar/bar", "describe", "--tag"}
+					if !reflect.DeepEqual(cmd.Args, wantArgs) {
+						t.Fatalf("Unexpected command arguments. Expected: %v, Got: %v", wantArgs, cmd.Args)
+					}
+					return tc.gitDescribeOutput, nil
+				}).After(patchRepoCall)
+			}
+
+			patchesApplied, failedPatch, failedFilesInPatch, err := applyPatchesToRepo("projects/foo/bar", "bar", "v1.2.3", 3)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error. Got: %v", err)
+			}
+			if patchesApplied != tc.wantPatchesApplied {
+				t.Fatalf("Unexpected applied patch count. Expected: %d, Got: %d", tc.wantPatchesApplied, patchesApplied)
+			}
+			if failedPatch != tc.wantFailedPatch {
+				t.Fatalf("Unexpected failed patch. Expected: %s, Got: %s", tc.wantFailedPatch, failedPatch)
+			}
+			if failedFilesInPatch != tc.wantFailedFilesInPatch {
+				t.Fatalf("Unexpected failed files. Expected: %s, Got: %s", tc.wantFailedFilesInPatch, failedFilesInPatch)
+			}
+		})
+	}
+}
+
+func TestUpdateChecksumsAttributionFiles(t *testing.T) {
+	testcases := []struct {
+		name    string
+		runErr  error
+		wantErr bool
+	}{
+		{
+			name: "Make target succeeded",
+		},
+		{
+			name:    "Make target failed",
+			runErr:  errors.New("exit status 2"),
+			wantErr: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := mocks.NewMockCommandRunner(gomock.NewController(t))
+			defer command.SetRunner(runner)()
+
+			runner.EXPECT().Run(gomock.Any()).DoAndReturn(func(cmd *exec.Cmd) (string, error) {
+				wantArgs := []string{"bash", "-c", "make -C projects/foo/bar attribution-checksums"}
+				if !reflect.DeepEqual(cmd.Args, wantArgs) {
+					t.Fatalf("Unexpected command arguments. Expected: %v, Got: %v", wantArgs, cmd.Args)
+				}
+				return "", tc.runErr
+			})
+
+			err := updateChecksumsAttributionFiles("projects/foo/bar")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error. Got: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetCurrentBottlerocketVersion(t *testing.T) {
+	bottlerocketReleaseMap := map[string]interface{}{
+		"1-32": map[string]interface{}{"ami-release-version": "v1.30.0", "ova-release-version": "v1.30.0"},
+		"1-31": map[string]interface{}{"ova-release-version": "v1.29.1"},
+		"1-30": map[string]interface{}{"raw-release-version": "v1.29.0"},
+		"1-29": map[string]interface{}{"ami-release-version": "v1.28.0"},
+	}
+
+	// Map iteration order is randomized, so repeated calls catch any dependence on it.
+	for i := 0; i < 20; i++ {
+		gotVersion := getCurrentBottlerocketVersion(bottlerocketReleaseMap)
+		if gotVersion != "v1.28.0" {
+			t.Fatalf("Unexpected current Bottlerocket version. Expected: %s, Got: %s", "v1.28.0", gotVersion)
+		}
+	}
+}
+
+func TestGetBuildSimulationSection(t *testing.T) {
+	testcases := []struct {
+		name          string
+		releaseBranch string
+		wantHeading   string
+	}{
+		{
+			name:        "Project without release branches",
+			wantHeading: "## Build simulation\n",
+		},
+		{
+			name:          "Release branch of release-branched project",
+			releaseBranch: "1-30",
+			wantHeading:   "## Build simulation for 1-30 release branch\n",
+		},
+	}
+
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSection := getBuildSimulationSection(tt.releaseBranch, "Succeeded", "The project builds successfully at the latest revision.")
+			if !strings.Contains(gotSection, tt.wantHeading) || !strings.Contains(gotSection, "**Result:** Succeeded") {
+				t.Fatalf("Unexpected build simulation section. Expected heading: %q, Got: %q", tt.wantHeading, gotSection)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package verify
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/rodaine/table"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/disk"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/environment"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/makefile"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/progress"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// Run contains the business logic to execute the `verify` subcommand.
+func Run(verifyOptions *types.VerifyOptions) error {
+	projectName := verifyOptions.ProjectName
+	releaseBranch := verifyOptions.ReleaseBranch
+
+	// Get base repository owner environment variable if set.
+	baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+	if baseRepoOwner == "" {
+		baseRepoOwner = constants.DefaultBaseRepoOwner
+	}
+
+	// Clone the eks-anywhere-build-tooling repository.
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+	if err != nil {
+		return fmt.Errorf("cloning build-tooling repo: %v", err)
+	}
+
+	// Validate if the project name provided exists in the repository.
+	projectRootFilepath := projects.RootPath(buildToolingRepoPath, projectName)
+	if !projects.Exists(buildToolingRepoPath, projectName) {
+		return fmt.Errorf("invalid project name %s", projectName)
+	}
+
+	gitTag, err := makefile.GetVariableValue(projectRootFilepath, "GIT_TAG", releaseBranch)
+	if err != nil {
+		return fmt.Errorf("getting project Git tag: %v", err)
+	}
+
+	// Release-branched projects keep their snapshots in the release branch directory.
+	snapshotsDirectory := projectRootFilepath
+	if releaseBranch != "" {
+		snapshotsDirectory = filepath.Join(projectRootFilepath, releaseBranch)
+	}
+
+	checksumsAttributionFiles, err := getChecksumsAttributionFiles(snapshotsDirectory)
+	if err != nil {
+		return fmt.Errorf("finding checksums and attribution files: %v", err)
+	}
+	goModuleFiles, err := filepath.Glob(filepath.Join(snapshotsDirectory, "go.*"))
+	if err != nil {
+		return fmt.Errorf("finding Go module files: %v", err)
+	}
+	if len(checksumsAttributionFiles) == 0 && len(goModuleFiles) == 0 {
+		logger.Info("Project does not have any checksums, attribution or go.sum snapshots to verify", "Project", projectName)
+		return nil
+	}
+
+	if len(checksumsAttributionFiles) > 0 {
+		// The checksums depend on the build environment, so the files are regenerated in the builder base container,
+		// the same way the periodic job generates them.
+		logger.Info("Regenerating checksums and attribution files in builder base container", "Project", projectName, "Git tag", gitTag)
+		err = RunMakeTarget(projectRootFilepath, "run-in-docker/attribution-checksums", releaseBranch)
+		if err != nil {
+			return err
+		}
+	}
+	if len(goModuleFiles) > 0 {
+		logger.Info("Regenerating go.mod and go.sum snapshots", "Project", projectName, "Git tag", gitTag)
+		err = RunMakeTarget(projectRootFilepath, "update-go-mods", releaseBranch)
+		if err != nil {
+			return err
+		}
+	}
+
+	results, err := diffSnapshots(buildToolingRepoPath, append(checksumsAttributionFiles, goModuleFiles...))
+	if err != nil {
+		return fmt.Errorf("comparing regenerated files: %v", err)
+	}
+
+	tbl := table.New("File", "Result", "Details").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	})
+	driftedFiles := []string{}
+	for _, result := range results {
+		status := "Pass"
+		if result.Drifted {
+			status = "Drift"
+			driftedFiles = append(driftedFiles, result.File)
+		}
+		tbl.AddRow(result.File, status, result.Details)
+	}
+	tbl.Print()
+
+	if len(driftedFiles) > 0 {
+		logger.Info(fmt.Sprintf("The regenerated files have been left in %s, inspect them with `git -C %s diff`", buildToolingRepoPath, buildToolingRepoPath))
+		return fmt.Errorf("files are not consistent with Git tag %s and patches: %s", gitTag, strings.Join(driftedFiles, ", "))
+	}
+
+	return nil
+}
+
+// getChecksumsAttributionFiles returns the checksums file and attribution files checked in to the given directory.
+func getChecksumsAttributionFiles(snapshotsDirectory string) ([]string, error) {
+	files := []string{}
+	checksumsFilepath := filepath.Join(snapshotsDirectory, constants.ChecksumsFile)
+	if _, err := os.Stat(checksumsFilepath); err == nil {
+		files = append(files, checksumsFilepath)
+	}
+
+	// Attribution files can have a binary name prefix so we use a common prefix regular expression
+	// and glob them to cover all possibilities.
+	attributionFiles, err := filepath.Glob(filepath.Join(snapshotsDirectory, constants.AttributionsFilePattern))
+	if err != nil {
+		return nil, fmt.Errorf("finding filenames matching attribution file pattern [%s]: %v", constants.AttributionsFilePattern, err)
+	}
+
+	return append(files, attributionFiles...), nil
+}
+
+// RunMakeTarget runs the given Make target for the project, passing the release branch if set.
+func RunMakeTarget(projectRootFilepath, target, releaseBranch string) error {
+	if err := disk.CheckFreeSpace(projectRootFilepath); err != nil {
+		return err
+	}
+
+	makeCommandSequence := fmt.Sprintf("%s -C %s %s", environment.Make(), projectRootFilepath, target)
+	if releaseBranch != "" {
+		makeCommandSequence = fmt.Sprintf("%s RELEASE_BRANCH=%s", makeCommandSequence, releaseBranch)
+	}
+	makeCmd := exec.Command("bash", "-c", makeCommandSequence)
+	stopProgress := progress.Start(fmt.Sprintf("running %s Make target", target))
+	makeOutput, err := command.ExecCommand(makeCmd)
+	stopProgress()
+	if err != nil {
+		if logger.Verbosity < 6 {
+			fmt.Println(makeOutput)
+		}
+		return fmt.Errorf("running %s Make command: %v", target, err)
+	}
+
+	return nil
+}
+
+// diffSnapshots compares each regenerated file against the version checked in to the build-tooling repository
+// and returns whether it drifted, along with the number of lines added and removed.
+func diffSnapshots(buildToolingRepoPath string, files []string) ([]types.SnapshotVerificationResult, error) {
+	results := make([]types.SnapshotVerificationResult, 0, len(files))
+	for _, file := range files {
+		relativePath, err := filepath.Rel(buildToolingRepoPath, file)
+		if err != nil {
+			return nil, err
+		}
+
+		diffCmd := exec.Command("git", "-C", buildToolingRepoPath, "diff", "--numstat", "--", relativePath)
+		diffOutput, err := command.ExecCommand(diffCmd)
+		if err != nil {
+			return nil, fmt.Errorf("running git diff for %s: %v", relativePath, err)
+		}
+
+		result := types.SnapshotVerificationResult{File: relativePath, Details: "Up to date"}
+		if diffOutput != "" {
+			result.Drifted = true
+			result.Details = "Changed on regeneration"
+			if fields := strings.Fields(diffOutput); len(fields) >= 2 {
+				result.Details = fmt.Sprintf("+%s/-%s lines", fields[0], fields[1])
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,299 @@
+package verifypatches
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rodaine/table"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/projects"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/git"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/provenance"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/upstream"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/cleanup"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/makefile"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/patch"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/progress"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/slices"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// Run contains the business logic to execute the `verify-patches` subcommand.
+func Run(verifyPatchesOptions *types.VerifyPatchesOptions) error {
+	projectName := verifyPatchesOptions.ProjectName
+
+	// Get base repository owner environment variable if set.
+	baseRepoOwner := os.Getenv(constants.BaseRepoOwnerEnvvar)
+	if baseRepoOwner == "" {
+		baseRepoOwner = constants.DefaultBaseRepoOwner
+	}
+
+	// Clone the eks-anywhere-build-tooling repository.
+	buildToolingRepoPath, err := workspace.BuildToolingRepoPath()
+	if err != nil {
+		return fmt.Errorf("getting build-tooling repository path: %v", err)
+	}
+	_, _, err = git.CloneRepo(fmt.Sprintf(constants.BuildToolingRepoURL, baseRepoOwner), buildToolingRepoPath, "")
+	if err != nil {
+		return fmt.Errorf("cloning build-tooling repo: %v", err)
+	}
+
+	// Validate if the project name provided exists in the repository.
+	projectRootFilepath := projects.RootPath(buildToolingRepoPath, projectName)
+	if !projects.Exists(buildToolingRepoPath, projectName) {
+		return fmt.Errorf("invalid project name %s", projectName)
+	}
+
+	releaseBranches, err := getReleaseBranches(projectRootFilepath)
+	if err != nil {
+		return fmt.Errorf("getting project release branches: %v", err)
+	}
+
+	cloneURL, err := makefile.GetVariableValue(projectRootFilepath, "CLONE_URL", releaseBranches[0])
+	if err != nil {
+		return fmt.Errorf("getting project clone URL: %v", err)
+	}
+
+	results := make([]types.PatchVerificationResult, len(releaseBranches))
+	for i, releaseBranch := range releaseBranches {
+		gitTag, err := makefile.GetVariableValue(projectRootFilepath, "GIT_TAG", releaseBranch)
+		if err != nil {
+			return fmt.Errorf("getting project Git tag for %s release branch: %v", releaseBranch, err)
+		}
+		patchesDirectory, err := makefile.GetVariableValue(projectRootFilepath, "PATCHES_DIR", releaseBranch)
+		if err != nil {
+			return fmt.Errorf("getting project patches directory for %s release branch: %v", releaseBranch, err)
+		}
+		results[i] = types.PatchVerificationResult{
+			ReleaseBranch:    releaseBranch,
+			GitTag:           gitTag,
+			PatchesDirectory: patchesDirectory,
+		}
+	}
+
+	// Check out each release branch's Git tag in its own worktree of the upstream mirror, so the patch series
+	// for all release branches can be applied concurrently without cloning the upstream repository each run.
+	gitTags := []string{}
+	for _, result := range results {
+		gitTags = append(gitTags, result.GitTag)
+	}
+	upstreamMirrorPath, err := upstream.SyncMirror(cloneURL, gitTags...)
+	if err != nil {
+		return fmt.Errorf("syncing upstream repository mirror: %v", err)
+	}
+	defer func() {
+		if err := upstream.PruneWorktrees(upstreamMirrorPath); err != nil {
+			logger.Warn("Unable to prune upstream mirror worktrees", "Error", err)
+		}
+	}()
+
+	// The upstream worktrees can be large, so they are created in the workspace directory rather than the
+	// system temporary directory.
+	workspaceDir, err := workspace.Dir()
+	if err != nil {
+		return fmt.Errorf("getting workspace directory: %v", err)
+	}
+	verificationDirectory, err := os.MkdirTemp(workspaceDir, "verify-patches")
+	if err != nil {
+		return fmt.Errorf("creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(verificationDirectory)
+	defer cleanup.Register("Removing temporary verification directory", func() error {
+		return os.RemoveAll(verificationDirectory)
+	})()
+
+	var wg sync.WaitGroup
+	var completed atomic.Int32
+	reportProgress := func(result *types.PatchVerificationResult) {
+		logger.Info("Verified patches for release branch", "Release branch", result.ReleaseBranch, "Progress", progress.Fraction(int(completed.Add(1)), len(results)))
+	}
+	for i := range results {
+		result := &results[i]
+		if result.PatchesDirectory == "" {
+			result.Succeeded = true
+			result.Details = "No patches"
+			reportProgress(result)
+			continue
+		}
+
+		worktreeName := result.ReleaseBranch
+		if worktreeName == "" {
+			worktreeName = "default"
+		}
+		worktreePath := filepath.Join(verificationDirectory, "worktrees", worktreeName)
+		err = upstream.AddWorktree(upstreamMirrorPath, worktreePath, result.GitTag)
+		if err != nil {
+			result.Details = fmt.Sprintf("Failed to check out Git tag %s", result.GitTag)
+			reportProgress(result)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			applyPatches(worktreePath, result)
+			reportProgress(result)
+		}()
+	}
+	stopProgress := progress.Start("applying patches", "Release branches", len(results))
+	wg.Wait()
+	stopProgress()
+
+	tbl := table.New("Release Branch", "Git Tag", "Result", "Details").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	})
+	failedBranches, missingLicenseHeaderBranches := []string{}, []string{}
+	for _, result := range results {
+		releaseBranch := result.ReleaseBranch
+		if releaseBranch == "" {
+			releaseBranch = "N/A"
+		}
+		status := "Pass"
+		if !result.Succeeded {
+			status = "Fail"
+			if len(result.MissingLicenseHeaders) > 0 {
+				missingLicenseHeaderBranches = append(missingLicenseHeaderBranches, releaseBranch)
+			} else {
+				failedBranches = append(failedBranches, releaseBranch)
+			}
+		}
+		tbl.AddRow(releaseBranch, result.GitTag, status, result.Details)
+	}
+	tbl.Print()
+
+	if verifyPatchesOptions.RecordProvenance {
+		for _, result := range results {
+			if !result.Succeeded || result.PatchesDirectory == "" {
+				continue
+			}
+			err = recordProvenance(result)
+			if err != nil {
+				return fmt.Errorf("recording patch provenance for %s release branch: %v", result.ReleaseBranch, err)
+			}
+		}
+	}
+
+	if len(failedBranches) > 0 {
+		return fmt.Errorf("patches failed to apply for release branches: %s", strings.Join(failedBranches, ", "))
+	}
+	if len(missingLicenseHeaderBranches) > 0 {
+		return fmt.Errorf("patches add source files without a license header for release branches: %s. New source files must start with the following header, commented out in the file's language:\n\n%s", strings.Join(missingLicenseHeaderBranches, ", "), constants.LicenseHeader)
+	}
+
+	return nil
+}
+
+// getReleaseBranches returns the supported release branches the project is built for, excluding skipped
+// release branches. Projects without release branches return a single empty release branch.
+func getReleaseBranches(projectRootFilepath string) ([]string, error) {
+	hasReleaseBranches, err := makefile.GetVariableValue(projectRootFilepath, "HAS_RELEASE_BRANCHES", "")
+	if err != nil {
+		return nil, err
+	}
+	if hasReleaseBranches != "true" {
+		return []string{""}, nil
+	}
+
+	supportedReleaseBranches, err := makefile.GetVariableValue(projectRootFilepath, "SUPPORTED_K8S_VERSIONS", "")
+	if err != nil {
+		return nil, err
+	}
+	skippedReleaseBranches, err := makefile.GetVariableValue(projectRootFilepath, "SKIPPED_K8S_VERSIONS", "")
+	if err != nil {
+		return nil, err
+	}
+	skipped := strings.Split(strings.ReplaceAll(skippedReleaseBranches, " ", ""), ",")
+
+	releaseBranches := []string{}
+	for _, releaseBranch := range strings.Fields(supportedReleaseBranches) {
+		if !slices.Contains(skipped, releaseBranch) {
+			releaseBranches = append(releaseBranches, releaseBranch)
+		}
+	}
+	if len(releaseBranches) == 0 {
+		return nil, fmt.Errorf("no supported release branches found")
+	}
+
+	return releaseBranches, nil
+}
+
+// applyPatches applies the release branch's patch series to the worktree with git am, the same way the
+// project's checkout-repo Make target does, and records the outcome in the result. A patch series that
+// applies but adds source files without a license header fails verification as well.
+func applyPatches(worktreePath string, result *types.PatchVerificationResult) {
+	patches, err := filepath.Glob(filepath.Join(result.PatchesDirectory, constants.PatchFileGlob))
+	if err != nil || len(patches) == 0 {
+		result.Succeeded = true
+		result.Details = "No patches"
+		return
+	}
+
+	args := []string{"-C", worktreePath, "-c", fmt.Sprintf("user.name=%s", constants.PatchCommitterName), "-c", fmt.Sprintf("user.email=%s", constants.PatchCommitterEmail), "am", "--committer-date-is-author-date"}
+	applyPatchesCmd := exec.Command("git", append(args, patches...)...)
+	applyPatchesOutput, err := command.ExecCommand(applyPatchesCmd)
+	if err != nil {
+		failedPatch := regexp.MustCompile(constants.FailedPatchApplyRegex).FindString(applyPatchesOutput)
+		if failedPatch == "" {
+			failedPatch = "git am failed"
+		}
+		result.Details = failedPatch
+		return
+	}
+
+	missingLicenseHeaders, err := findMissingLicenseHeaders(patches)
+	if err != nil {
+		logger.Warn("Unable to read patches", "Release branch", result.ReleaseBranch, "Error", err)
+		result.Details = "Failed to read patches"
+		return
+	}
+	if len(missingLicenseHeaders) > 0 {
+		result.MissingLicenseHeaders = missingLicenseHeaders
+		result.Details = fmt.Sprintf("Applied %d patches, missing license header in %s", len(patches), strings.Join(missingLicenseHeaders, ", "))
+		return
+	}
+
+	result.Succeeded = true
+	result.Details = fmt.Sprintf("Applied %d patches", len(patches))
+}
+
+// recordProvenance records in the provenance files of the release branch's patches that they apply to the
+// release branch's Git tag.
+func recordProvenance(result types.PatchVerificationResult) error {
+	patches, err := filepath.Glob(filepath.Join(result.PatchesDirectory, constants.PatchFileGlob))
+	if err != nil {
+		return fmt.Errorf("listing patches: %v", err)
+	}
+	for _, patchFile := range patches {
+		err = provenance.RecordVerification(patchFile, result.GitTag)
+		if err != nil {
+			return fmt.Errorf("recording verification of %s: %v", filepath.Base(patchFile), err)
+		}
+	}
+
+	return nil
+}
+
+// findMissingLicenseHeaders returns the source files added by the patch series that do not start with a
+// license header.
+func findMissingLicenseHeaders(patches []string) ([]string, error) {
+	missingLicenseHeaders := []string{}
+	for _, patchFile := range patches {
+		contents, err := os.ReadFile(patchFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading patch file %s: %v", patchFile, err)
+		}
+		missingLicenseHeaders = append(missingLicenseHeaders, patch.MissingLicenseHeaders(string(contents))...)
+	}
+
+	return missingLicenseHeaders, nil
+}
@@ -0,0 +1,43 @@
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rodaine/table"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/buildinfo"
+)
+
+// Run contains the business logic to execute the `version` subcommand.
+func Run(versionOptions *types.VersionOptions) error {
+	if versionOptions.OutputFormat != constants.TableOutputFormat && versionOptions.OutputFormat != constants.JSONOutputFormat {
+		return fmt.Errorf("invalid output format %s, must be one of %s or %s", versionOptions.OutputFormat, constants.TableOutputFormat, constants.JSONOutputFormat)
+	}
+
+	buildInfo := buildinfo.Get()
+
+	if versionOptions.OutputFormat == constants.JSONOutputFormat {
+		buildInfoJSON, err := json.MarshalIndent(buildInfo, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshalling build information: %v", err)
+		}
+		fmt.Println(string(buildInfoJSON))
+		return nil
+	}
+
+	tbl := table.New("Field", "Value").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	})
+	tbl.AddRow("Version", buildInfo.Version)
+	tbl.AddRow("Git Commit", buildInfo.GitCommit)
+	tbl.AddRow("Build Date", buildInfo.BuildDate)
+	tbl.AddRow("Go Version", buildInfo.GoVersion)
+	tbl.AddRow("Platform", buildInfo.Platform)
+	tbl.Print()
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package eksdistro
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	gogithub "github.com/google/go-github/v53/github"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/github"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/slices"
+)
+
+// GetLatestRelease returns the latest production release number and Kubernetes version for the given
+// EKS Distro release branch, as recorded in the EKS Distro repository.
+func GetLatestRelease(client *gogithub.Client, branch string) (int, string, error) {
+	eksDistroProdReleaseNumberFile := fmt.Sprintf(constants.EKSDistroProdReleaseNumberFileFormat, branch)
+	releaseNumber, err := github.GetFileContents(client, "aws", "eks-distro", eksDistroProdReleaseNumberFile, constants.MainBranchName)
+	if err != nil {
+		return 0, "", fmt.Errorf("getting contents of EKS Distro prod release number file %s: %v", eksDistroProdReleaseNumberFile, err)
+	}
+
+	kubernetesGitTagFile := fmt.Sprintf(constants.KubernetesGitTagFileFormat, branch)
+	kubeVersion, err := github.GetFileContents(client, "aws", "eks-distro", kubernetesGitTagFile, constants.MainBranchName)
+	if err != nil {
+		return 0, "", fmt.Errorf("getting contents of Kubernetes Git tag file %s: %v", kubernetesGitTagFile, err)
+	}
+
+	releaseNumberInt, err := strconv.Atoi(strings.TrimRight(string(releaseNumber), "\n"))
+	if err != nil {
+		return 0, "", fmt.Errorf("converting release number to integer: %v", err)
+	}
+
+	kubeVersionTrimmed := strings.TrimRight(string(kubeVersion), "\n")
+
+	return releaseNumberInt, kubeVersionTrimmed, nil
+}
+
+// GetReleaseForBranch returns the EKS Distro release tracked for the given release branch in the
+// contents of the EKS Distro latest releases file.
+func GetReleaseForBranch(latestReleasesFileContents []byte, branch string) (types.EKSDistroRelease, error) {
+	var eksDistroLatestReleases types.EKSDistroLatestReleases
+	err := yaml.Unmarshal(latestReleasesFileContents, &eksDistroLatestReleases)
+	if err != nil {
+		return types.EKSDistroRelease{}, fmt.Errorf("unmarshalling EKS Distro latest releases file: %v", err)
+	}
+
+	for _, release := range eksDistroLatestReleases.Releases {
+		if release.Branch == branch {
+			return release, nil
+		}
+	}
+
+	return types.EKSDistroRelease{}, fmt.Errorf("no EKS Distro release found for %s branch", branch)
+}
+
+// GetReleaseManifestURL returns the URL of the EKS Distro release manifest for the given release branch
+// and release number. Development releases are served from the EKS Distro postsubmit artifacts bucket.
+func GetReleaseManifestURL(branch string, number int, dev bool) string {
+	domain := constants.EKSDistroProdDomain
+	if dev {
+		domain = constants.EKSDistroDevDomain
+	}
+	manifestPath := fmt.Sprintf(constants.EKSDistroReleaseManifestPathFormat, branch, number)
+
+	return fmt.Sprintf("https://%s/%s", domain, manifestPath)
+}
+
+// GetReleaseManifest downloads and parses the EKS Distro release manifest for the given release branch
+// and release number.
+func GetReleaseManifest(branch string, number int, dev bool) (*types.EKSDistroReleaseManifest, error) {
+	manifestURL := GetReleaseManifestURL(branch, number, dev)
+	logger.V(6).Info(fmt.Sprintf("Downloading EKS Distro release manifest from %s", manifestURL))
+
+	resp, err := http.Get(manifestURL)
+	if err != nil {
+		return nil, fmt.Errorf("downloading EKS Distro release manifest: %v", err)
+	}
+	defer resp.Body.Close()
+
+	manifestContents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading EKS Distro release manifest: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading EKS Distro release manifest from %s returned status %d", manifestURL, resp.StatusCode)
+	}
+
+	return ParseReleaseManifest(manifestContents)
+}
+
+// ParseReleaseManifest unmarshals the contents of an EKS Distro release manifest.
+func ParseReleaseManifest(contents []byte) (*types.EKSDistroReleaseManifest, error) {
+	var manifest types.EKSDistroReleaseManifest
+	err := yaml.Unmarshal(contents, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling EKS Distro release manifest: %v", err)
+	}
+
+	return &manifest, nil
+}
+
+// GetComponent returns the component with the given name from the EKS Distro release manifest.
+func GetComponent(manifest *types.EKSDistroReleaseManifest, componentName string) (*types.EKSDistroComponent, error) {
+	for i := range manifest.Status.Components {
+		if manifest.Status.Components[i].Name == componentName {
+			return &manifest.Status.Components[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("component %s not found in EKS Distro release %s", componentName, manifest.Metadata.Name)
+}
+
+// GetComponentVersion returns the Git tag of the given component in the EKS Distro release manifest.
+func GetComponentVersion(manifest *types.EKSDistroReleaseManifest, componentName string) (string, error) {
+	component, err := GetComponent(manifest, componentName)
+	if err != nil {
+		return "", err
+	}
+
+	return component.GitTag, nil
+}
+
+// GetArchiveAsset returns the archive URI and checksums of a component asset for the given architecture.
+// If assetName is empty, the first archive asset of the component for that architecture is returned.
+func GetArchiveAsset(manifest *types.EKSDistroReleaseManifest, componentName, assetName, arch string) (*types.EKSDistroAssetArchive, error) {
+	asset, err := getAsset(manifest, componentName, assetName, arch, constants.EKSDistroArchiveAssetType)
+	if err != nil {
+		return nil, err
+	}
+	if asset.Archive == nil {
+		return nil, fmt.Errorf("asset %s of component %s has no archive", asset.Name, componentName)
+	}
+
+	return asset.Archive, nil
+}
+
+// GetImageURI returns the image URI of a component asset for the given architecture.
+func GetImageURI(manifest *types.EKSDistroReleaseManifest, componentName, assetName, arch string) (string, error) {
+	asset, err := getAsset(manifest, componentName, assetName, arch, constants.EKSDistroImageAssetType)
+	if err != nil {
+		return "", err
+	}
+	if asset.Image == nil {
+		return "", fmt.Errorf("asset %s of component %s has no image", asset.Name, componentName)
+	}
+
+	return asset.Image.URI, nil
+}
+
+func getAsset(manifest *types.EKSDistroReleaseManifest, componentName, assetName, arch, assetType string) (*types.EKSDistroAsset, error) {
+	component, err := GetComponent(manifest, componentName)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range component.Assets {
+		asset := &component.Assets[i]
+		if asset.Type != assetType || !slices.Contains(asset.Arch, arch) {
+			continue
+		}
+		if assetName == "" || asset.Name == assetName {
+			return asset, nil
+		}
+	}
+
+	if assetName == "" {
+		return nil, fmt.Errorf("no %s asset found for %s architecture in component %s", assetType, arch, componentName)
+	}
+	return nil, fmt.Errorf("%s asset %s not found for %s architecture in component %s", assetType, assetName, arch, componentName)
+}
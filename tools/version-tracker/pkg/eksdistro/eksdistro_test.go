@@ -0,0 +1,250 @@
+package eksdistro
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+)
+
+const latestReleasesFileContents = `latest: 1-28
+releases:
+- branch: 1-28
+  kubeVersion: v1.28.7
+  number: 18
+- branch: 1-29
+  dev: true
+  kubeVersion: v1.29.1
+  number: 7
+`
+
+func loadTestManifest(t *testing.T) *types.EKSDistroReleaseManifest {
+	t.Helper()
+
+	contents, err := os.ReadFile(filepath.Join("testdata", "kubernetes-1-28-eks-18.yaml"))
+	if err != nil {
+		t.Fatalf("Error reading test manifest: %v", err)
+	}
+
+	manifest, err := ParseReleaseManifest(contents)
+	if err != nil {
+		t.Fatalf("Error parsing test manifest: %v", err)
+	}
+
+	return manifest
+}
+
+func TestGetReleaseForBranch(t *testing.T) {
+	testcases := []struct {
+		name    string
+		branch  string
+		number  int
+		dev     bool
+		wantErr bool
+	}{
+		{
+			name:   "Production release",
+			branch: "1-28",
+			number: 18,
+		},
+		{
+			name:   "Development release",
+			branch: "1-29",
+			number: 7,
+			dev:    true,
+		},
+		{
+			name:    "Untracked release branch",
+			branch:  "1-17",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			release, err := GetReleaseForBranch([]byte(latestReleasesFileContents), tc.branch)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error. Got: %v", err)
+			}
+			if tc.wantErr {
+				return
+			}
+			if release.Number != tc.number {
+				t.Fatalf("Unexpected release number. Expected: %d, Got: %d", tc.number, release.Number)
+			}
+			if (release.Dev != nil && *release.Dev) != tc.dev {
+				t.Fatalf("Unexpected dev release value. Expected: %t, Got: %v", tc.dev, release.Dev)
+			}
+		})
+	}
+}
+
+func TestGetReleaseManifestURL(t *testing.T) {
+	testcases := []struct {
+		name   string
+		branch string
+		number int
+		dev    bool
+		url    string
+	}{
+		{
+			name:   "Production release",
+			branch: "1-28",
+			number: 18,
+			url:    "https://distro.eks.amazonaws.com/kubernetes-1-28/kubernetes-1-28-eks-18.yaml",
+		},
+		{
+			name:   "Development release",
+			branch: "1-29",
+			number: 7,
+			dev:    true,
+			url:    "https://eks-d-postsubmit-artifacts.s3.us-west-2.amazonaws.com/kubernetes-1-29/kubernetes-1-29-eks-7.yaml",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := GetReleaseManifestURL(tc.branch, tc.number, tc.dev)
+			if got != tc.url {
+				t.Fatalf("Unexpected URL. Expected: %s, Got: %s", tc.url, got)
+			}
+		})
+	}
+}
+
+func TestParseReleaseManifest(t *testing.T) {
+	manifest := loadTestManifest(t)
+
+	if manifest.Metadata.Name != "kubernetes-1-28-eks-18" {
+		t.Fatalf("Unexpected release name. Got: %s", manifest.Metadata.Name)
+	}
+	if manifest.Spec.Channel != "1-28" || manifest.Spec.Number != 18 {
+		t.Fatalf("Unexpected release spec. Got: %+v", manifest.Spec)
+	}
+	if len(manifest.Status.Components) != 2 {
+		t.Fatalf("Unexpected number of components. Expected: 2, Got: %d", len(manifest.Status.Components))
+	}
+}
+
+func TestGetComponentVersion(t *testing.T) {
+	manifest := loadTestManifest(t)
+
+	testcases := []struct {
+		name      string
+		component string
+		version   string
+		wantErr   bool
+	}{
+		{
+			name:      "Kubernetes version",
+			component: "kubernetes",
+			version:   "v1.28.7",
+		},
+		{
+			name:      "CNI plugins version",
+			component: "cni-plugins",
+			version:   "v1.2.0",
+		},
+		{
+			name:      "Missing component",
+			component: "etcd",
+			wantErr:   true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := GetComponentVersion(manifest, tc.component)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error. Got: %v", err)
+			}
+			if got != tc.version {
+				t.Fatalf("Unexpected version. Expected: %s, Got: %s", tc.version, got)
+			}
+		})
+	}
+}
+
+func TestGetArchiveAsset(t *testing.T) {
+	manifest := loadTestManifest(t)
+
+	testcases := []struct {
+		name      string
+		component string
+		asset     string
+		arch      string
+		uri       string
+		sha256    string
+		wantErr   bool
+	}{
+		{
+			name:      "Named asset for amd64",
+			component: "kubernetes",
+			asset:     "bin/linux/amd64/kube-apiserver",
+			arch:      "amd64",
+			uri:       "https://distro.eks.amazonaws.com/kubernetes-1-28/releases/18/artifacts/kubernetes/v1.28.7/bin/linux/amd64/kube-apiserver",
+			sha256:    "0b9a1b3e2e4f1b1f4c0e3c9b8a7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a1b0c9d",
+		},
+		{
+			name:      "First archive asset for arm64",
+			component: "kubernetes",
+			arch:      "arm64",
+			uri:       "https://distro.eks.amazonaws.com/kubernetes-1-28/releases/18/artifacts/kubernetes/v1.28.7/bin/linux/arm64/kube-apiserver",
+			sha256:    "9d0c1b2a3f4e5d6c7b8a9f0e1d2c3b4a5f6e7d8c9b0a1f2e3d4c5b6a7f8e9d0c",
+		},
+		{
+			name:      "Component archive",
+			component: "cni-plugins",
+			arch:      "amd64",
+			uri:       "https://distro.eks.amazonaws.com/kubernetes-1-28/releases/18/artifacts/plugins/v1.2.0/cni-plugins-linux-amd64-v1.2.0.tar.gz",
+			sha256:    "5e6f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f",
+		},
+		{
+			name:      "Image asset is not an archive",
+			component: "kubernetes",
+			asset:     "kube-apiserver-image",
+			arch:      "amd64",
+			wantErr:   true,
+		},
+		{
+			name:      "Unsupported architecture",
+			component: "cni-plugins",
+			arch:      "arm64",
+			wantErr:   true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := GetArchiveAsset(manifest, tc.component, tc.asset, tc.arch)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error. Got: %v", err)
+			}
+			if tc.wantErr {
+				return
+			}
+			if got.URI != tc.uri {
+				t.Fatalf("Unexpected URI. Expected: %s, Got: %s", tc.uri, got.URI)
+			}
+			if got.SHA256 != tc.sha256 {
+				t.Fatalf("Unexpected checksum. Expected: %s, Got: %s", tc.sha256, got.SHA256)
+			}
+		})
+	}
+}
+
+func TestGetImageURI(t *testing.T) {
+	manifest := loadTestManifest(t)
+
+	for _, arch := range []string{"amd64", "arm64"} {
+		got, err := GetImageURI(manifest, "kubernetes", "kube-apiserver-image", arch)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s architecture. Got: %v", arch, err)
+		}
+		want := "public.ecr.aws/eks-distro/kubernetes/kube-apiserver:v1.28.7-eks-1-28-18"
+		if got != want {
+			t.Fatalf("Unexpected image URI for %s architecture. Expected: %s, Got: %s", arch, want, got)
+		}
+	}
+
+	if _, err := GetImageURI(manifest, "kubernetes", "bin/linux/amd64/kube-apiserver", "amd64"); err == nil {
+		t.Fatal("Expected error for archive asset, got nil")
+	}
+}
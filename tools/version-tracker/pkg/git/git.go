@@ -13,21 +13,24 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 
-	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/progress"
 )
 
 // CloneRepo clones the remote repository to a destination folder and creates a Git remote.
 func CloneRepo(cloneURL, destination, headRepoOwner string) (*git.Repository, string, error) {
 	logger.V(6).Info(fmt.Sprintf("Cloning repository [%s] to %s directory", cloneURL, destination))
-	progress := io.Discard
+	progressWriter := io.Discard
 	if logger.Verbosity >= 6 {
-		progress = os.Stdout
+		progressWriter = os.Stdout
 	}
+	stopProgress := progress.Start("cloning repository", "URL", cloneURL)
 	repo, err := git.PlainClone(destination, false, &git.CloneOptions{
 		URL:      cloneURL,
-		Progress: progress,
+		Progress: progressWriter,
 	})
+	stopProgress()
 	if err != nil {
 		if err == git.ErrRepositoryAlreadyExists {
 			logger.V(6).Info(fmt.Sprintf("Repo already exists at %s", destination))
@@ -76,6 +79,52 @@ func ResetToMain(worktree *git.Worktree, baseRepoHeadCommit string) error {
 	return nil
 }
 
+// DeleteBranch checks out the given commit and deletes the local branch, so that it can be created afresh.
+func DeleteBranch(repo *git.Repository, worktree *git.Worktree, branch, commit string) error {
+	logger.V(6).Info(fmt.Sprintf("Deleting branch [%s] in local repository", branch))
+
+	err := worktree.Checkout(&git.CheckoutOptions{
+		Hash:  plumbing.NewHash(commit),
+		Force: true,
+	})
+	if err != nil {
+		return fmt.Errorf("checking out commit %s: %v", commit, err)
+	}
+
+	err = repo.Storer.RemoveReference(plumbing.NewBranchReferenceName(branch))
+	if err != nil {
+		return fmt.Errorf("deleting branch [%s]: %v", branch, err)
+	}
+
+	return nil
+}
+
+// DiscardChanges hard-resets the working tree to the current HEAD commit, removing the given files if they are not
+// tracked, so that any changes made to them since the last commit are discarded.
+func DiscardChanges(worktree *git.Worktree, paths []string) error {
+	logger.V(6).Info("Discarding changes in local worktree")
+
+	err := worktree.Reset(&git.ResetOptions{Mode: git.HardReset})
+	if err != nil {
+		return fmt.Errorf("resetting to HEAD commit: %v", err)
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return fmt.Errorf("getting worktree status: %v", err)
+	}
+	for _, path := range paths {
+		if status.IsUntracked(path) {
+			err = worktree.Filesystem.Remove(path)
+			if err != nil {
+				return fmt.Errorf("removing untracked file [%s]: %v", path, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // Checkout checks out the working tree at the given branch, creating a new branch if necessary.
 func Checkout(worktree *git.Worktree, branch string) error {
 	logger.V(6).Info(fmt.Sprintf("Checking out branch [%s] in local worktree", branch))
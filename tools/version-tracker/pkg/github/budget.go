@@ -0,0 +1,122 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v53/github"
+)
+
+// APIBudget tracks the GitHub API calls made by a client against an optional budget, along with the remaining
+// rate limit quota reported by GitHub, so that long-running scans can stop before running out of either.
+type APIBudget struct {
+	mu                 sync.Mutex
+	maxCalls           int
+	calls              int
+	rateLimitRemaining int
+	rateLimitReset     time.Time
+}
+
+// apiBudgetTransport is an HTTP transport that authenticates requests to the GitHub API with a token and records
+// them against an API budget, refusing to make further requests once the budget or rate limit is exhausted.
+type apiBudgetTransport struct {
+	token  string
+	budget *APIBudget
+	base   http.RoundTripper
+}
+
+// NewClientWithBudget returns a GitHub client authenticated with the given token that makes at most maxAPICalls API
+// calls, or an unlimited number if maxAPICalls is zero, along with the budget tracking its API usage.
+func NewClientWithBudget(githubToken string, maxAPICalls int) (*github.Client, *APIBudget) {
+	budget := &APIBudget{
+		maxCalls:           maxAPICalls,
+		rateLimitRemaining: -1,
+	}
+	transport := &apiBudgetTransport{
+		token:  githubToken,
+		budget: budget,
+		base:   http.DefaultTransport,
+	}
+
+	return github.NewClient(&http.Client{Transport: transport}), budget
+}
+
+func (t *apiBudgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	err := t.budget.reserve()
+	if err != nil {
+		return nil, err
+	}
+
+	authenticatedReq := req.Clone(req.Context())
+	authenticatedReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.token))
+	resp, err := t.base.RoundTrip(authenticatedReq)
+	if resp != nil {
+		t.budget.update(resp.Header)
+	}
+
+	return resp, err
+}
+
+// Exhausted returns whether the API call budget has been used up or the GitHub rate limit has been reached.
+func (b *APIBudget) Exhausted() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.budgetExhausted() || b.rateLimitExhausted()
+}
+
+// Calls returns the number of GitHub API calls made so far.
+func (b *APIBudget) Calls() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.calls
+}
+
+// RateLimitReset returns the time at which the GitHub rate limit resets, or the zero time if it is not known.
+func (b *APIBudget) RateLimitReset() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.rateLimitReset
+}
+
+// reserve records an API call against the budget, or returns an error if the budget or rate limit is exhausted.
+func (b *APIBudget) reserve() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.budgetExhausted() {
+		return fmt.Errorf("GitHub API call budget of %d calls exhausted", b.maxCalls)
+	}
+	if b.rateLimitExhausted() {
+		return fmt.Errorf("GitHub API rate limit exhausted until %s", b.rateLimitReset.Format(time.RFC3339))
+	}
+	b.calls++
+
+	return nil
+}
+
+// update records the remaining rate limit quota and its reset time from the headers of a GitHub API response.
+func (b *APIBudget) update(header http.Header) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil {
+		b.rateLimitRemaining = remaining
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		b.rateLimitReset = time.Unix(reset, 0)
+	}
+}
+
+func (b *APIBudget) budgetExhausted() bool {
+	return b.maxCalls > 0 && b.calls >= b.maxCalls
+}
+
+func (b *APIBudget) rateLimitExhausted() bool {
+	return b.rateLimitRemaining == 0 && time.Now().Before(b.rateLimitReset)
+}
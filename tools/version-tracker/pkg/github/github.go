@@ -7,16 +7,20 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/aws/eks-anywhere/pkg/semver"
 	"github.com/google/go-github/v53/github"
 
-	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/file"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/slices"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/tar"
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/version"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
 )
 
 // getReleasesForRepo retrieves the list of releases for the given GitHub repository.
@@ -70,6 +74,60 @@ func getTagsForRepo(client *github.Client, org, repo string) ([]*github.Reposito
 	return allTags, nil
 }
 
+// GetBranchesWithPrefix retrieves the names of the branches in the given GitHub repository that start with the given prefix.
+func GetBranchesWithPrefix(client *github.Client, org, repo, prefix string) ([]string, error) {
+	logger.V(6).Info(fmt.Sprintf("Getting branches with prefix %s for [%s/%s] repository", prefix, org, repo))
+	var branchNames []string
+	listBranchOptions := &github.BranchListOptions{
+		ListOptions: github.ListOptions{
+			PerPage: constants.GithubPerPage,
+		},
+	}
+
+	for {
+		branches, resp, err := client.Repositories.ListBranches(context.Background(), org, repo, listBranchOptions)
+		if err != nil {
+			return nil, fmt.Errorf("calling ListBranches API for [%s/%s] repository: %v", org, repo, err)
+		}
+		for _, branch := range branches {
+			if strings.HasPrefix(branch.GetName(), prefix) {
+				branchNames = append(branchNames, branch.GetName())
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		listBranchOptions.Page = resp.NextPage
+	}
+
+	return branchNames, nil
+}
+
+// GetPullRequestsForBranch retrieves the open and closed pull requests from the given head branch to the
+// build-tooling repository.
+func GetPullRequestsForBranch(client *github.Client, baseRepoOwner, headRepoOwner, headBranch string) ([]*github.PullRequest, error) {
+	pullRequests, _, err := client.PullRequests.List(context.Background(), baseRepoOwner, constants.BuildToolingRepoName, &github.PullRequestListOptions{
+		State: "all",
+		Head:  fmt.Sprintf("%s:%s", headRepoOwner, headBranch),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing pull requests from %s:%s: %v", headRepoOwner, headBranch, err)
+	}
+
+	return pullRequests, nil
+}
+
+// DeleteBranch deletes the given branch from the GitHub repository.
+func DeleteBranch(client *github.Client, org, repo, branch string) error {
+	_, err := client.Git.DeleteRef(context.Background(), org, repo, fmt.Sprintf("heads/%s", branch))
+	if err != nil {
+		return fmt.Errorf("deleting branch %s in [%s/%s] repository: %v", branch, org, repo, err)
+	}
+
+	return nil
+}
+
 // getCommitsForRepo retrieves the list of commits for the given GitHub repository.
 func getCommitsForRepo(client *github.Client, org, repo string) ([]*github.RepositoryCommit, error) {
 	logger.V(6).Info(fmt.Sprintf("Getting commits for [%s/%s] repository", org, repo))
@@ -121,6 +179,53 @@ func GetFileContents(client *github.Client, org, repo, filePath, ref string) ([]
 	return contentsDecoded, nil
 }
 
+// GetDefaultBranch returns the default branch of a given GitHub repository.
+func GetDefaultBranch(client *github.Client, org, repo string) (string, error) {
+	logger.V(6).Info(fmt.Sprintf("Getting default branch for [%s/%s] repository", org, repo))
+
+	repository, _, err := client.Repositories.Get(context.Background(), org, repo)
+	if err != nil {
+		return "", fmt.Errorf("getting [%s/%s] repository: %v", org, repo, err)
+	}
+
+	return repository.GetDefaultBranch(), nil
+}
+
+// GetBranchHeadCommit returns the commit hash at the head of the given branch of a GitHub repository.
+func GetBranchHeadCommit(client *github.Client, org, repo, branch string) (string, error) {
+	logger.V(6).Info(fmt.Sprintf("Getting head commit of %s branch for [%s/%s] repository", branch, org, repo))
+
+	githubBranch, _, err := client.Repositories.GetBranch(context.Background(), org, repo, branch, true)
+	if err != nil {
+		return "", fmt.Errorf("getting %s branch for [%s/%s] repository: %v", branch, org, repo, err)
+	}
+
+	return githubBranch.GetCommit().GetSHA(), nil
+}
+
+// GetCommitLog returns the abbreviated hash and subject line of the commits between the current and latest
+// revisions of a given GitHub repository, oldest first.
+func GetCommitLog(client *github.Client, org, repo, currentRevision, latestRevision string) ([]string, error) {
+	logger.V(6).Info(fmt.Sprintf("Getting commit log between %s and %s for [%s/%s] repository", currentRevision, latestRevision, org, repo))
+
+	comparison, _, err := client.Repositories.CompareCommits(context.Background(), org, repo, currentRevision, latestRevision, &github.ListOptions{PerPage: constants.GithubPerPage})
+	if err != nil {
+		return nil, fmt.Errorf("comparing commits between %s and %s for [%s/%s] repository: %v", currentRevision, latestRevision, org, repo, err)
+	}
+
+	commitLog := []string{}
+	for _, commit := range comparison.Commits {
+		shortSHA := commit.GetSHA()
+		if len(shortSHA) > 7 {
+			shortSHA = shortSHA[:7]
+		}
+		subject := strings.SplitN(commit.GetCommit().GetMessage(), "\n", 2)[0]
+		commitLog = append(commitLog, fmt.Sprintf("%s %s", shortSHA, subject))
+	}
+
+	return commitLog, nil
+}
+
 // GetLatestRevision returns the latest revision (GitHub release or tag) for a given GitHub repository.
 func GetLatestRevision(client *github.Client, org, repo, currentRevision string) (string, bool, error) {
 	logger.V(6).Info(fmt.Sprintf("Getting latest revision for [%s/%s] repository", org, repo))
@@ -154,49 +259,206 @@ func GetLatestRevision(client *github.Client, org, repo, currentRevision string)
 		return "", false, fmt.Errorf("getting semver for current version: %v", err)
 	}
 
-	// If the project has GitHub releases, determine the latest from among them.
-	if len(allReleases) > 0 {
-		for _, release := range allReleases {
-			latestRevision = *release.TagName
+	// If the project has neither GitHub releases nor tags, pick the latest commit.
+	if len(allReleases) == 0 && len(allTags) == 0 {
+		allCommits, err := getCommitsForRepo(client, org, repo)
+		if err != nil {
+			return "", false, fmt.Errorf("getting all commits for [%s/%s] repository: %v", org, repo, err)
+		}
+		return *allCommits[0].SHA, true, nil
+	}
 
-			// Determine if upgrade is required based on current and latest revisions
-			upgradeRequired, shouldBreak, err := isUpgradeRequired(client, org, repo, latestRevision, currentRevisionCommitEpoch, currentRevisionSemver, allTags)
-			if err != nil {
-				return "", false, fmt.Errorf("determining if upgrade is required for project: %v", err)
+	// Determine the latest from among the GitHub releases and/or tags, according to the project's version resolution policy.
+	candidateRevisions, err := getCandidateRevisions(client, org, repo, allReleases, allTags)
+	if err != nil {
+		return "", false, fmt.Errorf("getting candidate revisions for [%s/%s] repository: %v", org, repo, err)
+	}
+	for _, candidateRevision := range candidateRevisions {
+		latestRevision = candidateRevision
+
+		// Determine if upgrade is required based on current and latest revisions
+		upgradeRequired, shouldBreak, err := isUpgradeRequired(client, org, repo, latestRevision, currentRevisionCommitEpoch, currentRevisionSemver, allTags)
+		if err != nil {
+			return "", false, fmt.Errorf("determining if upgrade is required for project: %v", err)
+		}
+		if shouldBreak {
+			needsUpgrade = upgradeRequired
+			break
+		}
+	}
+
+	return latestRevision, needsUpgrade, nil
+}
+
+// GetLatestPatchRevision gets the latest patch revision in the same minor version line as the current revision for
+// the given project, and determines if the project needs to be upgraded. Tags that carry a prefix before the version,
+// such as `cluster-autoscaler-1.29.0`, are only compared with tags that have the same prefix.
+func GetLatestPatchRevision(client *github.Client, org, repo, currentRevision string) (string, bool, error) {
+	logger.V(6).Info(fmt.Sprintf("Getting latest patch revision for %s for [%s/%s] repository", currentRevision, org, repo))
+	revisionPrefixRegex := regexp.MustCompile(constants.RevisionPrefixRegex)
+
+	currentRevisionMatches := revisionPrefixRegex.FindStringSubmatch(currentRevision)
+	if currentRevisionMatches == nil {
+		return "", false, fmt.Errorf("current revision %s does not contain a semantic version", currentRevision)
+	}
+	currentRevisionPrefix := currentRevisionMatches[1]
+	currentRevisionSemver, err := semver.New(currentRevisionMatches[2])
+	if err != nil {
+		return "", false, fmt.Errorf("getting semver for current version: %v", err)
+	}
+
+	// Get all GitHub tags for this project.
+	allTags, err := getTagsForRepo(client, org, repo)
+	if err != nil {
+		return "", false, fmt.Errorf("getting all tags for [%s/%s] repository: %v", org, repo, err)
+	}
+
+	latestRevision := currentRevision
+	latestRevisionSemver := currentRevisionSemver
+	for _, tag := range allTags {
+		tagMatches := revisionPrefixRegex.FindStringSubmatch(tag.GetName())
+		if tagMatches == nil || tagMatches[1] != currentRevisionPrefix {
+			continue
+		}
+		tagSemver, err := semver.New(tagMatches[2])
+		if err != nil || tagSemver.Prerelease != "" {
+			continue
+		}
+		if tagSemver.Major == currentRevisionSemver.Major && tagSemver.Minor == currentRevisionSemver.Minor && tagSemver.GreaterThan(latestRevisionSemver) {
+			latestRevision = tag.GetName()
+			latestRevisionSemver = tagSemver
+		}
+	}
+
+	return latestRevision, latestRevision != currentRevision, nil
+}
+
+// getCandidateRevisions returns the revisions to consider when determining the latest revision for the project,
+// newest first. By default, the project's GitHub releases are used, falling back to its tags if it has no releases.
+// Projects can instead be configured to use only tags, or both releases and tags, in which case the revisions are
+// ordered by semantic version with ties broken by commit date. Revisions can further be restricted to the ones
+// matching a tag regular expression and not matching any of the exclude patterns.
+func getCandidateRevisions(client *github.Client, org, repo string, allReleases []*github.RepositoryRelease, allTags []*github.RepositoryTag) ([]string, error) {
+	policy := constants.ProjectVersionResolutionPolicies[fmt.Sprintf("%s/%s", org, repo)]
+
+	releaseNames := []string{}
+	for _, release := range allReleases {
+		releaseNames = append(releaseNames, release.GetTagName())
+	}
+	tagNames := []string{}
+	for _, tag := range allTags {
+		tagNames = append(tagNames, tag.GetName())
+	}
+
+	var candidateRevisions []string
+	switch policy.Source {
+	case "", constants.ReleasesVersionSource:
+		candidateRevisions = releaseNames
+		if len(candidateRevisions) == 0 {
+			candidateRevisions = tagNames
+		}
+	case constants.TagsVersionSource:
+		candidateRevisions = tagNames
+	case constants.ReleasesAndTagsVersionSource:
+		candidateRevisions = releaseNames
+		for _, tagName := range tagNames {
+			if !slices.Contains(candidateRevisions, tagName) {
+				candidateRevisions = append(candidateRevisions, tagName)
 			}
-			if shouldBreak {
-				needsUpgrade = upgradeRequired
-				break
+		}
+		err := sortRevisions(client, org, repo, candidateRevisions, allTags)
+		if err != nil {
+			return nil, fmt.Errorf("sorting revisions: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("invalid version source %s", policy.Source)
+	}
+
+	if policy.TagRegex != "" {
+		tagRegex, err := regexp.Compile(policy.TagRegex)
+		if err != nil {
+			return nil, fmt.Errorf("compiling tag regex: %v", err)
+		}
+		filteredRevisions := []string{}
+		for _, candidateRevision := range candidateRevisions {
+			if tagRegex.MatchString(candidateRevision) {
+				filteredRevisions = append(filteredRevisions, candidateRevision)
 			}
 		}
-	} else {
-		// If the project doesn't have GitHub releases but has tags on GitHub, determine the latest from among them.
-		if len(allTags) > 0 {
-			for _, tag := range allTags {
-				latestRevision = *tag.Name
-
-				// Determine if upgrade is required based on current and latest revisions
-				upgradeRequired, shouldBreak, err := isUpgradeRequired(client, org, repo, latestRevision, currentRevisionCommitEpoch, currentRevisionSemver, allTags)
-				if err != nil {
-					return "", false, fmt.Errorf("determining if upgrade is required for project: %v", err)
-				}
-				if shouldBreak {
-					needsUpgrade = upgradeRequired
+		candidateRevisions = filteredRevisions
+	}
+
+	if len(policy.ExcludePatterns) > 0 {
+		excludeRegexes := []*regexp.Regexp{}
+		for _, excludePattern := range policy.ExcludePatterns {
+			excludeRegex, err := regexp.Compile(excludePattern)
+			if err != nil {
+				return nil, fmt.Errorf("compiling exclude pattern: %v", err)
+			}
+			excludeRegexes = append(excludeRegexes, excludeRegex)
+		}
+		filteredRevisions := []string{}
+		for _, candidateRevision := range candidateRevisions {
+			excluded := false
+			for _, excludeRegex := range excludeRegexes {
+				if excludeRegex.MatchString(candidateRevision) {
+					logger.V(6).Info(fmt.Sprintf("Excluding revision %s matching pattern %s", candidateRevision, excludeRegex.String()))
+					excluded = true
 					break
 				}
 			}
-		} else {
-			// If the project has neither Github releases nor tags, pick the latest commit.
-			allCommits, err := getCommitsForRepo(client, org, repo)
-			if err != nil {
-				return "", false, fmt.Errorf("getting all commits for [%s/%s] repository: %v", org, repo, err)
+			if !excluded {
+				filteredRevisions = append(filteredRevisions, candidateRevision)
 			}
-			latestRevision = *allCommits[0].SHA
-			needsUpgrade = true
 		}
+		candidateRevisions = filteredRevisions
 	}
 
-	return latestRevision, needsUpgrade, nil
+	return candidateRevisions, nil
+}
+
+// sortRevisions sorts the given revisions in descending order of semantic version. Revisions with the same semantic
+// version, such as a tag with and without the `v` prefix, are ordered by the date of their commit, newest first.
+// Revisions that are not semantic versions are placed last.
+func sortRevisions(client *github.Client, org, repo string, revisions []string, allTags []*github.RepositoryTag) error {
+	revisionSemvers := map[string]*semver.Version{}
+	for _, revision := range revisions {
+		revisionSemver, err := semver.New(revision)
+		if err == nil {
+			revisionSemvers[revision] = revisionSemver
+		}
+	}
+
+	var sortErr error
+	commitEpochs := map[string]int64{}
+	getCommitEpoch := func(revision string) int64 {
+		if epoch, ok := commitEpochs[revision]; ok {
+			return epoch
+		}
+		epoch, err := getCommitDateEpoch(client, org, repo, getCommitForTag(allTags, revision))
+		if err != nil && sortErr == nil {
+			sortErr = err
+		}
+		commitEpochs[revision] = epoch
+		return epoch
+	}
+
+	sort.SliceStable(revisions, func(i, j int) bool {
+		iSemver, iOk := revisionSemvers[revisions[i]]
+		jSemver, jOk := revisionSemvers[revisions[j]]
+		if !iOk || !jOk {
+			return iOk
+		}
+		if iSemver.GreaterThan(jSemver) {
+			return true
+		}
+		if jSemver.GreaterThan(iSemver) {
+			return false
+		}
+		return getCommitEpoch(revisions[i]) > getCommitEpoch(revisions[j])
+	})
+
+	return sortErr
 }
 
 // isUpgradeRequired determines if the project requires an upgrade by comparing the current revision to the latest revision.
@@ -248,9 +510,9 @@ func getCommitForTag(allTags []*github.RepositoryTag, searchTag string) string {
 // GetGoVersionForLatestRevision gets the Go version used to build the latest revision of the project.
 func GetGoVersionForLatestRevision(client *github.Client, org, repo, latestRevision string) (string, error) {
 	logger.V(6).Info(fmt.Sprintf("Getting Go version corresponding to latest revision %s for [%s/%s] repository", latestRevision, org, repo))
-	cwd, err := os.Getwd()
+	workspaceDir, err := workspace.Dir()
 	if err != nil {
-		return "", fmt.Errorf("retrieving current working directory: %v", err)
+		return "", fmt.Errorf("getting workspace directory: %v", err)
 	}
 
 	var goVersion string
@@ -286,7 +548,7 @@ func GetGoVersionForLatestRevision(client *github.Client, org, repo, latestRevis
 			}
 		}
 
-		tarballDownloadPath := filepath.Join(cwd, "github-release-downloads")
+		tarballDownloadPath := filepath.Join(workspaceDir, "github-release-downloads")
 		err = os.MkdirAll(tarballDownloadPath, 0o755)
 		if err != nil {
 			return "", fmt.Errorf("failed to create GitHub release downloads folder: %v", err)
@@ -340,8 +602,89 @@ func GetGoVersionForLatestRevision(client *github.Client, org, repo, latestRevis
 	return goVersion, nil
 }
 
+// GetBreakingChanges scans the release notes of the releases after the current revision up to the latest revision,
+// and the messages of the commits between the two revisions, for lines with breaking change markers.
+func GetBreakingChanges(client *github.Client, org, repo, currentRevision, latestRevision string) ([]types.MarkedChange, error) {
+	logger.V(6).Info(fmt.Sprintf("Getting breaking changes between %s and %s for [%s/%s] repository", currentRevision, latestRevision, org, repo))
+
+	return findMarkedChanges(client, org, repo, currentRevision, latestRevision, constants.BreakingChangeMarkers)
+}
+
+// HasSecurityFixes returns whether the upstream release notes and commit messages between the current and latest
+// revisions mention security fixes, such as CVE or GitHub security advisory identifiers.
+func HasSecurityFixes(client *github.Client, org, repo, currentRevision, latestRevision string) (bool, error) {
+	logger.V(6).Info(fmt.Sprintf("Getting security fixes between %s and %s for [%s/%s] repository", currentRevision, latestRevision, org, repo))
+	securityFixes, err := findMarkedChanges(client, org, repo, currentRevision, latestRevision, constants.SecurityFixMarkers)
+	if err != nil {
+		return false, err
+	}
+
+	return len(securityFixes) > 0, nil
+}
+
+// findMarkedChanges returns the lines of the upstream release notes and commit messages between the current and
+// latest revisions that match any of the given markers, along with the release tag or commit they were found in.
+func findMarkedChanges(client *github.Client, org, repo, currentRevision, latestRevision string, markers []string) ([]types.MarkedChange, error) {
+	markerRegexes := make([]*regexp.Regexp, 0, len(markers))
+	for _, marker := range markers {
+		markerRegexes = append(markerRegexes, regexp.MustCompile(marker))
+	}
+	markedChanges := []types.MarkedChange{}
+
+	currentRevisionSemver, currentErr := semver.New(currentRevision)
+	latestRevisionSemver, latestErr := semver.New(latestRevision)
+	if currentErr == nil && latestErr == nil {
+		allReleases, err := getReleasesForRepo(client, org, repo)
+		if err != nil {
+			return nil, fmt.Errorf("getting all releases for [%s/%s] repository: %v", org, repo, err)
+		}
+
+		for _, release := range allReleases {
+			releaseSemver, err := semver.New(release.GetTagName())
+			if err != nil || !releaseSemver.GreaterThan(currentRevisionSemver) || releaseSemver.GreaterThan(latestRevisionSemver) {
+				continue
+			}
+			for _, line := range findMarkedLines(release.GetBody(), markerRegexes) {
+				markedChanges = append(markedChanges, types.MarkedChange{Source: release.GetTagName(), Line: line})
+			}
+		}
+	}
+
+	comparison, _, err := client.Repositories.CompareCommits(context.Background(), org, repo, currentRevision, latestRevision, &github.ListOptions{PerPage: constants.GithubPerPage})
+	if err != nil {
+		return nil, fmt.Errorf("comparing commits between %s and %s for [%s/%s] repository: %v", currentRevision, latestRevision, org, repo, err)
+	}
+	for _, commit := range comparison.Commits {
+		shortSHA := commit.GetSHA()
+		if len(shortSHA) > 7 {
+			shortSHA = shortSHA[:7]
+		}
+		for _, line := range findMarkedLines(commit.GetCommit().GetMessage(), markerRegexes) {
+			markedChanges = append(markedChanges, types.MarkedChange{Source: shortSHA, Line: line})
+		}
+	}
+
+	return markedChanges, nil
+}
+
+// findMarkedLines returns the lines of the given text that match any of the markers.
+func findMarkedLines(text string, markerRegexes []*regexp.Regexp) []string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		for _, marker := range markerRegexes {
+			if marker.MatchString(line) {
+				lines = append(lines, line)
+				break
+			}
+		}
+	}
+
+	return lines
+}
+
 // CreatePullRequest creates a pull request from the head branch to the base branch on the base repository.
-func CreatePullRequest(client *github.Client, org, repo, title, body, baseRepoOwner, baseBranch, headRepoOwner, headBranch, currentRevision, latestRevision string, addPatchWarningComment bool, patchesWarningComment string) error {
+func CreatePullRequest(client *github.Client, org, repo, title, body, baseRepoOwner, baseBranch, headRepoOwner, headBranch, currentRevision, latestRevision string, addPatchWarningComment bool, patchesWarningComment string, labels []string) error {
 	var pullRequest *github.PullRequest
 	var patchWarningCommentExists bool
 
@@ -361,7 +704,7 @@ func CreatePullRequest(client *github.Client, org, repo, title, body, baseRepoOw
 		pullRequest.Body = github.String(body)
 		pullRequest, _, err = client.PullRequests.Edit(context.Background(), baseRepoOwner, constants.BuildToolingRepoName, *pullRequest.Number, pullRequest)
 		if err != nil {
-			return fmt.Errorf("editing existing pull request %s: %v", pullRequest.HTMLURL, err)
+			return fmt.Errorf("editing existing pull request %s: %v", pullRequest.GetHTMLURL(), err)
 		}
 
 		// If patches to the project failed to apply, check if the PR already has a comment warning about
@@ -369,7 +712,7 @@ func CreatePullRequest(client *github.Client, org, repo, title, body, baseRepoOw
 		if addPatchWarningComment {
 			pullRequestComments, _, err := client.Issues.ListComments(context.Background(), baseRepoOwner, constants.BuildToolingRepoName, *pullRequest.Number, nil)
 			if err != nil {
-				return fmt.Errorf("listing comments on pull request [%s]: %v", pullRequest.HTMLURL, err)
+				return fmt.Errorf("listing comments on pull request [%s]: %v", pullRequest.GetHTMLURL(), err)
 			}
 
 			for _, comment := range pullRequestComments {
@@ -409,5 +752,79 @@ func CreatePullRequest(client *github.Client, org, repo, title, body, baseRepoOw
 		}
 	}
 
+	if len(labels) > 0 {
+		_, _, err = client.Issues.AddLabelsToIssue(context.Background(), baseRepoOwner, constants.BuildToolingRepoName, *pullRequest.Number, labels)
+		if err != nil {
+			return fmt.Errorf("adding labels to pull request [%s]: %v", *pullRequest.HTMLURL, err)
+		}
+	}
+
+	return nil
+}
+
+// GetPullRequestURL returns the URL of the open pull request from the head branch to the base branch on the base repository.
+func GetPullRequestURL(client *github.Client, baseRepoOwner, baseBranch, headRepoOwner, headBranch string) (string, error) {
+	pullRequests, _, err := client.PullRequests.List(context.Background(), baseRepoOwner, constants.BuildToolingRepoName, &github.PullRequestListOptions{
+		Base: baseBranch,
+		Head: fmt.Sprintf("%s:%s", headRepoOwner, headBranch),
+	})
+	if err != nil {
+		return "", fmt.Errorf("listing pull requests from %s:%s -> %s:%s: %v", headRepoOwner, headBranch, baseRepoOwner, baseBranch, err)
+	}
+	if len(pullRequests) == 0 {
+		return "", fmt.Errorf("no open pull request found from %s:%s -> %s:%s", headRepoOwner, headBranch, baseRepoOwner, baseBranch)
+	}
+
+	return pullRequests[0].GetHTMLURL(), nil
+}
+
+// HasOpenPullRequest returns whether there is an open pull request from the head branch to the base branch.
+func HasOpenPullRequest(client *github.Client, baseRepoOwner, baseBranch, headRepoOwner, headBranch string) (bool, error) {
+	pullRequests, _, err := client.PullRequests.List(context.Background(), baseRepoOwner, constants.BuildToolingRepoName, &github.PullRequestListOptions{
+		Base: baseBranch,
+		Head: fmt.Sprintf("%s:%s", headRepoOwner, headBranch),
+	})
+	if err != nil {
+		return false, fmt.Errorf("listing pull requests from %s:%s -> %s:%s: %v", headRepoOwner, headBranch, baseRepoOwner, baseBranch, err)
+	}
+
+	return len(pullRequests) > 0, nil
+}
+
+// CloseSupersededPullRequests closes the open pull requests from the given superseded branches to the base branch, with
+// a comment linking the pull request from the head branch that replaces them.
+func CloseSupersededPullRequests(client *github.Client, baseRepoOwner, baseBranch, headRepoOwner, headBranch string, supersededBranches []string) error {
+	replacementPullRequestURL, err := GetPullRequestURL(client, baseRepoOwner, baseBranch, headRepoOwner, headBranch)
+	if err != nil {
+		return err
+	}
+
+	for _, supersededBranch := range supersededBranches {
+		pullRequests, _, err := client.PullRequests.List(context.Background(), baseRepoOwner, constants.BuildToolingRepoName, &github.PullRequestListOptions{
+			Base: baseBranch,
+			Head: fmt.Sprintf("%s:%s", headRepoOwner, supersededBranch),
+		})
+		if err != nil {
+			return fmt.Errorf("listing pull requests from %s:%s -> %s:%s: %v", headRepoOwner, supersededBranch, baseRepoOwner, baseBranch, err)
+		}
+
+		for _, pullRequest := range pullRequests {
+			logger.Info("Closing superseded pull request", "Pull request", pullRequest.GetHTMLURL(), "Replacement", replacementPullRequestURL)
+			_, _, err = client.Issues.CreateComment(context.Background(), baseRepoOwner, constants.BuildToolingRepoName, pullRequest.GetNumber(), &github.IssueComment{
+				Body: github.String(fmt.Sprintf(constants.SupersededPullRequestComment, replacementPullRequestURL)),
+			})
+			if err != nil {
+				return fmt.Errorf("commenting on superseded pull request [%s]: %v", pullRequest.GetHTMLURL(), err)
+			}
+
+			_, _, err = client.PullRequests.Edit(context.Background(), baseRepoOwner, constants.BuildToolingRepoName, pullRequest.GetNumber(), &github.PullRequest{
+				State: github.String("closed"),
+			})
+			if err != nil {
+				return fmt.Errorf("closing superseded pull request [%s]: %v", pullRequest.GetHTMLURL(), err)
+			}
+		}
+	}
+
 	return nil
 }
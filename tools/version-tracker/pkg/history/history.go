@@ -0,0 +1,174 @@
+package history
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+)
+
+// entryFileTimeFormat is the format of the upgrade time in the names of the upgrade history entry files, which
+// sorts them chronologically.
+const entryFileTimeFormat = "20060102T150405Z"
+
+// Load reads and unmarshals the entries in the upgrade history directory, in chronological order. A missing
+// directory is treated as an empty history.
+func Load(historyDirectory string) (*types.UpgradeHistory, error) {
+	upgrades, _, err := loadEntries(historyDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.UpgradeHistory{Upgrades: upgrades}, nil
+}
+
+// loadEntries reads and unmarshals the upgrade history entry files under the given directory, and returns the
+// entries in chronological order along with the paths of the files they were read from.
+func loadEntries(directory string) ([]types.UpgradeHistoryEntry, []string, error) {
+	entryFilepaths := []string{}
+	err := filepath.WalkDir(directory, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && strings.HasSuffix(path, ".yaml") {
+			entryFilepaths = append(entryFilepaths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("listing upgrade history entry files: %v", err)
+	}
+
+	type entryFile struct {
+		entry    types.UpgradeHistoryEntry
+		filepath string
+	}
+	entryFiles := make([]entryFile, 0, len(entryFilepaths))
+	for _, entryFilepath := range entryFilepaths {
+		contents, err := os.ReadFile(entryFilepath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading upgrade history entry file: %v", err)
+		}
+
+		var entry types.UpgradeHistoryEntry
+		err = yaml.Unmarshal(contents, &entry)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unmarshalling upgrade history entry file %s: %v", entryFilepath, err)
+		}
+		entryFiles = append(entryFiles, entryFile{entry: entry, filepath: entryFilepath})
+	}
+
+	// The RFC 3339 dates in UTC sort chronologically as strings.
+	sort.SliceStable(entryFiles, func(i, j int) bool {
+		return entryFiles[i].entry.Date < entryFiles[j].entry.Date
+	})
+
+	entries := make([]types.UpgradeHistoryEntry, 0, len(entryFiles))
+	sortedEntryFilepaths := make([]string, 0, len(entryFiles))
+	for _, file := range entryFiles {
+		entries = append(entries, file.entry)
+		sortedEntryFilepaths = append(sortedEntryFilepaths, file.filepath)
+	}
+
+	return entries, sortedEntryFilepaths, nil
+}
+
+// writeEntry marshals the upgrade history entry and writes it to the given entry file.
+func writeEntry(entryFilepath string, entry types.UpgradeHistoryEntry) error {
+	contents, err := yaml.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshalling upgrade history entry: %v", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(entryFilepath), 0o755)
+	if err != nil {
+		return fmt.Errorf("creating upgrade history directory: %v", err)
+	}
+
+	err = os.WriteFile(entryFilepath, contents, 0o644)
+	if err != nil {
+		return fmt.Errorf("writing upgrade history entry file: %v", err)
+	}
+
+	return nil
+}
+
+// RecordUpgrade writes an entry for the given project version upgrade to the upgrade history directory, and
+// returns the path of the entry file. Each upgrade is recorded in its own file, under a directory for the project
+// and named after the upgrade time and release branch, so that the upgrade pull requests of different projects
+// never modify the same file and don't conflict with each other once one of them is merged.
+func RecordUpgrade(historyDirectory, project, releaseBranch, previousVersion, version string) (string, error) {
+	upgradeTime := time.Now().UTC()
+	entryFilename := upgradeTime.Format(entryFileTimeFormat)
+	if releaseBranch != "" {
+		entryFilename = fmt.Sprintf("%s-%s", entryFilename, releaseBranch)
+	}
+	entryFilepath := filepath.Join(historyDirectory, project, entryFilename+".yaml")
+
+	err := writeEntry(entryFilepath, types.UpgradeHistoryEntry{
+		Project:         project,
+		ReleaseBranch:   releaseBranch,
+		PreviousVersion: previousVersion,
+		Version:         version,
+		Date:            upgradeTime.Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return entryFilepath, nil
+}
+
+// LastUpgradeDate returns the date of the last recorded upgrade of the given project, and whether the project
+// has any recorded upgrade.
+func LastUpgradeDate(historyDirectory, project string) (time.Time, bool, error) {
+	upgrades, _, err := loadEntries(filepath.Join(historyDirectory, project))
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if len(upgrades) == 0 {
+		return time.Time{}, false, nil
+	}
+
+	lastUpgrade := upgrades[len(upgrades)-1]
+	upgradeDate, err := time.Parse(time.RFC3339, lastUpgrade.Date)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("parsing upgrade date %s: %v", lastUpgrade.Date, err)
+	}
+
+	return upgradeDate, true, nil
+}
+
+// RecordPullRequest sets the pull request on the entries for the given project that don't have one yet, and
+// returns the paths of the updated entry files.
+func RecordPullRequest(historyDirectory, project, pullRequestURL string) ([]string, error) {
+	upgrades, entryFilepaths, err := loadEntries(filepath.Join(historyDirectory, project))
+	if err != nil {
+		return nil, err
+	}
+
+	updatedFilepaths := []string{}
+	for i, upgrade := range upgrades {
+		if upgrade.PullRequest != "" {
+			continue
+		}
+		upgrade.PullRequest = pullRequestURL
+		err = writeEntry(entryFilepaths[i], upgrade)
+		if err != nil {
+			return nil, err
+		}
+		updatedFilepaths = append(updatedFilepaths, entryFilepaths[i])
+	}
+
+	return updatedFilepaths, nil
+}
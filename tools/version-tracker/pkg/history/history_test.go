@@ -0,0 +1,130 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+)
+
+func writeTestEntry(t *testing.T, historyDirectory, filename string, entry types.UpgradeHistoryEntry) {
+	t.Helper()
+	contents, err := yaml.Marshal(entry)
+	if err != nil {
+		t.Fatalf("Error marshalling upgrade history entry: %v", err)
+	}
+	entryFilepath := filepath.Join(historyDirectory, entry.Project, filename)
+	if err := os.MkdirAll(filepath.Dir(entryFilepath), 0o755); err != nil {
+		t.Fatalf("Error creating upgrade history directory: %v", err)
+	}
+	if err := os.WriteFile(entryFilepath, contents, 0o644); err != nil {
+		t.Fatalf("Error writing upgrade history entry file: %v", err)
+	}
+}
+
+func TestLoadMissingDirectory(t *testing.T) {
+	upgradeHistory, err := Load(filepath.Join(t.TempDir(), "upgrade-history"))
+	if err != nil {
+		t.Fatalf("Error loading missing upgrade history directory: %v", err)
+	}
+	if len(upgradeHistory.Upgrades) != 0 {
+		t.Fatalf("Expected no upgrades in missing upgrade history directory, got: %+v", upgradeHistory.Upgrades)
+	}
+}
+
+func TestLoadSortsEntriesChronologically(t *testing.T) {
+	historyDirectory := t.TempDir()
+	writeTestEntry(t, historyDirectory, "20240301T000000Z.yaml", types.UpgradeHistoryEntry{Project: "vmware/govmomi", PreviousVersion: "v0.36.0", Version: "v0.37.0", Date: "2024-03-01T00:00:00Z"})
+	writeTestEntry(t, historyDirectory, "20240201T000000Z-1-29.yaml", types.UpgradeHistoryEntry{Project: "kubernetes/cloud-provider-aws", ReleaseBranch: "1-29", PreviousVersion: "v1.29.0", Version: "v1.29.1", Date: "2024-02-01T00:00:00Z"})
+	writeTestEntry(t, historyDirectory, "20240101T000000Z.yaml", types.UpgradeHistoryEntry{Project: "vmware/govmomi", PreviousVersion: "v0.35.0", Version: "v0.36.0", Date: "2024-01-01T00:00:00Z"})
+
+	upgradeHistory, err := Load(historyDirectory)
+	if err != nil {
+		t.Fatalf("Error loading upgrade history: %v", err)
+	}
+
+	want := []string{"v0.36.0", "v1.29.1", "v0.37.0"}
+	if len(upgradeHistory.Upgrades) != len(want) {
+		t.Fatalf("Unexpected number of upgrades. Want: %d, got: %d", len(want), len(upgradeHistory.Upgrades))
+	}
+	for i, upgrade := range upgradeHistory.Upgrades {
+		if upgrade.Version != want[i] {
+			t.Fatalf("Unexpected upgrade at index %d. Want version: %s, got: %+v", i, want[i], upgrade)
+		}
+	}
+}
+
+func TestRecordUpgradeWritesEntryFilePerUpgrade(t *testing.T) {
+	historyDirectory := t.TempDir()
+
+	mainEntryFilepath, err := RecordUpgrade(historyDirectory, "kubernetes/cloud-provider-aws", "1-29", "v1.29.0", "v1.29.1")
+	if err != nil {
+		t.Fatalf("Error recording upgrade: %v", err)
+	}
+	otherEntryFilepath, err := RecordUpgrade(historyDirectory, "kubernetes/cloud-provider-aws", "1-30", "v1.30.0", "v1.30.1")
+	if err != nil {
+		t.Fatalf("Error recording upgrade: %v", err)
+	}
+	if mainEntryFilepath == otherEntryFilepath {
+		t.Fatalf("Expected upgrades of different release branches to be recorded in different files, got: %s", mainEntryFilepath)
+	}
+	if filepath.Dir(mainEntryFilepath) != filepath.Join(historyDirectory, "kubernetes/cloud-provider-aws") {
+		t.Fatalf("Expected upgrade to be recorded in the project's upgrade history directory, got: %s", mainEntryFilepath)
+	}
+
+	upgradeDate, upgraded, err := LastUpgradeDate(historyDirectory, "kubernetes/cloud-provider-aws")
+	if err != nil {
+		t.Fatalf("Error getting last upgrade date: %v", err)
+	}
+	if !upgraded || time.Since(upgradeDate) > time.Minute {
+		t.Fatalf("Expected a recorded upgrade within the last minute, got: %v, %t", upgradeDate, upgraded)
+	}
+
+	_, upgraded, err = LastUpgradeDate(historyDirectory, "vmware/govmomi")
+	if err != nil {
+		t.Fatalf("Error getting last upgrade date: %v", err)
+	}
+	if upgraded {
+		t.Fatalf("Expected no recorded upgrade for project without upgrade history entries")
+	}
+}
+
+func TestRecordPullRequestUpdatesEntriesWithoutPullRequest(t *testing.T) {
+	historyDirectory := t.TempDir()
+	writeTestEntry(t, historyDirectory, "20240101T000000Z.yaml", types.UpgradeHistoryEntry{Project: "vmware/govmomi", PreviousVersion: "v0.35.0", Version: "v0.36.0", Date: "2024-01-01T00:00:00Z", PullRequest: "https://github.com/aws/eks-anywhere-build-tooling/pull/1"})
+	entryFilepath, err := RecordUpgrade(historyDirectory, "vmware/govmomi", "", "v0.36.0", "v0.37.0")
+	if err != nil {
+		t.Fatalf("Error recording upgrade: %v", err)
+	}
+	_, err = RecordUpgrade(historyDirectory, "kubernetes/cloud-provider-aws", "1-29", "v1.29.0", "v1.29.1")
+	if err != nil {
+		t.Fatalf("Error recording upgrade: %v", err)
+	}
+
+	updatedFilepaths, err := RecordPullRequest(historyDirectory, "vmware/govmomi", "https://github.com/aws/eks-anywhere-build-tooling/pull/2")
+	if err != nil {
+		t.Fatalf("Error recording pull request: %v", err)
+	}
+	if len(updatedFilepaths) != 1 || updatedFilepaths[0] != entryFilepath {
+		t.Fatalf("Unexpected updated entry files. Want: [%s], got: %v", entryFilepath, updatedFilepaths)
+	}
+
+	upgradeHistory, err := Load(historyDirectory)
+	if err != nil {
+		t.Fatalf("Error loading upgrade history: %v", err)
+	}
+	want := map[string]string{
+		"v0.36.0": "https://github.com/aws/eks-anywhere-build-tooling/pull/1",
+		"v0.37.0": "https://github.com/aws/eks-anywhere-build-tooling/pull/2",
+		"v1.29.1": "",
+	}
+	for _, upgrade := range upgradeHistory.Upgrades {
+		if upgrade.PullRequest != want[upgrade.Version] {
+			t.Fatalf("Unexpected pull request for upgrade to %s. Want: %q, got: %q", upgrade.Version, want[upgrade.Version], upgrade.PullRequest)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package osv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+)
+
+// GetVulnerabilities queries the OSV database for known vulnerabilities affecting the given Go modules.
+// It returns a mapping of module path and version to the list of vulnerability IDs.
+func GetVulnerabilities(modules []types.GoModule) (map[types.GoModule][]string, error) {
+	vulnerabilities := map[types.GoModule][]string{}
+
+	for start := 0; start < len(modules); start += constants.OSVQueryBatchSize {
+		end := start + constants.OSVQueryBatchSize
+		if end > len(modules) {
+			end = len(modules)
+		}
+		batch := modules[start:end]
+		logger.V(6).Info(fmt.Sprintf("Querying OSV database for vulnerabilities in %d modules", len(batch)))
+
+		var batchQuery types.OSVBatchQuery
+		for _, module := range batch {
+			batchQuery.Queries = append(batchQuery.Queries, types.OSVQuery{
+				Version: module.Version,
+				Package: types.OSVPackage{
+					Name:      module.Path,
+					Ecosystem: constants.OSVGoEcosystem,
+				},
+			})
+		}
+
+		requestBody, err := json.Marshal(batchQuery)
+		if err != nil {
+			return nil, fmt.Errorf("marshalling OSV batch query: %v", err)
+		}
+
+		resp, err := http.Post(constants.OSVQueryBatchURL, "application/json", bytes.NewReader(requestBody))
+		if err != nil {
+			return nil, fmt.Errorf("querying OSV batch API: %v", err)
+		}
+		responseBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading OSV batch API response: %v", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("OSV batch API returned status %d: %s", resp.StatusCode, string(responseBody))
+		}
+
+		var batchResponse types.OSVBatchResponse
+		err = json.Unmarshal(responseBody, &batchResponse)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshalling OSV batch API response: %v", err)
+		}
+
+		// Results are returned in the same order as the queries.
+		for i, result := range batchResponse.Results {
+			for _, vuln := range result.Vulns {
+				vulnerabilities[batch[i]] = append(vulnerabilities[batch[i]], vuln.ID)
+			}
+		}
+	}
+
+	return vulnerabilities, nil
+}
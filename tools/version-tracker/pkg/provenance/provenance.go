@@ -0,0 +1,92 @@
+package provenance
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/patch"
+)
+
+// Path returns the path of the provenance file for the given patch. Provenance files are kept in a directory
+// next to the patches directory rather than in it, since the project Makefiles apply every file in the
+// patches directory with git am.
+func Path(patchFilepath string) string {
+	patchesDirectory := filepath.Dir(patchFilepath)
+	provenanceFilename := strings.TrimSuffix(filepath.Base(patchFilepath), filepath.Ext(patchFilepath)) + ".yaml"
+
+	return filepath.Join(filepath.Dir(patchesDirectory), constants.PatchProvenanceDirectory, provenanceFilename)
+}
+
+// Load reads and unmarshals the provenance file for the given patch. If the patch has no provenance file yet,
+// the provenance is initialized with the author and subject of the patch, which stand in for the reason until
+// a maintainer fills in the details.
+func Load(patchFilepath string) (*types.PatchProvenance, error) {
+	var patchProvenance types.PatchProvenance
+	contents, err := os.ReadFile(Path(patchFilepath))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("reading patch provenance file: %v", err)
+		}
+
+		patchContents, err := os.ReadFile(patchFilepath)
+		if err != nil {
+			return nil, fmt.Errorf("reading patch file %s: %v", patchFilepath, err)
+		}
+		patchProvenance.Author, patchProvenance.Reason = patch.Header(string(patchContents))
+		patchProvenance.Patch = filepath.Base(patchFilepath)
+		return &patchProvenance, nil
+	}
+
+	err = yaml.Unmarshal(contents, &patchProvenance)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling patch provenance file: %v", err)
+	}
+
+	return &patchProvenance, nil
+}
+
+// Write marshals the provenance and writes it to the provenance file for the given patch.
+func Write(patchFilepath string, patchProvenance *types.PatchProvenance) error {
+	contents, err := yaml.Marshal(patchProvenance)
+	if err != nil {
+		return fmt.Errorf("marshalling patch provenance: %v", err)
+	}
+
+	provenanceFilepath := Path(patchFilepath)
+	err = os.MkdirAll(filepath.Dir(provenanceFilepath), 0o755)
+	if err != nil {
+		return fmt.Errorf("creating patch provenance directory: %v", err)
+	}
+	err = os.WriteFile(provenanceFilepath, contents, 0o644)
+	if err != nil {
+		return fmt.Errorf("writing patch provenance file: %v", err)
+	}
+
+	return nil
+}
+
+// RecordVerification records in the provenance file of the given patch that the patch applies to the given Git
+// tag, creating the file if needed. The verification date only changes along with the Git tag, so that
+// verifying patches repeatedly does not modify the provenance files.
+func RecordVerification(patchFilepath, gitTag string) error {
+	patchProvenance, err := Load(patchFilepath)
+	if err != nil {
+		return err
+	}
+	if patchProvenance.LastVerifiedGitTag == gitTag {
+		return nil
+	}
+
+	patchProvenance.Patch = filepath.Base(patchFilepath)
+	patchProvenance.LastVerifiedGitTag = gitTag
+	patchProvenance.LastVerifiedDate = time.Now().UTC().Format(time.RFC3339)
+
+	return Write(patchFilepath, patchProvenance)
+}
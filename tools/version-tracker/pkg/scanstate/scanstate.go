@@ -0,0 +1,57 @@
+package scanstate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+)
+
+// Load reads and unmarshals the scan state file. A missing file is treated as a scan that has not started yet.
+func Load(scanStateFilepath string) (*types.ScanState, error) {
+	scanState := types.ScanState{LatestRevisions: map[string]string{}}
+	contents, err := os.ReadFile(scanStateFilepath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &scanState, nil
+		}
+		return nil, fmt.Errorf("reading scan state file: %v", err)
+	}
+
+	err = yaml.Unmarshal(contents, &scanState)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling scan state file: %v", err)
+	}
+	if scanState.LatestRevisions == nil {
+		scanState.LatestRevisions = map[string]string{}
+	}
+
+	return &scanState, nil
+}
+
+// Write marshals the scan state and writes it to the scan state file.
+func Write(scanStateFilepath string, scanState *types.ScanState) error {
+	contents, err := yaml.Marshal(scanState)
+	if err != nil {
+		return fmt.Errorf("marshalling scan state: %v", err)
+	}
+
+	err = os.WriteFile(scanStateFilepath, contents, 0o644)
+	if err != nil {
+		return fmt.Errorf("writing scan state file: %v", err)
+	}
+
+	return nil
+}
+
+// Remove deletes the scan state file once the scan has completed.
+func Remove(scanStateFilepath string) error {
+	err := os.Remove(scanStateFilepath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing scan state file: %v", err)
+	}
+
+	return nil
+}
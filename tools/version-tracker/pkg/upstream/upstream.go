@@ -0,0 +1,243 @@
+package upstream
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/disk"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/makefile"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/progress"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/workspace"
+)
+
+// MirrorPath returns the path of the bare mirror of the given upstream repository in the workspace directory.
+// Mirrors are laid out by host and repository path, so that each upstream repository has a single mirror.
+func MirrorPath(cloneURL string) (string, error) {
+	workspaceDir, err := workspace.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	// SCP-like URLs such as git@github.com:org/repo.git have no scheme.
+	repoLocation := cloneURL
+	if parsedURL, err := url.Parse(cloneURL); err == nil && parsedURL.Host != "" {
+		repoLocation = filepath.Join(parsedURL.Host, parsedURL.Path)
+	} else {
+		repoLocation = strings.ReplaceAll(repoLocation[strings.Index(repoLocation, "@")+1:], ":", "/")
+	}
+	repoLocation = strings.TrimSuffix(filepath.Clean(repoLocation), ".git")
+
+	return filepath.Join(workspaceDir, constants.UpstreamMirrorsDirectory, fmt.Sprintf("%s.git", repoLocation)), nil
+}
+
+// SyncMirror creates the bare mirror of the given upstream repository if it does not exist, or fetches the
+// latest branches and tags into it otherwise, and returns its path. In the shallow clone mode, only the given
+// revisions are fetched, without their history, and revisions already in the mirror are not fetched again.
+func SyncMirror(cloneURL string, revisions ...string) (string, error) {
+	mirrorPath, err := MirrorPath(cloneURL)
+	if err != nil {
+		return "", fmt.Errorf("getting upstream mirror path: %v", err)
+	}
+	if err := disk.CheckFreeSpace(mirrorPath); err != nil {
+		return "", err
+	}
+	_, err = os.Stat(mirrorPath)
+	mirrorExists := err == nil
+
+	if workspace.CloneMode() == constants.ShallowCloneMode {
+		// The revisions are fetched individually, so the mirror starts out empty.
+		if !mirrorExists {
+			logger.Info("Creating shallow upstream repository mirror", "URL", cloneURL)
+			initCmd := exec.Command("git", "init", "--quiet", "--bare", mirrorPath)
+			if _, err := command.ExecCommand(initCmd); err != nil {
+				return "", fmt.Errorf("initializing upstream repository mirror: %v", err)
+			}
+			remoteAddCmd := exec.Command("git", "-C", mirrorPath, "remote", "add", "origin", cloneURL)
+			if _, err := command.ExecCommand(remoteAddCmd); err != nil {
+				return "", fmt.Errorf("adding upstream repository remote: %v", err)
+			}
+		}
+		for _, revision := range revisions {
+			if err := fetchShallowRevision(mirrorPath, revision); err != nil {
+				return "", err
+			}
+		}
+		return mirrorPath, nil
+	}
+
+	if !mirrorExists {
+		logger.Info("Cloning upstream repository mirror", "URL", cloneURL)
+		cloneArgs := []string{"clone", "--quiet", "--bare"}
+		if workspace.CloneMode() == constants.PartialCloneMode {
+			cloneArgs = append(cloneArgs, fmt.Sprintf("--filter=%s", constants.PartialCloneFilter))
+		}
+		cloneCmd := exec.Command("git", append(cloneArgs, cloneURL, mirrorPath)...)
+		stopProgress := progress.Start("cloning upstream repository mirror", "URL", cloneURL)
+		_, err = command.ExecCommand(cloneCmd)
+		stopProgress()
+		if err != nil {
+			return "", fmt.Errorf("cloning upstream repository mirror: %v", err)
+		}
+		return mirrorPath, nil
+	}
+
+	logger.Info("Fetching upstream repository mirror", "URL", cloneURL)
+	fetchArgs := []string{"-C", mirrorPath, "fetch", "--quiet", "--prune", "--tags"}
+	// A mirror created in the shallow clone mode is completed, so that all revisions are available.
+	shallow, err := isShallow(mirrorPath)
+	if err != nil {
+		return "", err
+	}
+	if shallow {
+		fetchArgs = append(fetchArgs, "--unshallow")
+	}
+	fetchCmd := exec.Command("git", append(fetchArgs, "origin", "+refs/heads/*:refs/heads/*")...)
+	stopProgress := progress.Start("fetching upstream repository mirror", "URL", cloneURL)
+	_, err = command.ExecCommand(fetchCmd)
+	stopProgress()
+	if err != nil {
+		return "", fmt.Errorf("fetching upstream repository mirror: %v", err)
+	}
+
+	return mirrorPath, nil
+}
+
+// fetchShallowRevision fetches the given Git tag or commit into the mirror with a depth of 1, unless it is
+// already in the mirror.
+func fetchShallowRevision(mirrorPath, revision string) error {
+	revParseCmd := exec.Command("git", "-C", mirrorPath, "rev-parse", "--verify", "--quiet", fmt.Sprintf("%s^{commit}", revision))
+	if _, err := command.ExecCommand(revParseCmd); err == nil {
+		return nil
+	}
+
+	refspec := revision
+	if !regexp.MustCompile(constants.CommitHashRegex).MatchString(revision) {
+		refspec = fmt.Sprintf("+refs/tags/%[1]s:refs/tags/%[1]s", revision)
+	}
+	logger.Info("Fetching upstream revision", "Revision", revision)
+	fetchCmd := exec.Command("git", "-C", mirrorPath, "fetch", "--quiet", "--depth", "1", "origin", refspec)
+	stopProgress := progress.Start("fetching upstream revision", "Revision", revision)
+	_, err := command.ExecCommand(fetchCmd)
+	stopProgress()
+	if err != nil {
+		return fmt.Errorf("fetching upstream revision %s: %v", revision, err)
+	}
+
+	return nil
+}
+
+// Deepen fetches the complete history of the given repository or worktree if it is a shallow clone, for
+// operations that need more than the checked-out revision, such as resolving patch conflicts. It does nothing
+// for complete clones.
+func Deepen(repoPath string) error {
+	shallow, err := isShallow(repoPath)
+	if err != nil {
+		return err
+	}
+	if !shallow {
+		return nil
+	}
+
+	logger.Info("Fetching upstream repository history", "Path", repoPath)
+	fetchCmd := exec.Command("git", "-C", repoPath, "fetch", "--quiet", "--unshallow", "--tags", "origin")
+	stopProgress := progress.Start("fetching upstream repository history", "Path", repoPath)
+	_, err = command.ExecCommand(fetchCmd)
+	stopProgress()
+	if err != nil {
+		return fmt.Errorf("fetching upstream repository history: %v", err)
+	}
+
+	return nil
+}
+
+// isShallow returns whether the given repository is a shallow clone.
+func isShallow(repoPath string) (bool, error) {
+	isShallowCmd := exec.Command("git", "-C", repoPath, "rev-parse", "--is-shallow-repository")
+	isShallowOutput, err := command.ExecCommand(isShallowCmd)
+	if err != nil {
+		return false, fmt.Errorf("checking if repository is shallow: %v", err)
+	}
+
+	return isShallowOutput == "true", nil
+}
+
+// AddWorktree creates a worktree of the mirror at the given path, detached at the given revision. Worktrees
+// whose directories have been deleted, for example by the clean-repo Make target, are pruned first so that
+// their paths can be reused.
+func AddWorktree(mirrorPath, worktreePath, revision string) error {
+	if err := PruneWorktrees(mirrorPath); err != nil {
+		return err
+	}
+
+	worktreeAddCmd := exec.Command("git", "-C", mirrorPath, "worktree", "add", "--quiet", "--detach", worktreePath, revision)
+	if _, err := command.ExecCommand(worktreeAddCmd); err != nil {
+		return fmt.Errorf("adding worktree at %s: %v", worktreePath, err)
+	}
+
+	return nil
+}
+
+// PruneWorktrees removes the mirror's administrative files for worktrees whose directories no longer exist.
+func PruneWorktrees(mirrorPath string) error {
+	worktreePruneCmd := exec.Command("git", "-C", mirrorPath, "worktree", "prune")
+	if _, err := command.ExecCommand(worktreePruneCmd); err != nil {
+		return fmt.Errorf("pruning worktrees: %v", err)
+	}
+
+	return nil
+}
+
+// PrepareCheckout creates the project's upstream repository directory as a worktree of the upstream mirror at
+// the tracked Git tag, if worktree checkouts are enabled and the directory does not exist yet. The project's
+// checkout-repo Make target then reuses the worktree instead of cloning the upstream repository. Projects with
+// sparse checkouts or without an upstream repository are left to the Make target.
+func PrepareCheckout(projectRootFilepath, releaseBranch string) error {
+	if !workspace.WorktreeCheckouts() {
+		return nil
+	}
+
+	projectRepo, err := makefile.GetVariableValue(projectRootFilepath, "REPO", releaseBranch)
+	if err != nil {
+		return fmt.Errorf("getting project repository directory: %v", err)
+	}
+	projectRepoPath := filepath.Join(projectRootFilepath, projectRepo)
+	if _, err := os.Stat(projectRepoPath); err == nil {
+		return nil
+	}
+
+	for _, variable := range []string{"REPO_NO_CLONE", "REPO_SPARSE_CHECKOUT"} {
+		value, err := makefile.GetVariableValue(projectRootFilepath, variable, releaseBranch)
+		if err != nil {
+			return fmt.Errorf("getting project %s: %v", variable, err)
+		}
+		if value != "" && value != "false" {
+			logger.V(6).Info(fmt.Sprintf("Project sets %s, leaving the checkout to the Make target", variable))
+			return nil
+		}
+	}
+
+	cloneURL, err := makefile.GetVariableValue(projectRootFilepath, "CLONE_URL", releaseBranch)
+	if err != nil {
+		return fmt.Errorf("getting project clone URL: %v", err)
+	}
+	gitTag, err := makefile.GetVariableValue(projectRootFilepath, "GIT_TAG", releaseBranch)
+	if err != nil {
+		return fmt.Errorf("getting project Git tag: %v", err)
+	}
+
+	mirrorPath, err := SyncMirror(cloneURL, gitTag)
+	if err != nil {
+		return err
+	}
+
+	logger.V(6).Info(fmt.Sprintf("Creating worktree of upstream mirror at %s", projectRepoPath))
+	return AddWorktree(mirrorPath, projectRepoPath, gitTag)
+}
@@ -0,0 +1,109 @@
+package cleanup
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+)
+
+// action represents an undo action that restores the workspace if the command is interrupted.
+type action struct {
+	id          int
+	description string
+	undo        func() error
+}
+
+var (
+	actions      []action
+	nextActionID int
+	interactive  bool
+	mutex        sync.Mutex
+)
+
+// Register registers an undo action to run if the command is interrupted by SIGINT or SIGTERM before the
+// operation it guards completes, such as reverting the working tree or removing temporary files. It returns
+// a function that deregisters the action, to be called once the operation has completed or been cleaned up
+// by other means.
+func Register(description string, undo func() error) func() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	id := nextActionID
+	nextActionID++
+	actions = append(actions, action{id: id, description: description, undo: undo})
+
+	return func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+		for i, registeredAction := range actions {
+			if registeredAction.id == id {
+				actions = append(actions[:i], actions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Run runs the registered undo actions in the reverse order of registration and deregisters them. Failing
+// actions are logged and do not prevent the remaining actions from running.
+func Run() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for i := len(actions) - 1; i >= 0; i-- {
+		logger.Info(actions[i].description)
+		if err := actions[i].undo(); err != nil {
+			logger.Warn("Cleanup action failed", "Action", actions[i].description, "Error", err)
+		}
+	}
+	actions = nil
+}
+
+// HandleSignals runs the registered undo actions when the process receives SIGINT or SIGTERM, then exits
+// with the conventional 128+signal exit code.
+func HandleSignals() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		for receivedSignal := range signals {
+			// Interrupts typed into an interactive child process are also delivered to this process, so
+			// they are left for the child process to handle.
+			mutex.Lock()
+			ignore := interactive && receivedSignal == syscall.SIGINT
+			mutex.Unlock()
+			if ignore {
+				continue
+			}
+
+			logger.Warn(fmt.Sprintf("Received %s, cleaning up before exiting", receivedSignal))
+			Run()
+
+			exitCode := 1
+			if sysSignal, ok := receivedSignal.(syscall.Signal); ok {
+				exitCode = 128 + int(sysSignal)
+			}
+			os.Exit(exitCode)
+		}
+	}()
+}
+
+// RunInteractive runs the given function, which is expected to attach a child process such as a shell to the
+// terminal, ignoring SIGINT in the meantime so that interrupting the child process does not end the command.
+func RunInteractive(fn func() error) error {
+	mutex.Lock()
+	interactive = true
+	mutex.Unlock()
+
+	defer func() {
+		mutex.Lock()
+		interactive = false
+		mutex.Unlock()
+	}()
+
+	return fn()
+}
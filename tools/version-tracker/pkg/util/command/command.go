@@ -1,3 +1,5 @@
+//go:generate ../../../hack/tools/bin/mockgen -destination ./mocks/command_mock.go -package mocks . CommandRunner
+
 package command
 
 import (
@@ -8,14 +10,57 @@ import (
 	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
 )
 
+// CommandRunner executes external commands such as Make, Git and Bash on behalf of the version-tracker
+// commands, so that the commands can be unit tested with a mocked runner.
+type CommandRunner interface {
+	// Run executes the command and returns its combined standard output and standard error.
+	Run(cmd *exec.Cmd) (string, error)
+	// Output executes the command and returns its standard output only.
+	Output(cmd *exec.Cmd) (string, error)
+}
+
+type execRunner struct{}
+
+func (execRunner) Run(cmd *exec.Cmd) (string, error) {
+	output, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(output)), err
+}
+
+func (execRunner) Output(cmd *exec.Cmd) (string, error) {
+	output, err := cmd.Output()
+	return strings.TrimSpace(string(output)), err
+}
+
+var runner CommandRunner = execRunner{}
+
+// SetRunner replaces the runner used to execute commands and returns a function that restores the
+// previous runner. It is intended to be used by tests to mock command execution.
+func SetRunner(commandRunner CommandRunner) func() {
+	previousRunner := runner
+	runner = commandRunner
+	return func() {
+		runner = previousRunner
+	}
+}
+
 // ExecCommand executes the given command, writing to standard output.
 func ExecCommand(cmd *exec.Cmd) (string, error) {
 	logger.V(6).Info(fmt.Sprintf("Executing command: %s", cmd.String()))
-	commandOutput, err := cmd.CombinedOutput()
-	commandOutputStr := strings.TrimSpace(string(commandOutput))
+	commandOutputStr, err := runner.Run(cmd)
 	logger.V(6).Info(commandOutputStr)
 	if err != nil {
 		return commandOutputStr, fmt.Errorf("executing command %s: %v", cmd.String(), err)
 	}
 	return commandOutputStr, nil
 }
+
+// ExecCommandOutput executes the given command and returns its standard output only, for commands that
+// log warnings to standard error that must not be mixed with their output.
+func ExecCommandOutput(cmd *exec.Cmd) (string, error) {
+	logger.V(6).Info(fmt.Sprintf("Executing command: %s", cmd.String()))
+	commandOutputStr, err := runner.Output(cmd)
+	if err != nil {
+		return "", fmt.Errorf("executing command %s: %v", cmd.String(), err)
+	}
+	return commandOutputStr, nil
+}
@@ -0,0 +1,61 @@
+package disk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+const bytesPerGiB = 1 << 30
+
+// minimumFreeSpace is the free disk space, in bytes, required before cloning upstream repositories or building
+// projects. A value of 0 disables the check.
+var minimumFreeSpace uint64
+
+// Init sets the free disk space, in GiB, that CheckFreeSpace requires. A value of 0 disables the check.
+func Init(minimumFreeSpaceGiB int) error {
+	if minimumFreeSpaceGiB < 0 {
+		return fmt.Errorf("invalid minimum free disk space %d GiB, must not be negative", minimumFreeSpaceGiB)
+	}
+	minimumFreeSpace = uint64(minimumFreeSpaceGiB) * bytesPerGiB
+
+	return nil
+}
+
+// CheckFreeSpace returns an error if the volume holding the given path has less free disk space than the
+// configured minimum, so that commands fail before cloning or building rather than partway through with Git or
+// compiler errors that do not mention the disk. The path does not need to exist yet, in which case the volume
+// of its closest existing parent directory is checked.
+func CheckFreeSpace(path string) error {
+	if minimumFreeSpace == 0 {
+		return nil
+	}
+
+	existingPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("getting absolute path of %s: %v", path, err)
+	}
+	for {
+		if _, err := os.Stat(existingPath); err == nil {
+			break
+		}
+		parentPath := filepath.Dir(existingPath)
+		if parentPath == existingPath {
+			break
+		}
+		existingPath = parentPath
+	}
+
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(existingPath, &stat); err != nil {
+		return fmt.Errorf("getting free disk space of volume holding %s: %v", existingPath, err)
+	}
+	// Bavail excludes the blocks reserved for the root user, which the commands cannot rely on.
+	freeSpace := stat.Bavail * uint64(stat.Bsize)
+	if freeSpace < minimumFreeSpace {
+		return fmt.Errorf("only %.1f GiB of disk space is free on the volume holding %s, but at least %d GiB is required; free up disk space or lower the minimum with the --min-free-disk-space flag", float64(freeSpace)/bytesPerGiB, existingPath, minimumFreeSpace/bytesPerGiB)
+	}
+
+	return nil
+}
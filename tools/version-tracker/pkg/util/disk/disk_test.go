@@ -0,0 +1,39 @@
+package disk
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckFreeSpace(t *testing.T) {
+	testcases := []struct {
+		name                string
+		minimumFreeSpaceGiB int
+		wantErr             bool
+	}{
+		{
+			name:                "Check disabled",
+			minimumFreeSpaceGiB: 0,
+			wantErr:             false,
+		},
+		{
+			name:                "Not enough free space",
+			minimumFreeSpaceGiB: 1 << 20,
+			wantErr:             true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := Init(tc.minimumFreeSpaceGiB); err != nil {
+				t.Fatalf("Unexpected error initializing disk space check. Got: %v", err)
+			}
+			t.Cleanup(func() { minimumFreeSpace = 0 })
+
+			// The path does not exist, so the volume of its closest existing parent directory is checked.
+			err := CheckFreeSpace(filepath.Join(t.TempDir(), "upstream-mirrors", "repo.git"))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error. Got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package environment
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+)
+
+var (
+	makeCommand         = "make"
+	detectOnce          sync.Once
+	gnuMakeVersionRegex = regexp.MustCompile(`^GNU Make (\d+)\.`)
+	bashVersionRegex    = regexp.MustCompile(`^(\d+)\.(\d+)`)
+)
+
+// Init adapts the environment of the Make and Git commands to the host. The project Makefiles and their recipes
+// expect the GNU variants of tools such as sed, find, date and tar, and Bash 4.2 or newer. On macOS, where the
+// default tools are the BSD variants and Bash 3.2, the GNU tools and Bash installed with Homebrew are put first
+// on the PATH of the commands, so developers can run the commands locally without changing their shell setup.
+func Init() error {
+	if runtime.GOOS != "darwin" {
+		return nil
+	}
+
+	pathEntries := filepath.SplitList(os.Getenv("PATH"))
+	prependedEntries := []string{}
+	for _, homebrewPrefix := range constants.HomebrewPrefixes {
+		for _, formula := range constants.HomebrewGNUToolFormulae {
+			gnubinDir := filepath.Join(homebrewPrefix, "opt", formula, "libexec", "gnubin")
+			if _, err := os.Stat(gnubinDir); err == nil {
+				prependedEntries = append(prependedEntries, gnubinDir)
+			}
+		}
+		// Homebrew's Bash is installed in the prefix's bin directory, which is often after /bin on the PATH.
+		if _, err := os.Stat(filepath.Join(homebrewPrefix, "bin", "bash")); err == nil {
+			prependedEntries = append(prependedEntries, filepath.Join(homebrewPrefix, "bin"))
+		}
+	}
+	if len(prependedEntries) == 0 {
+		return nil
+	}
+
+	logger.V(6).Info("Prepending Homebrew GNU tool directories to PATH", "Directories", prependedEntries)
+	if err := os.Setenv("PATH", strings.Join(append(prependedEntries, pathEntries...), string(os.PathListSeparator))); err != nil {
+		return fmt.Errorf("setting PATH environment variable: %v", err)
+	}
+
+	return nil
+}
+
+// Make returns the command that runs GNU Make 4 or newer, which the project Makefiles require. This is make on
+// Linux and gmake on macOS hosts with GNU Make installed with Homebrew but not on the PATH under its unprefixed
+// name. The host tools are checked the first time it is called, and missing tools are reported as warnings
+// with installation instructions, since the Make commands may still succeed without them.
+func Make() string {
+	detectOnce.Do(detectTools)
+
+	return makeCommand
+}
+
+// detectTools selects the GNU Make command and warns about host tools that do not meet the requirements of
+// the project Makefiles.
+func detectTools() {
+	gnuMakeFound := false
+	for _, candidate := range constants.GNUMakeCommands {
+		majorVersion, err := gnuMakeMajorVersion(candidate)
+		if err != nil {
+			logger.V(6).Info(fmt.Sprintf("Skipping %s: %v", candidate, err))
+			continue
+		}
+		if majorVersion >= constants.MinimumGNUMakeMajorVersion {
+			makeCommand = candidate
+			gnuMakeFound = true
+			break
+		}
+	}
+	if !gnuMakeFound {
+		logger.Warn(fmt.Sprintf("GNU Make %d or newer not found, Make commands may fail", constants.MinimumGNUMakeMajorVersion), "Install", installHint("make"))
+	}
+
+	majorVersion, minorVersion, err := bashVersion()
+	if err != nil {
+		logger.Warn("Unable to determine Bash version, Make commands may fail", "Error", err)
+	} else if majorVersion < constants.MinimumBashMajorVersion || majorVersion == constants.MinimumBashMajorVersion && minorVersion < constants.MinimumBashMinorVersion {
+		logger.Warn(fmt.Sprintf("Bash %d.%d or newer is required by the Make recipes, found %d.%d", constants.MinimumBashMajorVersion, constants.MinimumBashMinorVersion, majorVersion, minorVersion), "Install", installHint("bash"))
+	}
+
+	// BSD sed, the macOS default, does not support --version, unlike GNU sed.
+	if err := exec.Command("sed", "--version").Run(); err != nil {
+		logger.Warn("GNU sed not found, Make recipes editing files may fail", "Install", installHint("coreutils findutils gnu-sed gnu-tar"))
+	}
+}
+
+// gnuMakeMajorVersion returns the major version of the given Make command, or an error if it is not GNU Make.
+func gnuMakeMajorVersion(makeCmd string) (int, error) {
+	versionOutput, err := exec.Command(makeCmd, "--version").Output()
+	if err != nil {
+		return 0, fmt.Errorf("getting version: %v", err)
+	}
+	match := gnuMakeVersionRegex.FindStringSubmatch(string(versionOutput))
+	if match == nil {
+		return 0, fmt.Errorf("not GNU Make")
+	}
+
+	return strconv.Atoi(match[1])
+}
+
+// bashVersion returns the major and minor version of the Bash on the PATH, which runs the Make commands and is
+// the shell of the Make recipes.
+func bashVersion() (int, int, error) {
+	versionOutput, err := exec.Command("bash", "-c", `echo "${BASH_VERSION}"`).Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("getting version: %v", err)
+	}
+	match := bashVersionRegex.FindStringSubmatch(strings.TrimSpace(string(versionOutput)))
+	if match == nil {
+		return 0, 0, fmt.Errorf("unexpected version %q", strings.TrimSpace(string(versionOutput)))
+	}
+	majorVersion, _ := strconv.Atoi(match[1])
+	minorVersion, _ := strconv.Atoi(match[2])
+
+	return majorVersion, minorVersion, nil
+}
+
+// installHint returns instructions for installing the given Homebrew formulae on macOS, or a generic hint on
+// other hosts, whose package names vary between distributions.
+func installHint(formulae string) string {
+	if runtime.GOOS == "darwin" {
+		return fmt.Sprintf("brew install %s", formulae)
+	}
+
+	return "install it with the host's package manager"
+}
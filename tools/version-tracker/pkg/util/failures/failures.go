@@ -0,0 +1,79 @@
+package failures
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rodaine/table"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+)
+
+// Report collects the failures of the items processed by a command that operates on several projects or
+// branches, so that the command can carry on with the remaining items and summarize all failures at the end
+// instead of hiding the status of the remaining items behind the first failure.
+type Report struct {
+	itemKind string
+	failures []failure
+}
+
+type failure struct {
+	item string
+	step string
+	err  error
+}
+
+// NewReport returns an empty report for items of the given kind, such as "Project" or "Branch", which is used
+// in log messages and as the heading of the summary table.
+func NewReport(itemKind string) *Report {
+	return &Report{itemKind: itemKind}
+}
+
+// Add records and logs that the given step, described as a verb phrase such as "get latest revision", failed
+// for the given item.
+func (r *Report) Add(item, step string, err error) {
+	logger.Warn(fmt.Sprintf("Unable to %s", step), r.itemKind, item, "Error", err)
+	r.failures = append(r.failures, failure{item: item, step: step, err: err})
+}
+
+// Len returns the number of recorded failures.
+func (r *Report) Len() int {
+	return len(r.failures)
+}
+
+// Print tabulates the recorded failures to standard output, in the order they occurred. It prints nothing if
+// there are no failures.
+func (r *Report) Print() {
+	if len(r.failures) == 0 {
+		return
+	}
+
+	tbl := table.New(r.itemKind, "Step", "Error").WithHeaderFormatter(func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	})
+	for _, failure := range r.failures {
+		tbl.AddRow(failure.item, failure.step, failure.err)
+	}
+
+	fmt.Println()
+	tbl.Print()
+}
+
+// Err returns an error listing the items that failed the given operation, or nil if there are no failures.
+// Items that failed several steps are listed once.
+func (r *Report) Err(operation string) error {
+	if len(r.failures) == 0 {
+		return nil
+	}
+
+	items := []string{}
+	seen := map[string]bool{}
+	for _, failure := range r.failures {
+		if !seen[failure.item] {
+			seen[failure.item] = true
+			items = append(items, failure.item)
+		}
+	}
+
+	return fmt.Errorf("failed to %s: %s", operation, strings.Join(items, ", "))
+}
@@ -0,0 +1,48 @@
+package failures
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestReportErr(t *testing.T) {
+	testcases := []struct {
+		name     string
+		failures [][2]string
+		wantErr  string
+	}{
+		{
+			name:    "No failures",
+			wantErr: "",
+		},
+		{
+			name: "Failures of several projects",
+			failures: [][2]string{
+				{"kubernetes-sigs/kind", "get latest revision"},
+				{"fluxcd/flux2", "upgrade project"},
+			},
+			wantErr: "failed to upgrade projects: kubernetes-sigs/kind, fluxcd/flux2",
+		},
+		{
+			name: "Several failures of the same project",
+			failures: [][2]string{
+				{"fluxcd/flux2", "update project version files"},
+				{"fluxcd/flux2", "upgrade low-risk projects in a single PR"},
+			},
+			wantErr: "failed to upgrade projects: fluxcd/flux2",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := NewReport("Project")
+			for _, failure := range tc.failures {
+				report.Add(failure[0], failure[1], fmt.Errorf("unexpected error"))
+			}
+
+			gotErr := report.Err("upgrade projects")
+			if gotErr == nil && tc.wantErr != "" || gotErr != nil && gotErr.Error() != tc.wantErr {
+				t.Fatalf("Unexpected error. Expected: %q, Got: %v", tc.wantErr, gotErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package gomod
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/cleanup"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command"
+)
+
+var leadingDigitsRegex = regexp.MustCompile(`^[0-9]+`)
+
+// ParseModFile parses the given go.mod contents using the `go mod edit -json` command.
+func ParseModFile(contents []byte) (*types.GoModFile, error) {
+	tempDir, err := os.MkdirTemp("", "go-mod-")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary directory for go.mod file: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	defer cleanup.Register("Removing temporary go.mod directory", func() error {
+		return os.RemoveAll(tempDir)
+	})()
+
+	goModFilepath := filepath.Join(tempDir, "go.mod")
+	err = os.WriteFile(goModFilepath, contents, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("writing go.mod file to temporary directory: %v", err)
+	}
+
+	goModEditCmd := exec.Command("go", "mod", "edit", "-json", goModFilepath)
+	goModEditOutput, err := command.ExecCommand(goModEditCmd)
+	if err != nil {
+		return nil, fmt.Errorf("running go mod edit command: %v", err)
+	}
+
+	var goModFile types.GoModFile
+	err = json.Unmarshal([]byte(goModEditOutput), &goModFile)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling go mod edit output: %v", err)
+	}
+
+	return &goModFile, nil
+}
+
+// EffectiveRequirements returns the module requirements of the go.mod file after applying any
+// replace directives, keyed by module path.
+func EffectiveRequirements(goModFile *types.GoModFile) map[string]string {
+	requirements := map[string]string{}
+	for _, require := range goModFile.Require {
+		requirements[require.Path] = require.Version
+	}
+
+	for _, replace := range goModFile.Replace {
+		if _, ok := requirements[replace.Old.Path]; !ok {
+			continue
+		}
+		if replace.Old.Version != "" && replace.Old.Version != requirements[replace.Old.Path] {
+			continue
+		}
+		// Replacements pointing to local directories don't have a version, so we drop them from the graph.
+		if replace.New.Version == "" {
+			delete(requirements, replace.Old.Path)
+			continue
+		}
+		delete(requirements, replace.Old.Path)
+		requirements[replace.New.Path] = replace.New.Version
+	}
+
+	return requirements
+}
+
+// ParseGoMinorVersion returns the major and minor components of a Go version, such as the version in a go or
+// toolchain directive, ignoring any `go` prefix and patch component.
+func ParseGoMinorVersion(goVersion string) (int, int, error) {
+	versionComponents := strings.Split(strings.TrimPrefix(goVersion, "go"), ".")
+	if len(versionComponents) < 2 {
+		return 0, 0, fmt.Errorf("invalid Go version %s", goVersion)
+	}
+
+	majorVersion, err := strconv.Atoi(versionComponents[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing Go major version from %s: %v", goVersion, err)
+	}
+	// Go 1.21 onwards, the minor component can be followed by a prerelease suffix, such as 1.21rc2.
+	minorVersion, err := strconv.Atoi(leadingDigitsRegex.FindString(versionComponents[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing Go minor version from %s: %v", goVersion, err)
+	}
+
+	return majorVersion, minorVersion, nil
+}
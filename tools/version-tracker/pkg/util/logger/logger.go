@@ -9,44 +9,84 @@ import (
 	"github.com/go-logr/zapr"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
 )
 
 var (
 	pkgLogger logr.Logger = logr.Discard()
+	zapLogger *zap.Logger = zap.NewNop()
 	Verbosity int
 )
 
-// Init initializes the package logger. Repeat calls will overwrite the package logger which may
-// result in unexpected behavior.
-func Init(verbosityLevel int) error {
+// Init initializes the package logger with the given verbosity level and output format, which can be
+// either text or JSON. In quiet mode, only warnings and errors are logged. Repeat calls will overwrite
+// the package logger which may result in unexpected behavior.
+func Init(verbosityLevel int, quiet bool, logFormat string) error {
 	Verbosity = verbosityLevel
-	encoderCfg := zap.NewDevelopmentEncoderConfig()
-	encoderCfg.EncodeLevel = nil
-	encoderCfg.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {}
 
-	// Level 6 and above are used for debugging and we want a different log structure for debug
-	// logs.
-	if verbosityLevel >= 6 {
+	var encoder zapcore.Encoder
+	var opts []zapr.Option
+	switch logFormat {
+	case constants.TextLogFormat:
+		encoderCfg := zap.NewDevelopmentEncoderConfig()
+		encoderCfg.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {}
 		encoderCfg.EncodeLevel = func(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
-			// Because we use negated levels it is necessary to negate the level again so the
-			// output appears in a V0 format.
-			//
-			// See logrAtomicLevel().
-			enc.AppendString(fmt.Sprintf("V%d", -int(l)))
+			// Warnings and errors are always prefixed with their level so that they stand out from
+			// informational messages.
+			if l >= zapcore.WarnLevel {
+				enc.AppendString(l.CapitalString())
+			}
+		}
+
+		// Level 6 and above are used for debugging and we want a different log structure for debug
+		// logs.
+		if verbosityLevel >= 6 {
+			encoderCfg.EncodeLevel = func(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+				if l >= zapcore.WarnLevel {
+					enc.AppendString(l.CapitalString())
+					return
+				}
+				// Because we use negated levels it is necessary to negate the level again so the
+				// output appears in a V0 format.
+				//
+				// See logrAtomicLevel().
+				enc.AppendString(fmt.Sprintf("V%d", -int(l)))
+			}
+			encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
 		}
+		encoder = zapcore.NewConsoleEncoder(encoderCfg)
+	case constants.JSONLogFormat:
+		encoderCfg := zap.NewProductionEncoderConfig()
 		encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoderCfg.EncodeLevel = func(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+			// Verbosity levels are reported in the numeric "v" field, so all of them are encoded as
+			// the info level.
+			if l < zapcore.InfoLevel {
+				l = zapcore.InfoLevel
+			}
+			enc.AppendString(l.String())
+		}
+		encoder = zapcore.NewJSONEncoder(encoderCfg)
+		opts = append(opts, zapr.LogInfoLevel("v"))
+	default:
+		return fmt.Errorf("unsupported log format %q, must be one of %q or %q", logFormat, constants.TextLogFormat, constants.JSONLogFormat)
+	}
+
+	level := logrAtomicLevel(verbosityLevel)
+	if quiet {
+		level = zap.NewAtomicLevelAt(zapcore.WarnLevel)
 	}
 
-	// Build the encoder and logger.
-	consoleEncoder := zapcore.NewConsoleEncoder(encoderCfg)
+	// Build the logger.
 	core := zapcore.NewTee(
-		zapcore.NewCore(consoleEncoder, zapcore.AddSync(os.Stdout), logrAtomicLevel(verbosityLevel)),
+		zapcore.NewCore(encoder, zapcore.AddSync(os.Stdout), level),
 	)
 	logger := zap.New(core)
 
 	// Configure package state so the logger can be used by other packages.
-
-	pkgLogger = zapr.NewLogger(logger)
+	zapLogger = logger
+	pkgLogger = zapr.NewLoggerWithOptions(logger, opts...)
 
 	return nil
 }
@@ -76,6 +116,18 @@ func Info(msg string, keysAndValues ...interface{}) {
 	Get().Info(msg, keysAndValues...)
 }
 
+// Warn logs a message about a recoverable problem, such as a step that was skipped or could not be
+// completed, with the given key/value pairs as context. Warnings are logged regardless of verbosity.
+func Warn(msg string, keysAndValues ...interface{}) {
+	zapLogger.Sugar().Warnw(msg, keysAndValues...)
+}
+
+// Error logs an error with the given message and key/value pairs as context. Errors are logged
+// regardless of verbosity.
+func Error(err error, msg string, keysAndValues ...interface{}) {
+	Get().Error(err, msg, keysAndValues...)
+}
+
 // V returns an Logger value for a specific verbosity level, relative to
 // this Logger. In other words, V values are additive.  V higher verbosity
 // level means a log message is less important.  It's illegal to pass a log
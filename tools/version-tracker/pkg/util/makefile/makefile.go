@@ -0,0 +1,74 @@
+package makefile
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/environment"
+)
+
+// variableCacheKey identifies a Make variable evaluation. The environment is part of the key since Make
+// variables can be overridden by environment variables.
+type variableCacheKey struct {
+	projectRootFilepath string
+	variable            string
+	releaseBranch       string
+	environment         [sha256.Size]byte
+}
+
+var (
+	variableCache      = map[variableCacheKey]string{}
+	variableCacheMutex sync.Mutex
+)
+
+// GetVariableValue returns the value of a Make variable as evaluated by the project Makefile, using
+// the `var-value-%` target. If the release branch is non-empty, it is passed to Make to evaluate
+// release-branched values. Evaluating a Makefile takes a few seconds, so values are memoized for the
+// lifetime of the process and each variable is evaluated only once per project, release branch and
+// environment.
+func GetVariableValue(projectRootFilepath, variable, releaseBranch string) (string, error) {
+	key := variableCacheKey{
+		projectRootFilepath: projectRootFilepath,
+		variable:            variable,
+		releaseBranch:       releaseBranch,
+		environment:         sha256.Sum256([]byte(strings.Join(os.Environ(), "\n"))),
+	}
+
+	variableCacheMutex.Lock()
+	defer variableCacheMutex.Unlock()
+	if value, ok := variableCache[key]; ok {
+		return value, nil
+	}
+
+	args := []string{"-C", projectRootFilepath, "--no-print-directory", fmt.Sprintf("var-value-%s", variable)}
+	if releaseBranch != "" {
+		args = append(args, fmt.Sprintf("RELEASE_BRANCH=%s", releaseBranch))
+	}
+
+	// Only standard output holds the value, since evaluating the Makefile can log warnings to standard error.
+	makeCmd := exec.Command(environment.Make(), args...)
+	value, err := command.ExecCommandOutput(makeCmd)
+	if err != nil {
+		return "", fmt.Errorf("getting value of Make variable %s: %v", variable, err)
+	}
+	variableCache[key] = value
+
+	return value, nil
+}
+
+// InvalidateCache discards the memoized variable values of the given project, for use after files that
+// the project Makefile reads, such as GIT_TAG, have been modified.
+func InvalidateCache(projectRootFilepath string) {
+	variableCacheMutex.Lock()
+	defer variableCacheMutex.Unlock()
+	for key := range variableCache {
+		if key.projectRootFilepath == projectRootFilepath {
+			delete(variableCache, key)
+		}
+	}
+}
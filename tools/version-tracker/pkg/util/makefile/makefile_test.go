@@ -0,0 +1,93 @@
+package makefile
+
+import (
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/command/mocks"
+)
+
+func TestGetVariableValue(t *testing.T) {
+	testcases := []struct {
+		name          string
+		releaseBranch string
+		output        string
+		runErr        error
+		wantArgs      []string
+		wantValue     string
+		wantErr       bool
+	}{
+		{
+			name:      "Project without release branches",
+			output:    "v1.2.3",
+			wantArgs:  []string{"make", "-C", "projects/foo/bar", "--no-print-directory", "var-value-GIT_TAG"},
+			wantValue: "v1.2.3",
+		},
+		{
+			name:          "Release-branched project",
+			releaseBranch: "1-29",
+			output:        "v1.29.0",
+			wantArgs:      []string{"make", "-C", "projects/foo/bar", "--no-print-directory", "var-value-GIT_TAG", "RELEASE_BRANCH=1-29"},
+			wantValue:     "v1.29.0",
+		},
+		{
+			name:     "Make command failure",
+			runErr:   errors.New("exit status 2"),
+			wantArgs: []string{"make", "-C", "projects/foo/bar", "--no-print-directory", "var-value-GIT_TAG"},
+			wantErr:  true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			variableCache = map[variableCacheKey]string{}
+			runner := mocks.NewMockCommandRunner(gomock.NewController(t))
+			defer command.SetRunner(runner)()
+
+			runner.EXPECT().Output(gomock.Any()).DoAndReturn(func(cmd *exec.Cmd) (string, error) {
+				if !reflect.DeepEqual(cmd.Args, tc.wantArgs) {
+					t.Fatalf("Unexpected command arguments. Expected: %v, Got: %v", tc.wantArgs, cmd.Args)
+				}
+				return tc.output, tc.runErr
+			})
+
+			value, err := GetVariableValue("projects/foo/bar", "GIT_TAG", tc.releaseBranch)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Unexpected error. Got: %v", err)
+			}
+			if value != tc.wantValue {
+				t.Fatalf("Unexpected variable value. Expected: %s, Got: %s", tc.wantValue, value)
+			}
+		})
+	}
+}
+
+func TestGetVariableValueCache(t *testing.T) {
+	variableCache = map[variableCacheKey]string{}
+	runner := mocks.NewMockCommandRunner(gomock.NewController(t))
+	defer command.SetRunner(runner)()
+
+	// Each distinct evaluation runs Make once, and an invalidated project is evaluated again.
+	runner.EXPECT().Output(gomock.Any()).Return("v1.2.3", nil).Times(3)
+
+	for i := 0; i < 2; i++ {
+		for _, releaseBranch := range []string{"", "1-29"} {
+			value, err := GetVariableValue("projects/foo/bar", "GIT_TAG", releaseBranch)
+			if err != nil {
+				t.Fatalf("Unexpected error. Got: %v", err)
+			}
+			if value != "v1.2.3" {
+				t.Fatalf("Unexpected variable value. Expected: v1.2.3, Got: %s", value)
+			}
+		}
+	}
+
+	InvalidateCache("projects/foo/bar")
+	if _, err := GetVariableValue("projects/foo/bar", "GIT_TAG", ""); err != nil {
+		t.Fatalf("Unexpected error. Got: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package network
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+)
+
+// systemCABundleFiles are the locations of the system CA bundle on common Linux distributions and macOS, in
+// order of preference.
+var systemCABundleFiles = []string{
+	"/etc/ssl/certs/ca-certificates.crt",
+	"/etc/pki/tls/certs/ca-bundle.crt",
+	"/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem",
+	"/etc/ssl/cert.pem",
+}
+
+// Init configures the network settings used by the tool and the Git and Make commands it runs. Proxy environment
+// variables are set in both their lowercase and uppercase forms, since tools such as curl only read one of them.
+// If a CA bundle is given, its certificates are trusted in addition to the system certificates by the tool's HTTP
+// clients, and a bundle combining both is exported to the commands through the CA bundle environment variables
+// read by Git, curl, the AWS CLI and Go.
+func Init(caBundleFilepath string) error {
+	for _, proxyEnvvar := range constants.ProxyEnvvars {
+		if err := alignProxyEnvvar(proxyEnvvar); err != nil {
+			return err
+		}
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("default HTTP transport is not configurable")
+	}
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if caBundleFilepath == "" {
+		return nil
+	}
+
+	caBundle, err := os.ReadFile(caBundleFilepath)
+	if err != nil {
+		return fmt.Errorf("reading CA bundle: %v", err)
+	}
+
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil || rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if !rootCAs.AppendCertsFromPEM(caBundle) {
+		return fmt.Errorf("no PEM-encoded certificates found in CA bundle %s", caBundleFilepath)
+	}
+
+	// The default transport is shared by the GitHub clients, go-git and the artifact downloads, so updating it
+	// in place applies the CA bundle to all of them.
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.RootCAs = rootCAs
+
+	combinedCABundleFilepath, err := writeCombinedCABundle(caBundle)
+	if err != nil {
+		return fmt.Errorf("writing combined CA bundle: %v", err)
+	}
+	for _, caBundleEnvvar := range constants.CABundleEnvvars {
+		if err := os.Setenv(caBundleEnvvar, combinedCABundleFilepath); err != nil {
+			return fmt.Errorf("setting %s environment variable: %v", caBundleEnvvar, err)
+		}
+	}
+
+	return nil
+}
+
+// alignProxyEnvvar sets the lowercase or uppercase form of the given proxy environment variable to the value of
+// the other form, if only one of them is set.
+func alignProxyEnvvar(proxyEnvvar string) error {
+	lowercaseValue, lowercaseSet := os.LookupEnv(strings.ToLower(proxyEnvvar))
+	uppercaseValue, uppercaseSet := os.LookupEnv(strings.ToUpper(proxyEnvvar))
+
+	switch {
+	case lowercaseSet && !uppercaseSet:
+		return os.Setenv(strings.ToUpper(proxyEnvvar), lowercaseValue)
+	case uppercaseSet && !lowercaseSet:
+		return os.Setenv(strings.ToLower(proxyEnvvar), uppercaseValue)
+	}
+
+	return nil
+}
+
+// writeCombinedCABundle writes the system CA bundle followed by the given CA bundle to a file in the temporary
+// directory and returns its path. The commands read the CA bundle environment variables as a replacement for the
+// system certificates rather than an addition, so both are needed for hosts that are not behind the proxy. The
+// file name is derived from the contents, so repeated runs reuse the same file.
+func writeCombinedCABundle(caBundle []byte) (string, error) {
+	combinedCABundle := []byte{}
+	systemCABundleFilepath := os.Getenv(constants.SSLCertFileEnvvar)
+	if systemCABundleFilepath == "" {
+		for _, candidate := range systemCABundleFiles {
+			if _, err := os.Stat(candidate); err == nil {
+				systemCABundleFilepath = candidate
+				break
+			}
+		}
+	}
+	if systemCABundleFilepath != "" {
+		systemCABundle, err := os.ReadFile(systemCABundleFilepath)
+		if err != nil {
+			return "", fmt.Errorf("reading system CA bundle: %v", err)
+		}
+		combinedCABundle = append(combinedCABundle, systemCABundle...)
+		combinedCABundle = append(combinedCABundle, '\n')
+	}
+	combinedCABundle = append(combinedCABundle, caBundle...)
+
+	combinedCABundleFilepath := filepath.Join(os.TempDir(), fmt.Sprintf("version-tracker-ca-bundle-%x.pem", sha256.Sum256(combinedCABundle)))
+	if err := os.WriteFile(combinedCABundleFilepath, combinedCABundle, 0o644); err != nil {
+		return "", err
+	}
+
+	return combinedCABundleFilepath, nil
+}
@@ -0,0 +1,427 @@
+package patch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/slices"
+)
+
+var (
+	fromLineRegex       = regexp.MustCompile(`^From [0-9a-f]{40} `)
+	subjectPrefixRegex  = regexp.MustCompile(`^\[PATCH[^\]]*\] *`)
+	patchNumberRegex    = regexp.MustCompile(`^[0-9]+/[0-9]+$`)
+	filenameNumberRegex = regexp.MustCompile(`^[0-9]+-`)
+	indexLineRegex      = regexp.MustCompile(`^index [0-9a-f]+\.\.[0-9a-f]+( [0-7]{6})?$`)
+	hunkHeaderRegex     = regexp.MustCompile(`^@@ -[0-9]+(?:,([0-9]+))? \+[0-9]+(?:,([0-9]+))? @@`)
+	// generatedCodeRegex matches the comment marking generated Go code, see https://go.dev/s/generatedcode.
+	generatedCodeRegex = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+)
+
+const (
+	zeroCommit = "0000000000000000000000000000000000000000"
+
+	// licenseHeaderSearchLines is the number of lines at the start of a new file that are searched for a
+	// license header, which leaves room for shebang lines, build constraints and blank lines before it.
+	licenseHeaderSearchLines = 20
+)
+
+// licenseHeaderExtensions are the extensions of the source files that must start with a license header when a
+// patch adds them.
+var licenseHeaderExtensions = []string{".go", ".sh", ".py"}
+
+// exemptDirectories are the directories holding code copied from other repositories, which keeps the headers
+// and formatting of the repositories it comes from, and test inputs.
+var exemptDirectories = []string{"vendor", "third_party", "testdata"}
+
+// AddedFile represents a file that a patch creates, along with the lines the patch adds to it.
+type AddedFile struct {
+	Path  string
+	Lines []string
+}
+
+// LintFinding represents a problem found in a patch, along with the line of the patch file it is on.
+type LintFinding struct {
+	Line    int
+	Check   string
+	Message string
+}
+
+// Normalize rewrites git format-patch output so that regenerating the same commits produces identical
+// patch files regardless of the machine or Git version used. It enforces LF line endings, zeroes the
+// commit hash in the From line, strips trailing whitespace from the commit message, and removes the
+// blob index lines and the Git version signature, which vary with Git's abbreviation length and version.
+// Diff content is left untouched since whitespace in it is significant.
+func Normalize(contents string) string {
+	lines := strings.Split(strings.ReplaceAll(contents, "\r\n", "\n"), "\n")
+
+	// Drop the signature separator and the Git version that follows it at the end of the patch.
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) >= 2 && lines[len(lines)-2] == "-- " {
+		lines = lines[:len(lines)-2]
+	}
+
+	normalizedLines := make([]string, 0, len(lines))
+	inMessage, inDiffHeader := true, false
+	for _, line := range lines {
+		if strings.HasPrefix(line, "diff --git ") {
+			inMessage, inDiffHeader = false, true
+		} else if strings.HasPrefix(line, "@@ ") {
+			inDiffHeader = false
+		}
+
+		switch {
+		case inMessage && fromLineRegex.MatchString(line):
+			line = fmt.Sprintf("From %s %s", zeroCommit, line[len("From ")+len(zeroCommit)+1:])
+		case inMessage:
+			line = strings.TrimRight(line, " \t")
+		case inDiffHeader && indexLineRegex.MatchString(line):
+			continue
+		}
+		normalizedLines = append(normalizedLines, line)
+	}
+
+	return strings.Join(normalizedLines, "\n") + "\n"
+}
+
+// NormalizeFile normalizes the patch file at the given path in place.
+func NormalizeFile(patchFilepath string) error {
+	contents, err := os.ReadFile(patchFilepath)
+	if err != nil {
+		return fmt.Errorf("reading patch file %s: %v", patchFilepath, err)
+	}
+
+	fileInfo, err := os.Stat(patchFilepath)
+	if err != nil {
+		return fmt.Errorf("unable to stat patch file %s: %v", patchFilepath, err)
+	}
+
+	err = os.WriteFile(patchFilepath, []byte(Normalize(string(contents))), fileInfo.Mode())
+	if err != nil {
+		return fmt.Errorf("writing patch file %s: %v", patchFilepath, err)
+	}
+
+	return nil
+}
+
+// Header returns the author and the subject of the commit in the given patch, without the [PATCH n/m]
+// prefix git format-patch adds to the subject.
+func Header(contents string) (string, string) {
+	var author, subject string
+	inSubject := false
+	for _, line := range strings.Split(strings.ReplaceAll(contents, "\r\n", "\n"), "\n") {
+		// The headers end at the first empty line, and long subjects are folded into indented lines.
+		if line == "" {
+			break
+		}
+		if inSubject && strings.HasPrefix(line, " ") {
+			subject += line
+			continue
+		}
+		inSubject = false
+
+		if value, found := strings.CutPrefix(line, "From: "); found {
+			author = value
+		} else if value, found := strings.CutPrefix(line, "Subject: "); found {
+			subject, inSubject = value, true
+		}
+	}
+
+	return author, subjectPrefixRegex.ReplaceAllString(subject, "")
+}
+
+// Numbered returns whether the subject of the given patch has a [PATCH n/m] prefix, as git format-patch adds to
+// the subjects of a series of more than one patch unless told not to.
+func Numbered(contents string) bool {
+	for _, line := range strings.Split(contents, "\n") {
+		// The headers end at the first empty line.
+		if strings.TrimSuffix(line, "\r") == "" {
+			break
+		}
+		if subject, found := strings.CutPrefix(line, "Subject: "); found {
+			prefixWords := strings.Fields(strings.Trim(strings.TrimSpace(subjectPrefixRegex.FindString(subject)), "[]"))
+			return len(prefixWords) > 0 && patchNumberRegex.MatchString(prefixWords[len(prefixWords)-1])
+		}
+	}
+
+	return false
+}
+
+// Renumber returns the given patch with the [PATCH n/m] prefix of its subject set to the given position in a series
+// of the given number of patches, numbered the way git format-patch numbers them: the number is zero-padded to the
+// width of the total, and a patch that is alone in its series is prefixed with [PATCH] only. Other words in the
+// prefix, such as a version or RFC marker, are kept, and a subject without a prefix gets one.
+func Renumber(contents string, number, total int) string {
+	lines := strings.Split(contents, "\n")
+	for i, line := range lines {
+		// The headers end at the first empty line.
+		if strings.TrimSuffix(line, "\r") == "" {
+			break
+		}
+		subject, found := strings.CutPrefix(line, "Subject: ")
+		if !found {
+			continue
+		}
+
+		prefix := subjectPrefixRegex.FindString(subject)
+		prefixWords := []string{"PATCH"}
+		if prefix != "" {
+			prefixWords = strings.Fields(strings.Trim(strings.TrimSpace(prefix), "[]"))
+			if patchNumberRegex.MatchString(prefixWords[len(prefixWords)-1]) {
+				prefixWords = prefixWords[:len(prefixWords)-1]
+			}
+		}
+		if total > 1 {
+			prefixWords = append(prefixWords, fmt.Sprintf("%0*d/%d", len(strconv.Itoa(total)), number, total))
+		}
+		lines[i] = fmt.Sprintf("Subject: [%s] %s", strings.Join(prefixWords, " "), strings.TrimPrefix(subject, prefix))
+		break
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// RenumberFilename returns the filename of the patch with the given filename at the given position in its series,
+// prefixed with the four-digit number git format-patch prefixes patch filenames with.
+func RenumberFilename(filename string, number int) string {
+	return fmt.Sprintf("%04d-%s", number, filenameNumberRegex.ReplaceAllString(filename, ""))
+}
+
+// AddedFiles returns the files created by the given patch, in the order they appear in it.
+func AddedFiles(contents string) []AddedFile {
+	diffHeaderRegex := regexp.MustCompile(constants.PatchDiffHeaderRegex)
+
+	addedFiles := []AddedFile{}
+	var current *AddedFile
+	inHunk := false
+	for _, line := range strings.Split(strings.ReplaceAll(contents, "\r\n", "\n"), "\n") {
+		if match := diffHeaderRegex.FindStringSubmatch(line); match != nil {
+			current, inHunk = nil, false
+			// The new file mode line that follows the diff header tells whether the file is created.
+			addedFiles = append(addedFiles, AddedFile{Path: match[2]})
+			continue
+		}
+
+		switch {
+		case len(addedFiles) == 0:
+		case !inHunk && strings.HasPrefix(line, "new file mode "):
+			current = &addedFiles[len(addedFiles)-1]
+			current.Lines = []string{}
+		case strings.HasPrefix(line, "@@ "):
+			inHunk = true
+		case inHunk && current != nil && strings.HasPrefix(line, "+"):
+			current.Lines = append(current.Lines, line[1:])
+		}
+	}
+
+	newFiles := []AddedFile{}
+	for _, addedFile := range addedFiles {
+		if addedFile.Lines != nil {
+			newFiles = append(newFiles, addedFile)
+		}
+	}
+
+	return newFiles
+}
+
+// MissingLicenseHeaders returns the paths of the source files created by the given patch that do not start with
+// a license header. Files are considered to have a license header if a copyright notice or an SPDX license
+// identifier appears in their first lines, so that files carrying the upstream project's own header pass as well.
+// Generated code and files in vendored and test data directories are not checked.
+func MissingLicenseHeaders(contents string) []string {
+	missingLicenseHeaders := []string{}
+	for _, addedFile := range AddedFiles(contents) {
+		if !requiresLicenseHeader(addedFile.Path) {
+			continue
+		}
+
+		headerLines := addedFile.Lines
+		if len(headerLines) > licenseHeaderSearchLines {
+			headerLines = headerLines[:licenseHeaderSearchLines]
+		}
+		header := strings.Join(headerLines, "\n")
+		if generatedCodeRegex.MatchString(header) {
+			continue
+		}
+		if !strings.Contains(header, "Copyright") && !strings.Contains(header, "SPDX-License-Identifier") {
+			missingLicenseHeaders = append(missingLicenseHeaders, addedFile.Path)
+		}
+	}
+
+	return missingLicenseHeaders
+}
+
+// requiresLicenseHeader returns whether a file created by a patch at the given path must start with a license
+// header.
+func requiresLicenseHeader(path string) bool {
+	if inExemptDirectory(path) {
+		return false
+	}
+
+	return slices.Contains(licenseHeaderExtensions, filepath.Ext(path))
+}
+
+// inExemptDirectory returns whether the file at the given path is in a vendored or test data directory.
+func inExemptDirectory(path string) bool {
+	for _, directory := range strings.Split(filepath.ToSlash(filepath.Dir(path)), "/") {
+		if slices.Contains(exemptDirectories, directory) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Lint checks the given patch for problems that git am either rejects or silently carries into the patched
+// repository: trailing whitespace and CRLF line endings on added lines, files left without a final newline,
+// file mode changes, absolute paths in the diff headers, CRLF line endings in the patch headers and a patch file
+// that does not end with a newline. Added lines ending with CRLF are allowed in files that already use CRLF line
+// endings upstream, and the contents of files in vendored and test data directories are not checked. Findings are
+// sorted by line.
+func Lint(contents string) []LintFinding {
+	diffHeaderRegex := regexp.MustCompile(constants.PatchDiffHeaderRegex)
+
+	findings := []LintFinding{}
+	addFinding := func(line int, check, format string, args ...interface{}) {
+		findings = append(findings, LintFinding{Line: line, Check: check, Message: fmt.Sprintf(format, args...)})
+	}
+
+	lines := strings.Split(contents, "\n")
+	if strings.HasSuffix(contents, "\n") {
+		lines = lines[:len(lines)-1]
+	} else if contents != "" {
+		addFinding(len(lines), constants.MissingFinalNewlinePatchCheck, "patch file does not end with a newline")
+	}
+
+	var path, oldMode string
+	inDiff, crlfHeaders := false, false
+	oldLines, newLines := 0, 0
+	var previousHunkLine byte
+	// Whether a file uses CRLF line endings upstream is only known once its context or removed lines are seen,
+	// so added lines ending with CRLF are checked at the end of each file's diff.
+	crlfFile, oldMissingNewline, crlfAddedLine := false, false, 0
+	endFile := func() {
+		if crlfAddedLine > 0 && !crlfFile && !inExemptDirectory(path) {
+			addFinding(crlfAddedLine, constants.CRLFPatchCheck, "lines added to %s end with CRLF", path)
+		}
+		crlfFile, oldMissingNewline, crlfAddedLine = false, false, 0
+	}
+	for i, rawLine := range lines {
+		lineNumber := i + 1
+		line, hasCR := strings.CutSuffix(rawLine, "\r")
+
+		// The marker for a missing newline follows the last line of a file, which can end the hunk.
+		if inDiff && strings.HasPrefix(line, "\\ ") {
+			switch previousHunkLine {
+			case '-':
+				oldMissingNewline = true
+			case '+':
+				if !oldMissingNewline && !inExemptDirectory(path) {
+					addFinding(lineNumber, constants.MissingFinalNewlinePatchCheck, "%s does not end with a newline after the patch", path)
+				}
+			}
+			continue
+		}
+
+		if oldLines > 0 || newLines > 0 {
+			// Empty lines in hunks are context lines whose leading space was stripped.
+			previousHunkLine = ' '
+			if line != "" {
+				previousHunkLine = line[0]
+			}
+			switch previousHunkLine {
+			case '-':
+				oldLines--
+				crlfFile = crlfFile || hasCR
+			case '+':
+				newLines--
+				added := line[1:]
+				if strings.TrimRight(added, " \t") != added && !inExemptDirectory(path) {
+					addFinding(lineNumber, constants.TrailingWhitespacePatchCheck, "line added to %s has trailing whitespace", path)
+				}
+				if hasCR && crlfAddedLine == 0 {
+					crlfAddedLine = lineNumber
+				}
+			default:
+				oldLines--
+				newLines--
+				crlfFile = crlfFile || hasCR
+			}
+			continue
+		}
+
+		if hasCR && !crlfHeaders {
+			crlfHeaders = true
+			addFinding(lineNumber, constants.CRLFPatchCheck, "patch headers end with CRLF")
+		}
+
+		if strings.HasPrefix(line, "diff ") {
+			endFile()
+			inDiff, path, oldMode = true, "", ""
+			headerPaths := strings.Fields(line)[1:]
+			if match := diffHeaderRegex.FindStringSubmatch(line); match != nil {
+				path, headerPaths = match[2], match[1:]
+			}
+			for _, headerPath := range headerPaths {
+				checkAbsolutePath(lineNumber, headerPath, addFinding)
+			}
+			continue
+		}
+		if !inDiff {
+			continue
+		}
+
+		if match := hunkHeaderRegex.FindStringSubmatch(line); match != nil {
+			oldLines, newLines = 1, 1
+			fmt.Sscan(match[1], &oldLines)
+			fmt.Sscan(match[2], &newLines)
+			continue
+		}
+		if value, found := strings.CutPrefix(line, "old mode "); found {
+			oldMode = value
+		} else if value, found := strings.CutPrefix(line, "new mode "); found {
+			addFinding(lineNumber, constants.FileModeChangePatchCheck, "file mode of %s changes from %s to %s", path, oldMode, value)
+		}
+		for _, prefix := range []string{"--- ", "+++ ", "rename from ", "rename to ", "copy from ", "copy to "} {
+			if value, found := strings.CutPrefix(line, prefix); found {
+				headerPath, _, _ := strings.Cut(value, "\t")
+				if path == "" && prefix == "+++ " {
+					path = strings.TrimPrefix(headerPath, "b/")
+				}
+				checkAbsolutePath(lineNumber, headerPath, addFinding)
+			}
+		}
+	}
+	endFile()
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Line < findings[j].Line
+	})
+
+	return findings
+}
+
+// checkAbsolutePath adds a finding if the given path from a diff header is absolute once the a/ or b/ prefix
+// git adds is removed, since git am cannot apply such a diff to the repository.
+func checkAbsolutePath(line int, path string, addFinding func(int, string, string, ...interface{})) {
+	if path == "/dev/null" {
+		return
+	}
+	for _, prefix := range []string{"a/", "b/"} {
+		if trimmed, found := strings.CutPrefix(path, prefix); found {
+			path = trimmed
+			break
+		}
+	}
+	if strings.HasPrefix(path, "/") {
+		addFinding(line, constants.AbsolutePathPatchCheck, "diff header refers to absolute path %s", path)
+	}
+}
@@ -0,0 +1,218 @@
+package patch
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+)
+
+const newFilesPatch = `From 0000000000000000000000000000000000000000 Mon Sep 17 00:00:00 2001
+From: Jane Doe <jane@example.com>
+Date: Mon, 1 Jan 2024 00:00:00 +0000
+Subject: [PATCH] Add new files
+
+---
+diff --git a/pkg/licensed.go b/pkg/licensed.go
+new file mode 100644
+--- /dev/null
++++ b/pkg/licensed.go
+@@ -0,0 +1,5 @@
++/*
++Copyright The Kubernetes Authors.
++*/
++
++package pkg
+diff --git a/pkg/unlicensed.go b/pkg/unlicensed.go
+new file mode 100644
+--- /dev/null
++++ b/pkg/unlicensed.go
+@@ -0,0 +1,2 @@
++package pkg
++
+diff --git a/pkg/existing.go b/pkg/existing.go
+--- a/pkg/existing.go
++++ b/pkg/existing.go
+@@ -1,2 +1,3 @@
+ package pkg
++
++func existing() {}
+diff --git a/pkg/zz_generated.go b/pkg/zz_generated.go
+new file mode 100644
+--- /dev/null
++++ b/pkg/zz_generated.go
+@@ -0,0 +1,3 @@
++// Code generated by controller-gen. DO NOT EDIT.
++
++package pkg
+diff --git a/vendor/example.com/lib/lib.go b/vendor/example.com/lib/lib.go
+new file mode 100644
+--- /dev/null
++++ b/vendor/example.com/lib/lib.go
+@@ -0,0 +1 @@
++package lib
+diff --git a/hack/run.sh b/hack/run.sh
+new file mode 100755
+--- /dev/null
++++ b/hack/run.sh
+@@ -0,0 +1,2 @@
++#!/usr/bin/env bash
++echo run
+diff --git a/docs/README.md b/docs/README.md
+new file mode 100644
+--- /dev/null
++++ b/docs/README.md
+@@ -0,0 +1 @@
++# Docs
+`
+
+func TestMissingLicenseHeaders(t *testing.T) {
+	want := []string{"pkg/unlicensed.go", "hack/run.sh"}
+	if got := MissingLicenseHeaders(newFilesPatch); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Unexpected files missing license headers. Want: %v, got: %v", want, got)
+	}
+}
+
+func TestAddedFiles(t *testing.T) {
+	addedFiles := AddedFiles(newFilesPatch)
+
+	wantPaths := []string{"pkg/licensed.go", "pkg/unlicensed.go", "pkg/zz_generated.go", "vendor/example.com/lib/lib.go", "hack/run.sh", "docs/README.md"}
+	gotPaths := []string{}
+	for _, addedFile := range addedFiles {
+		gotPaths = append(gotPaths, addedFile.Path)
+	}
+	if !reflect.DeepEqual(gotPaths, wantPaths) {
+		t.Fatalf("Unexpected added files. Want: %v, got: %v", wantPaths, gotPaths)
+	}
+
+	wantLines := []string{"package pkg", ""}
+	if !reflect.DeepEqual(addedFiles[1].Lines, wantLines) {
+		t.Fatalf("Unexpected lines added to %s. Want: %q, got: %q", addedFiles[1].Path, wantLines, addedFiles[1].Lines)
+	}
+}
+
+func TestHeader(t *testing.T) {
+	contents := `From 0000000000000000000000000000000000000000 Mon Sep 17 00:00:00 2001
+From: Jane Doe <jane@example.com>
+Date: Mon, 1 Jan 2024 00:00:00 +0000
+Subject: [PATCH 02/10] Add support for a long feature description that git
+ format-patch folds
+
+---
+`
+	author, subject := Header(contents)
+	if author != "Jane Doe <jane@example.com>" {
+		t.Fatalf("Unexpected author. Got: %s", author)
+	}
+	if subject != "Add support for a long feature description that git format-patch folds" {
+		t.Fatalf("Unexpected subject. Got: %s", subject)
+	}
+}
+
+func TestLint(t *testing.T) {
+	contents := strings.Join([]string{
+		"From 0000000000000000000000000000000000000000 Mon Sep 17 00:00:00 2001",
+		"From: Jane Doe <jane@example.com>",
+		"Subject: [PATCH] Update files",
+		"",
+		"---",
+		"diff --git a/pkg/whitespace.go b/pkg/whitespace.go",
+		"--- a/pkg/whitespace.go",
+		"+++ b/pkg/whitespace.go",
+		"@@ -1,2 +1,3 @@",
+		" package pkg",
+		"",
+		"+var whitespace = true ",
+		"diff --git a/hack/run.sh b/hack/run.sh",
+		"old mode 100755",
+		"new mode 100644",
+		"diff --git a/docs/README.md b/docs/README.md",
+		"--- a/docs/README.md",
+		"+++ b/docs/README.md",
+		"@@ -1 +1,2 @@",
+		"-# Docs",
+		"+# Docs\r",
+		"+More docs",
+		"\\ No newline at end of file",
+		"diff --git a/scripts/run.bat b/scripts/run.bat",
+		"--- a/scripts/run.bat",
+		"+++ b/scripts/run.bat",
+		"@@ -1 +1 @@",
+		"-echo run\r",
+		"\\ No newline at end of file",
+		"+echo run all\r",
+		"\\ No newline at end of file",
+		"diff --git a/vendor/example.com/lib/lib.go b/vendor/example.com/lib/lib.go",
+		"--- a/vendor/example.com/lib/lib.go",
+		"+++ b/vendor/example.com/lib/lib.go",
+		"@@ -1 +1 @@",
+		"-package lib",
+		"+package lib\t",
+		"--- /home/jane/src/pkg/absolute.go",
+		"+++ /home/jane/src/pkg/absolute.go",
+		"@@ -1 +1 @@",
+		"-package pkg",
+		"+package absolute",
+	}, "\n")
+
+	want := []LintFinding{
+		{Line: 12, Check: constants.TrailingWhitespacePatchCheck, Message: "line added to pkg/whitespace.go has trailing whitespace"},
+		{Line: 15, Check: constants.FileModeChangePatchCheck, Message: "file mode of hack/run.sh changes from 100755 to 100644"},
+		{Line: 21, Check: constants.CRLFPatchCheck, Message: "lines added to docs/README.md end with CRLF"},
+		{Line: 23, Check: constants.MissingFinalNewlinePatchCheck, Message: "docs/README.md does not end with a newline after the patch"},
+		{Line: 38, Check: constants.AbsolutePathPatchCheck, Message: "diff header refers to absolute path /home/jane/src/pkg/absolute.go"},
+		{Line: 39, Check: constants.AbsolutePathPatchCheck, Message: "diff header refers to absolute path /home/jane/src/pkg/absolute.go"},
+		{Line: 42, Check: constants.MissingFinalNewlinePatchCheck, Message: "patch file does not end with a newline"},
+	}
+	if got := Lint(contents); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Unexpected lint findings. Want: %+v, got: %+v", want, got)
+	}
+
+	if got := Lint("Subject: [PATCH] Update files\r\n\r\n---\r\n"); len(got) != 1 || got[0].Check != constants.CRLFPatchCheck || got[0].Line != 1 {
+		t.Fatalf("Unexpected lint findings for patch with CRLF headers. Got: %+v", got)
+	}
+}
+
+func TestRenumber(t *testing.T) {
+	testCases := []struct {
+		name    string
+		subject string
+		number  int
+		total   int
+		want    string
+	}{
+		{name: "numbered patch", subject: "[PATCH 2/4] Add feature", number: 3, total: 12, want: "[PATCH 03/12] Add feature"},
+		{name: "versioned patch", subject: "[PATCH v2 1/3] Add feature", number: 2, total: 2, want: "[PATCH v2 2/2] Add feature"},
+		{name: "unnumbered patch", subject: "[PATCH] Add feature", number: 1, total: 3, want: "[PATCH 1/3] Add feature"},
+		{name: "subject without prefix", subject: "Add feature", number: 2, total: 3, want: "[PATCH 2/3] Add feature"},
+		{name: "single patch", subject: "[PATCH 1/2] Add feature", number: 1, total: 1, want: "[PATCH] Add feature"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			contents := "From 0000000000000000000000000000000000000000 Mon Sep 17 00:00:00 2001\nFrom: Jane Doe <jane@example.com>\nSubject: " + tc.subject + "\n\nSubject: [PATCH 1/1] in the commit message\n"
+			want := "From 0000000000000000000000000000000000000000 Mon Sep 17 00:00:00 2001\nFrom: Jane Doe <jane@example.com>\nSubject: " + tc.want + "\n\nSubject: [PATCH 1/1] in the commit message\n"
+			if got := Renumber(contents, tc.number, tc.total); got != want {
+				t.Fatalf("Unexpected renumbered patch. Want: %q, got: %q", want, got)
+			}
+		})
+	}
+}
+
+func TestNumbered(t *testing.T) {
+	if !Numbered("From: Jane Doe <jane@example.com>\nSubject: [PATCH v2 03/12] Add feature\n\n") {
+		t.Fatalf("Expected patch with [PATCH v2 03/12] subject prefix to be numbered")
+	}
+	if Numbered("From: Jane Doe <jane@example.com>\nSubject: [PATCH] Add feature\n\nSubject: [PATCH 1/2] in the commit message\n") {
+		t.Fatalf("Expected patch with [PATCH] subject prefix not to be numbered")
+	}
+}
+
+func TestRenumberFilename(t *testing.T) {
+	if got := RenumberFilename("0007-Add-feature.patch", 3); got != "0003-Add-feature.patch" {
+		t.Fatalf("Unexpected renumbered filename. Got: %s", got)
+	}
+	if got := RenumberFilename("add-feature.patch", 12); got != "0012-add-feature.patch" {
+		t.Fatalf("Unexpected renumbered filename. Got: %s", got)
+	}
+}
@@ -0,0 +1,44 @@
+package progress
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+)
+
+// Start logs a progress event with the elapsed time at a regular interval until the returned function is
+// called, so that operators can tell long operations such as clones, patch application and builds apart from
+// a hung command. Operations that finish within the interval log nothing. The events are logged at the info
+// level with the given key/value pairs, so they are suppressed by the --quiet flag.
+func Start(operation string, keysAndValues ...interface{}) func() {
+	startTime := time.Now()
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(constants.ProgressReportInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				logger.Info(fmt.Sprintf("Still %s", operation), append([]interface{}{"Elapsed", time.Since(startTime).Round(time.Second).String()}, keysAndValues...)...)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+	}
+}
+
+// Fraction formats the number of completed items out of the total, along with the completed percentage, for
+// progress events of loops over projects, release branches or patches.
+func Fraction(completed, total int) string {
+	if total == 0 {
+		return "0/0"
+	}
+
+	return fmt.Sprintf("%d/%d (%d%%)", completed, total, completed*100/total)
+}
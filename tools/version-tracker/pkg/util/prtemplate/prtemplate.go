@@ -0,0 +1,77 @@
+package prtemplate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/types"
+)
+
+// Load reads and unmarshals the pull request templates file at the given path.
+func Load(templatesFilepath string) (*types.PullRequestTemplates, error) {
+	contents, err := os.ReadFile(templatesFilepath)
+	if err != nil {
+		return nil, fmt.Errorf("reading pull request templates file: %v", err)
+	}
+
+	var pullRequestTemplates types.PullRequestTemplates
+	err = yaml.Unmarshal(contents, &pullRequestTemplates)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling pull request templates file: %v", err)
+	}
+
+	return &pullRequestTemplates, nil
+}
+
+// Render executes the title and body templates for the project in the given data, using the project-specific
+// templates if present and the default templates otherwise. If neither defines a template, the default title or
+// body in the data is returned as is.
+func Render(pullRequestTemplates *types.PullRequestTemplates, data types.PullRequestTemplateData) (string, string, error) {
+	titleTemplate := pullRequestTemplates.Default.Title
+	bodyTemplate := pullRequestTemplates.Default.Body
+	if projectTemplate, ok := pullRequestTemplates.Projects[data.Project]; ok {
+		if projectTemplate.Title != "" {
+			titleTemplate = projectTemplate.Title
+		}
+		if projectTemplate.Body != "" {
+			bodyTemplate = projectTemplate.Body
+		}
+	}
+
+	title, err := execute("title", titleTemplate, data.DefaultTitle, data)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := execute("body", bodyTemplate, data.DefaultBody, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Pull request titles are single-line, so collapse any newlines introduced by the template.
+	return strings.Join(strings.Fields(title), " "), body, nil
+}
+
+// execute renders the given template text with the data, or returns the default value if the template is empty.
+func execute(name, text, defaultValue string, data types.PullRequestTemplateData) (string, error) {
+	if text == "" {
+		return defaultValue, nil
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parsing pull request %s template: %v", name, err)
+	}
+
+	var b bytes.Buffer
+	err = tmpl.Execute(&b, data)
+	if err != nil {
+		return "", fmt.Errorf("executing pull request %s template: %v", name, err)
+	}
+
+	return b.String(), nil
+}
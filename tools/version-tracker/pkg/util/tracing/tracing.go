@@ -0,0 +1,151 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/buildinfo"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/cleanup"
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/pkg/util/logger"
+)
+
+// Span represents a timed step of a command, such as cloning a repository or creating a pull request.
+type Span struct {
+	span          trace.Span
+	parentContext context.Context
+}
+
+var (
+	provider       *sdktrace.TracerProvider
+	currentContext = context.Background()
+	openSpans      []*Span
+	mutex          sync.Mutex
+)
+
+// Init configures the OTLP HTTP trace exporter if an OTLP endpoint is set in the environment. The exporter
+// is configured with the standard OpenTelemetry environment variables, such as OTEL_EXPORTER_OTLP_ENDPOINT
+// and OTEL_EXPORTER_OTLP_HEADERS. Without an endpoint, spans are not recorded.
+func Init() error {
+	if os.Getenv(constants.OTLPEndpointEnvvar) == "" && os.Getenv(constants.OTLPTracesEndpointEnvvar) == "" {
+		return nil
+	}
+
+	ctx := context.Background()
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return fmt.Errorf("creating OTLP trace exporter: %v", err)
+	}
+
+	// Resource attributes from the environment, such as OTEL_SERVICE_NAME, take precedence over the defaults.
+	traceResource, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", constants.TracingServiceName), attribute.String("service.version", buildinfo.Get().Version)),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return fmt.Errorf("creating trace resource: %v", err)
+	}
+
+	mutex.Lock()
+	provider = sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(traceResource))
+	mutex.Unlock()
+	otel.SetTracerProvider(provider)
+
+	// Export the spans recorded so far if the command is interrupted.
+	cleanup.Register("Exporting trace spans", func() error {
+		Shutdown()
+		return nil
+	})
+
+	return nil
+}
+
+// Start starts a span with the given name as a child of the innermost open span. The key-value pairs are
+// recorded as span attributes, in the same form as logger key-value pairs. Spans are nested in the order
+// they are started, so they must be ended in the reverse order, from the goroutine that started them.
+func Start(name string, keysAndValues ...interface{}) *Span {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	parentContext := currentContext
+	ctx, span := otel.Tracer(constants.TracingServiceName).Start(parentContext, name, trace.WithAttributes(toAttributes(keysAndValues)...))
+	currentContext = ctx
+
+	s := &Span{span: span, parentContext: parentContext}
+	openSpans = append(openSpans, s)
+
+	return s
+}
+
+// SetAttributes records the given key-value pairs as span attributes.
+func (s *Span) SetAttributes(keysAndValues ...interface{}) {
+	s.span.SetAttributes(toAttributes(keysAndValues)...)
+}
+
+// End ends the span, marking it as failed if the given error is non-nil.
+func (s *Span) End(err error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	s.end(err)
+}
+
+// end ends the span and makes its parent the innermost open span. Callers must hold the mutex.
+func (s *Span) end(err error) {
+	for i, openSpan := range openSpans {
+		if openSpan == s {
+			openSpans = append(openSpans[:i], openSpans[i+1:]...)
+			break
+		}
+	}
+
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+	currentContext = s.parentContext
+}
+
+// Shutdown ends any spans that are still open and exports all recorded spans. It must be called before the
+// command exits, since spans are exported in batches.
+func Shutdown() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if provider == nil {
+		return
+	}
+
+	for len(openSpans) > 0 {
+		openSpans[len(openSpans)-1].end(fmt.Errorf("command exited before the span ended"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.TracingShutdownTimeout)
+	defer cancel()
+	if err := provider.Shutdown(ctx); err != nil {
+		logger.Warn("Unable to export trace spans", "Error", err)
+	}
+	provider = nil
+}
+
+// toAttributes converts logger-style key-value pairs to span attributes, formatting values as strings.
+func toAttributes(keysAndValues []interface{}) []attribute.KeyValue {
+	attributes := make([]attribute.KeyValue, 0, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		attributes = append(attributes, attribute.String(fmt.Sprint(keysAndValues[i]), fmt.Sprint(keysAndValues[i+1])))
+	}
+
+	return attributes
+}
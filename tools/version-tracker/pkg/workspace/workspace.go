@@ -0,0 +1,112 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/eks-anywhere-build-tooling/tools/version-tracker/api/constants"
+)
+
+var (
+	repoRoot          string
+	dir               string
+	worktreeCheckouts bool
+	cloneMode         = constants.FullCloneMode
+)
+
+// Init configures the workspace used by the commands. If the repository root is non-empty, the commands operate
+// on the existing build-tooling checkout at that path instead of cloning the repository. The workspace directory
+// holds the build-tooling clone and the tool's downloads and temporary upstream checkouts, and defaults to the
+// current working directory. If worktree checkouts are enabled, upstream repositories checked out by the project
+// Makefiles are created as worktrees of mirrors kept in the workspace directory. The clone mode determines how
+// much of the upstream repositories is downloaded.
+func Init(buildToolingRepoRoot, workspaceDir string, useWorktreeCheckouts bool, upstreamCloneMode string) error {
+	worktreeCheckouts = useWorktreeCheckouts
+
+	switch upstreamCloneMode {
+	case constants.FullCloneMode:
+	case constants.PartialCloneMode, constants.ShallowCloneMode:
+		// The project Makefiles cannot check out arbitrary Git tags or commits from shallow clones, so they make
+		// partial clones in both modes. The filter is passed to them through the environment of the Make commands.
+		if _, ok := os.LookupEnv(constants.RepoCloneFilterEnvvar); !ok {
+			if err := os.Setenv(constants.RepoCloneFilterEnvvar, constants.PartialCloneFilter); err != nil {
+				return fmt.Errorf("setting %s environment variable: %v", constants.RepoCloneFilterEnvvar, err)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid clone mode %s, must be one of %s, %s or %s", upstreamCloneMode, constants.FullCloneMode, constants.PartialCloneMode, constants.ShallowCloneMode)
+	}
+	cloneMode = upstreamCloneMode
+
+	if workspaceDir != "" {
+		absoluteWorkspaceDir, err := filepath.Abs(workspaceDir)
+		if err != nil {
+			return fmt.Errorf("getting absolute path of workspace directory: %v", err)
+		}
+		if err := os.MkdirAll(absoluteWorkspaceDir, 0o755); err != nil {
+			return fmt.Errorf("creating workspace directory: %v", err)
+		}
+		dir = absoluteWorkspaceDir
+	}
+
+	if buildToolingRepoRoot != "" {
+		absoluteRepoRoot, err := filepath.Abs(buildToolingRepoRoot)
+		if err != nil {
+			return fmt.Errorf("getting absolute path of build-tooling repository root: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(absoluteRepoRoot, ".git")); err != nil {
+			return fmt.Errorf("%s is not a Git repository: %v", absoluteRepoRoot, err)
+		}
+		repoRoot = absoluteRepoRoot
+	}
+
+	return nil
+}
+
+// Dir returns the workspace directory, which defaults to the current working directory.
+func Dir() (string, error) {
+	if dir != "" {
+		return dir, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("retrieving current working directory: %v", err)
+	}
+
+	return cwd, nil
+}
+
+// BuildToolingRepoPath returns the path of the build-tooling repository checkout the commands operate on, which
+// is either the configured repository root or a clone in the workspace directory.
+func BuildToolingRepoPath() (string, error) {
+	if repoRoot != "" {
+		return repoRoot, nil
+	}
+
+	workspaceDir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(workspaceDir, constants.BuildToolingRepoName), nil
+}
+
+// ExistingCheckout returns whether the commands operate on an existing build-tooling checkout given as the
+// repository root, rather than on a clone in the workspace directory that the tool manages.
+func ExistingCheckout() bool {
+	return repoRoot != ""
+}
+
+// WorktreeCheckouts returns whether upstream repositories are checked out as worktrees of mirrors in the
+// workspace directory instead of being cloned by the project Makefiles.
+func WorktreeCheckouts() bool {
+	return worktreeCheckouts
+}
+
+// CloneMode returns how upstream repositories are cloned: fully, as partial clones that download file contents
+// on demand, or as shallow clones of the revisions being checked out.
+func CloneMode() string {
+	return cloneMode
+}